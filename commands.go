@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/organization/dtos"
+	organizationrepository "github.com/laksanagusta/identity/internal/organization/repository"
+	organizationusecase "github.com/laksanagusta/identity/internal/organization/usecase"
+	"github.com/laksanagusta/identity/pkg/database"
+)
+
+// commandUsername is recorded as the updater of rows a command writes
+const commandUsername = "cli"
+
+// runCommand runs the maintenance command in args and returns the exit code. Supported:
+//
+//	identity organizations check-hierarchy [-repair]
+func runCommand(ctx context.Context, db *sqlx.DB, args []string, out io.Writer) int {
+	if len(args) >= 2 && args[0] == "organizations" && args[1] == "check-hierarchy" {
+		return checkOrganizationHierarchy(ctx, db, args[2:], out)
+	}
+
+	fmt.Fprintln(out, "usage: identity organizations check-hierarchy [-repair]")
+	return 2
+}
+
+// checkOrganizationHierarchy prints the hierarchy issues by type and exits non zero while any is left
+func checkOrganizationHierarchy(ctx context.Context, db *sqlx.DB, args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("check-hierarchy", flag.ContinueOnError)
+	flags.SetOutput(out)
+	repair := flags.Bool("repair", false, "recompute path, level and closure rows from parent_uuid")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	organizationUseCase := organizationusecase.NewOrganizationUseCase(organizationusecase.UseCaseParameter{
+		OrganizationRepo: organizationrepository.NewOrganizationRepo(db),
+		TxManager:        database.NewManager(db),
+	})
+
+	report, err := organizationUseCase.CheckHierarchy(ctx, entities.AuthenticatedUser{Username: commandUsername}, dtos.CheckOrganizationHierarchyReq{Repair: *repair})
+	if err != nil {
+		fmt.Fprintf(out, "check failed: %s\n", err)
+		return 1
+	}
+
+	for _, issue := range report.Issues {
+		fmt.Fprintf(out, "%s\t%s\texpected=%q\tactual=%q\trepairable=%t\n", issue.Type, issue.OrganizationUUID, issue.Expected, issue.Actual, issue.IsRepairable)
+	}
+
+	counts := report.CountByType()
+	types := make([]string, 0, len(counts))
+	for issueType := range counts {
+		types = append(types, issueType)
+	}
+	sort.Strings(types)
+
+	fmt.Fprintf(out, "checked %d organizations, %d issues\n", report.Checked, len(report.Issues))
+	for _, issueType := range types {
+		fmt.Fprintf(out, "  %s: %d\n", issueType, counts[issueType])
+	}
+
+	remaining := 0
+	for _, issue := range report.Issues {
+		if !report.Repaired || !issue.IsRepairable {
+			remaining++
+		}
+	}
+	if report.Repaired {
+		fmt.Fprintf(out, "repaired %d issues, %d left for an admin\n", len(report.Issues)-remaining, remaining)
+	}
+	if remaining > 0 {
+		return 1
+	}
+
+	return 0
+}
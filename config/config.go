@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/viper"
@@ -16,6 +18,9 @@ type Config struct {
 	Postgres PostgresConfig
 	Logger   LoggerConfig
 	JWT      JWTconfig
+	Storage  StorageConfig
+	Auth     AuthConfig
+	Jobs     JobsConfig
 }
 
 type AppConfig struct {
@@ -45,6 +50,43 @@ type JWTconfig struct {
 	SecretKey string
 }
 
+// StorageConfig selects the blob storage driver, "local" or "s3"
+type StorageConfig struct {
+	Driver    string
+	LocalPath string
+	PublicURL string
+	S3        S3Config
+}
+
+type S3Config struct {
+	Endpoint     string
+	Region       string
+	Bucket       string
+	AccessKey    string
+	SecretKey    string
+	UsePathStyle bool
+}
+
+type AuthConfig struct {
+	// SSODomains maps an email domain to the SSO login URL its users are sent to
+	SSODomains map[string]string
+}
+
+// JobsConfig sets how often background jobs run
+type JobsConfig struct {
+	// OrganizationVersionInterval is how often scheduled organization changes are checked for activation
+	OrganizationVersionInterval time.Duration
+	// UserImportInterval is how often queued user imports are looked for
+	UserImportInterval time.Duration
+	// ChangeStreamInterval is how often change streams look for events without a notification, those of
+	// transactions that committed behind a longer running one
+	ChangeStreamInterval time.Duration
+	// ChangeEventRetentionInterval is how often change events older than ChangeEventRetention are deleted
+	ChangeEventRetentionInterval time.Duration
+	// ChangeEventRetention is how long change events are kept, consumers further behind have to resync
+	ChangeEventRetention time.Duration
+}
+
 func LoadConfig(env string) (Config, error) {
 	v := viper.New()
 
@@ -100,7 +142,64 @@ func LoadConfigV2() (Config, error) {
 		JWT: JWTconfig{
 			SecretKey: os.Getenv("JWT_SECRETKEY"),
 		},
+		Storage: StorageConfig{
+			Driver:    os.Getenv("STORAGE_DRIVER"),
+			LocalPath: os.Getenv("STORAGE_LOCAL_PATH"),
+			PublicURL: os.Getenv("STORAGE_PUBLIC_URL"),
+			S3: S3Config{
+				Endpoint:     os.Getenv("STORAGE_S3_ENDPOINT"),
+				Region:       os.Getenv("STORAGE_S3_REGION"),
+				Bucket:       os.Getenv("STORAGE_S3_BUCKET"),
+				AccessKey:    os.Getenv("STORAGE_S3_ACCESS_KEY"),
+				SecretKey:    os.Getenv("STORAGE_S3_SECRET_KEY"),
+				UsePathStyle: os.Getenv("STORAGE_S3_USE_PATH_STYLE") == "true",
+			},
+		},
+		Auth: AuthConfig{
+			SSODomains: parseSSODomains(os.Getenv("AUTH_SSO_DOMAINS")),
+		},
+		Jobs: JobsConfig{
+			OrganizationVersionInterval:  parseDuration(os.Getenv("JOBS_ORGANIZATION_VERSION_INTERVAL"), time.Minute),
+			UserImportInterval:           parseDuration(os.Getenv("JOBS_USER_IMPORT_INTERVAL"), 10*time.Second),
+			ChangeStreamInterval:         parseDuration(os.Getenv("JOBS_CHANGE_STREAM_INTERVAL"), 5*time.Second),
+			ChangeEventRetentionInterval: parseDuration(os.Getenv("JOBS_CHANGE_EVENT_RETENTION_INTERVAL"), time.Hour),
+			ChangeEventRetention:         parseDays(os.Getenv("JOBS_CHANGE_EVENT_RETENTION_DAYS"), 90),
+		},
 	}
 
 	return config, nil
 }
+
+// parseDuration reads a duration like "5m", an empty or invalid value falls back to defaultValue
+func parseDuration(value string, defaultValue time.Duration) time.Duration {
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return defaultValue
+	}
+
+	return duration
+}
+
+// parseDays reads a number of days, an empty or invalid value falls back to defaultDays
+func parseDays(value string, defaultDays int) time.Duration {
+	days, err := strconv.Atoi(value)
+	if err != nil || days <= 0 {
+		days = defaultDays
+	}
+
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// parseSSODomains reads "example.com=https://sso.example.com/login,corp.example=https://..."
+func parseSSODomains(value string) map[string]string {
+	domains := make(map[string]string)
+	for _, entry := range strings.Split(value, ",") {
+		domain, url, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || domain == "" || url == "" {
+			continue
+		}
+		domains[strings.ToLower(strings.TrimSpace(domain))] = strings.TrimSpace(url)
+	}
+
+	return domains
+}
@@ -7,9 +7,78 @@ const (
 	ValidationError       = "validation_error"
 	UnauthorizedError     = "unauthorized_request"
 
-	ErrMsgAlreadyExist    = "already exist"
-	ErrMsgNotFound        = "not found"
-	ErrDuplicated         = "duplicated"
-	ErrMsgPendingApproval = "Akun Anda belum diverifikasi oleh admin. Silakan tunggu verifikasi."
-	ErrMsgAlreadyApproved = "User sudah disetujui sebelumnya"
+	ErrMsgAlreadyExist       = "already exist"
+	ErrMsgUsernameIdentifier = "is already the email, employee ID or phone number of another user"
+	ErrMsgNotFound           = "not found"
+	ErrMsgInvalidCredentials = "invalid identifier or password"
+	ErrDuplicated            = "duplicated"
+	ErrMsgPendingApproval    = "Akun Anda belum diverifikasi oleh admin. Silakan tunggu verifikasi."
+	ErrMsgAlreadyApproved    = "User sudah disetujui sebelumnya"
+	ErrMsgAlreadyErased      = "Data user sudah dianonimkan sebelumnya"
+
+	ErrMsgAttributeUnknown     = "unknown attribute"
+	ErrMsgAttributeRequired    = "required"
+	ErrMsgAttributeNotEditable = "not editable"
+	ErrMsgAttributeInvalidType = "invalid type"
+	ErrMsgAttributeInvalidEnum = "must be one of the allowed values"
+	ErrMsgAttributeNoMatch     = "does not match the required format"
+
+	ErrMsgUnsupportedImage = "must be a JPEG, PNG or WebP image"
+	ErrMsgImageTooLarge    = "image is too large"
+
+	ErrMsgManagerCycle    = "would create a reporting cycle"
+	ErrMsgManagerInactive = "manager is not active"
+
+	ErrMsgPositionEndBeforeStart = "must not be before start_date"
+	ErrMsgPositionPrimaryLocked  = "mark another position as primary first"
+	ErrMsgNoActivePosition       = "no active position in this organization"
+
+	ErrMsgGroupFilterField    = "unknown filter field"
+	ErrMsgGroupFilterOperator = "operator not supported for this field"
+	ErrMsgGroupFilterValue    = "invalid filter value"
+	ErrMsgGroupNotStatic      = "only static groups have members"
+	ErrMsgGroupNotDynamic     = "only dynamic groups have filters"
+	ErrMsgGroupCycle          = "would create a group membership cycle"
+
+	ErrMsgOrganizationCycle = "cannot move an organization below itself or its descendants"
+
+	ErrMsgOrganizationTypeRoot      = "this organization type cannot be a root organization"
+	ErrMsgOrganizationTypeParent    = "this organization type is not allowed below the parent organization type"
+	ErrMsgOrganizationTypeDepth     = "exceeds the maximum depth of the organization type"
+	ErrMsgOrganizationTypeChildren  = "existing sub organizations are not allowed below this organization type"
+	ErrMsgOrganizationTypeInUse     = "organization type is still used by organizations"
+	ErrMsgOrganizationFieldRequired = "required by the organization type"
+
+	ErrMsgOrganizationSettingEnumInUse = "removes values organizations still have set"
+
+	ErrMsgOrganizationVersionPast    = "must not be in the past"
+	ErrMsgOrganizationVersionEmpty   = "at least one of name, parent_id, type or is_active is required"
+	ErrMsgOrganizationVersionApplied = "has already taken effect"
+	ErrMsgOrganizationNotExistsAsOf  = "does not exist on this date"
+
+	ErrMsgOrganizationMergeSubtree   = "cannot merge an organization into itself or its descendants"
+	ErrMsgOrganizationInactive       = "organization is not active"
+	ErrMsgOrganizationVersionPending = "has scheduled changes, cancel them first"
+	ErrMsgOrganizationSplitEmpty     = "at least one of user_ids or child_ids is required"
+	ErrMsgOrganizationNotMember      = "is not a member of the organization"
+	ErrMsgOrganizationNotChild       = "is not a direct sub organization of the organization"
+
+	ErrMsgOrganizationImportDuplicate = "appears more than once in the file"
+	ErrMsgOrganizationImportDeleted   = "belongs to a deleted organization"
+	ErrMsgOrganizationImportFormat    = "must be json or csv"
+	ErrMsgOrganizationImportColumn    = "missing column"
+	ErrMsgOrganizationImportNumber    = "must be a number"
+
+	ErrMsgUserImportFormat    = "must be a csv or xlsx file"
+	ErrMsgUserImportTooLarge  = "file is too large"
+	ErrMsgUserImportEmpty     = "file has no users"
+	ErrMsgUserImportTooMany   = "file has too many users"
+	ErrMsgUserImportColumn    = "missing column"
+	ErrMsgUserImportDuplicate = "appears more than once in the file"
+
+	ErrMsgChangeCursor       = "is not a cursor of this feed"
+	ErrMsgChangeResync       = "is older than the retained changes, resync required"
+	ErrMsgChangeEntityType   = "unknown entity type"
+	ErrMsgChangeEventType    = "unknown event type"
+	ErrMsgChangeOrganization = "is outside of your organization"
 )
@@ -22,6 +22,7 @@ require (
 	go.uber.org/multierr v1.10.0
 	go.uber.org/zap v1.27.0
 	golang.org/x/crypto v0.41.0
+	golang.org/x/image v0.24.0
 )
 
 require (
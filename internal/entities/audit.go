@@ -0,0 +1,74 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+const (
+	AuditEntityUser         = "user"
+	AuditEntityOrganization = "organization"
+
+	AuditActionUserUpdated        = "user.updated"
+	AuditActionUserDeleted        = "user.deleted"
+	AuditActionUserApproved       = "user.approved"
+	AuditActionUserRejected       = "user.rejected"
+	AuditActionUserExported       = "user.exported"
+	AuditActionUserErased         = "user.erased"
+	AuditActionUserManagerChanged = "user.manager_changed"
+
+	AuditActionOrganizationMerged = "organization.merged"
+	AuditActionOrganizationSplit  = "organization.split"
+)
+
+type AuditLog struct {
+	UUID       string          `json:"id" db:"uuid"`
+	Actor      string          `json:"actor" db:"actor"`
+	Action     string          `json:"action" db:"action"`
+	EntityType string          `json:"entity_type" db:"entity_type"`
+	EntityUUID string          `json:"entity_id" db:"entity_uuid"`
+	Metadata   json.RawMessage `json:"metadata" db:"metadata"`
+	CreatedAt  time.Time       `json:"created_at" db:"created_at"`
+}
+
+func NewAuditLog(actor, action, entityType, entityUUID string, metadata map[string]any) AuditLog {
+	if metadata == nil {
+		metadata = map[string]any{}
+	}
+
+	rawMetadata, err := json.Marshal(metadata)
+	if err != nil {
+		rawMetadata = []byte("{}")
+	}
+
+	return AuditLog{
+		UUID:       uuid.NewString(),
+		Actor:      actor,
+		Action:     action,
+		EntityType: entityType,
+		EntityUUID: entityUUID,
+		Metadata:   rawMetadata,
+		CreatedAt:  time.Now(),
+	}
+}
+
+type LoginHistory struct {
+	UUID          string              `json:"id" db:"uuid"`
+	UserUUID      string              `json:"user_id" db:"user_uuid"`
+	IPAddress     nullable.NullString `json:"ip_address" db:"ip_address"`
+	UserAgent     nullable.NullString `json:"user_agent" db:"user_agent"`
+	IsSuccess     bool                `json:"is_success" db:"is_success"`
+	FailureReason nullable.NullString `json:"failure_reason" db:"failure_reason"`
+	CreatedAt     time.Time           `json:"created_at" db:"created_at"`
+}
+
+// UserDataExport bundles everything stored about a single user for data subject requests
+type UserDataExport struct {
+	User           *User
+	LoginHistories []*LoginHistory
+	AuditLogs      []*AuditLog
+	ExportedAt     time.Time
+}
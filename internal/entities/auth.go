@@ -2,6 +2,14 @@ package entities
 
 import "github.com/google/uuid"
 
+// Authentication methods reported by login discovery
+const (
+	AuthMethodPassword = "password"
+	AuthMethodSSO      = "sso"
+	// AuthMethodPasskey is part of the discovery contract, it is reported once passkey credentials can be registered
+	AuthMethodPasskey = "passkey"
+)
+
 // AuthRole represents a user role from identity service (simplified for auth context)
 type AuthRole struct {
 	ID   string `json:"id"`
@@ -24,4 +32,6 @@ type AuthenticatedUser struct {
 	PhoneNumber  string           `json:"phone_number"`
 	Roles        []AuthRole       `json:"roles"`
 	Organization UserOrganization `json:"organization"`
+	// ActiveOrganization is the organization the session acts in, the primary one unless switched
+	ActiveOrganization UserOrganization `json:"active_organization"`
 }
@@ -0,0 +1,16 @@
+package entities
+
+import "strconv"
+
+// AvatarSizes are the square variants generated for every uploaded avatar
+var AvatarSizes = []int{64, 128, 256, 512}
+
+const AvatarContentType = "image/jpeg"
+
+// AvatarURLs maps a variant size ("64", "128", ...) to its public URL
+type AvatarURLs map[string]string
+
+// AvatarVariantKey returns the blob key of a single variant under the avatar prefix stored on users
+func AvatarVariantKey(avatarKey string, size int) string {
+	return avatarKey + "/" + strconv.Itoa(size) + ".jpg"
+}
@@ -0,0 +1,107 @@
+package entities
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Entity types of the change feed
+const (
+	ChangeEntityUser         = "user"
+	ChangeEntityOrganization = "organization"
+	ChangeEntityRole         = "role"
+	ChangeEntityUserRole     = "user_role"
+)
+
+// Actions of the change feed, deleted events are tombstones without data
+const (
+	ChangeActionCreated = "created"
+	ChangeActionUpdated = "updated"
+	ChangeActionDeleted = "deleted"
+)
+
+var (
+	ChangeEntityTypes = []string{ChangeEntityUser, ChangeEntityOrganization, ChangeEntityRole, ChangeEntityUserRole}
+	ChangeActions     = []string{ChangeActionCreated, ChangeActionUpdated, ChangeActionDeleted}
+)
+
+var (
+	ErrInvalidChangeCursor = errors.New("invalid change cursor")
+	// ErrChangeResyncRequired is returned for cursors whose following events were purged, their consumers
+	// have to synchronize everything again and read the feed from its start
+	ErrChangeResyncRequired = errors.New("change cursor was purged, resync required")
+)
+
+// ChangeEvent is a change of a user, organization, role or role assignment. Data is the state of the
+// entity after the change, keyed like the API.
+type ChangeEvent struct {
+	ID         int64           `db:"id"`
+	TxID       uint64          `db:"txid"`
+	EntityType string          `db:"entity_type"`
+	EntityUUID string          `db:"entity_uuid"`
+	Action     string          `db:"action"`
+	Data       json.RawMessage `db:"data"`
+	OccurredAt time.Time       `db:"occurred_at"`
+}
+
+// ChangeCursor is the position of an event in the feed, which is ordered by transaction then by event
+type ChangeCursor struct {
+	TxID uint64
+	ID   int64
+}
+
+func (e ChangeEvent) Cursor() ChangeCursor {
+	return ChangeCursor{TxID: e.TxID, ID: e.ID}
+}
+
+// Name is the event type, the entity type and the action like user.updated
+func (e ChangeEvent) Name() string {
+	return e.EntityType + "." + e.Action
+}
+
+// String encodes the cursor for clients, which should treat it as opaque
+func (c ChangeCursor) String() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(c.TxID, 10) + ":" + strconv.FormatInt(c.ID, 10)))
+}
+
+// ParseChangeCursor decodes a cursor of ChangeCursor.String, an empty cursor is the start of the feed
+func ParseChangeCursor(value string) (ChangeCursor, error) {
+	var cursor ChangeCursor
+	if value == "" {
+		return cursor, nil
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return cursor, ErrInvalidChangeCursor
+	}
+
+	txID, id, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return cursor, ErrInvalidChangeCursor
+	}
+	cursor.TxID, err = strconv.ParseUint(txID, 10, 64)
+	if err != nil {
+		return ChangeCursor{}, ErrInvalidChangeCursor
+	}
+	cursor.ID, err = strconv.ParseInt(id, 10, 64)
+	if err != nil || cursor.ID < 0 {
+		return ChangeCursor{}, ErrInvalidChangeCursor
+	}
+
+	return cursor, nil
+}
+
+// ListChangeEventParams selects the events of Types after the cursor After. A type is an entity type
+// or an event type like user.deleted. A non empty OrganizationUUID keeps the events of its subtree and
+// the global ones of roles.
+type ListChangeEventParams struct {
+	After            ChangeCursor
+	Types            []string
+	OrganizationUUID string
+	Limit            int
+}
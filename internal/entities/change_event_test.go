@@ -0,0 +1,43 @@
+package entities
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestChangeCursor_RoundTrip(t *testing.T) {
+	cursor := ChangeCursor{TxID: 18446744073709551615, ID: 42}
+
+	parsed, err := ParseChangeCursor(cursor.String())
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if parsed != cursor {
+		t.Errorf("expected %+v, got %+v", cursor, parsed)
+	}
+
+	start, err := ParseChangeCursor("")
+	if err != nil || start != (ChangeCursor{}) {
+		t.Errorf("expected the start of the feed, got %+v, %v", start, err)
+	}
+}
+
+func TestParseChangeCursor_Invalid(t *testing.T) {
+	values := []string{"not base64!", "5:7x", "5", "a:1", "5:-1", "-5:1"}
+	for _, value := range values {
+		encoded := value
+		if value != "not base64!" {
+			encoded = base64.RawURLEncoding.EncodeToString([]byte(value))
+		}
+		if _, err := ParseChangeCursor(encoded); err != ErrInvalidChangeCursor {
+			t.Errorf("%q: expected ErrInvalidChangeCursor, got %v", value, err)
+		}
+	}
+}
+
+func TestChangeEvent_Name(t *testing.T) {
+	event := ChangeEvent{EntityType: ChangeEntityUserRole, Action: ChangeActionDeleted}
+	if event.Name() != "user_role.deleted" {
+		t.Errorf("unexpected name %q", event.Name())
+	}
+}
@@ -0,0 +1,105 @@
+package entities
+
+import (
+	"math"
+
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+// EarthRadiusMeters is the mean earth radius, the SQL distance in the organization repository uses the same value
+const EarthRadiusMeters = 6371008.8
+
+type GeoPoint struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// GeoBox is a latitude/longitude rectangle, MinLongitude > MaxLongitude when it crosses the antimeridian
+type GeoBox struct {
+	MinLatitude  float64
+	MinLongitude float64
+	MaxLatitude  float64
+	MaxLongitude float64
+}
+
+// GeoSearchParams finds the Limit organizations closest to Origin, optionally restricted to Box
+// and to RadiusMeters around Origin
+type GeoSearchParams struct {
+	Origin       GeoPoint
+	Box          *GeoBox
+	RadiusMeters nullable.NullFloat64
+	Limit        int
+}
+
+// DistanceMeters is the great circle distance between p and q using the haversine formula
+func (p GeoPoint) DistanceMeters(q GeoPoint) float64 {
+	lat1, lat2 := degreesToRadians(p.Latitude), degreesToRadians(q.Latitude)
+	deltaLat := lat2 - lat1
+	deltaLon := degreesToRadians(q.Longitude - p.Longitude)
+
+	h := math.Pow(math.Sin(deltaLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(deltaLon/2), 2)
+
+	return 2 * EarthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// BoxAround returns a box containing every point within radiusMeters of p, it is used to narrow
+// radius searches down before distances are computed
+func (p GeoPoint) BoxAround(radiusMeters float64) GeoBox {
+	deltaLat := radiansToDegrees(radiusMeters / EarthRadiusMeters)
+	box := GeoBox{
+		MinLatitude:  math.Max(-90, p.Latitude-deltaLat),
+		MaxLatitude:  math.Min(90, p.Latitude+deltaLat),
+		MinLongitude: -180,
+		MaxLongitude: 180,
+	}
+
+	// Near the poles every longitude can be within reach
+	if box.MinLatitude == -90 || box.MaxLatitude == 90 {
+		return box
+	}
+
+	deltaLon := radiansToDegrees(math.Asin(math.Min(1, math.Sin(radiusMeters/EarthRadiusMeters)/math.Cos(degreesToRadians(p.Latitude)))))
+	if deltaLon >= 180 {
+		return box
+	}
+
+	box.MinLongitude = normalizeLongitude(p.Longitude - deltaLon)
+	box.MaxLongitude = normalizeLongitude(p.Longitude + deltaLon)
+
+	return box
+}
+
+func (b GeoBox) CrossesAntimeridian() bool {
+	return b.MinLongitude > b.MaxLongitude
+}
+
+func (b GeoBox) Center() GeoPoint {
+	maxLongitude := b.MaxLongitude
+	if b.CrossesAntimeridian() {
+		maxLongitude += 360
+	}
+
+	return GeoPoint{
+		Latitude:  (b.MinLatitude + b.MaxLatitude) / 2,
+		Longitude: normalizeLongitude((b.MinLongitude + maxLongitude) / 2),
+	}
+}
+
+func normalizeLongitude(longitude float64) float64 {
+	for longitude > 180 {
+		longitude -= 360
+	}
+	for longitude < -180 {
+		longitude += 360
+	}
+
+	return longitude
+}
+
+func degreesToRadians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+func radiansToDegrees(radians float64) float64 {
+	return radians * 180 / math.Pi
+}
@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGeoPoint_DistanceMeters(t *testing.T) {
+	tests := []struct {
+		name string
+		p, q GeoPoint
+		want float64
+	}{
+		{"Jakarta to Bandung", GeoPoint{-6.1754, 106.8272}, GeoPoint{-6.9175, 107.6191}, 120258},
+		{"across the antimeridian", GeoPoint{0, 179.9}, GeoPoint{0, -179.9}, 22239},
+		{"same point", GeoPoint{-6.2, 106.8}, GeoPoint{-6.2, 106.8}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.DistanceMeters(tt.q); math.Abs(got-tt.want) > 1 {
+				t.Errorf("got %.1f, want %.1f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGeoPoint_BoxAround(t *testing.T) {
+	origin := GeoPoint{-6.1754, 106.8272}
+	box := origin.BoxAround(5000)
+
+	for _, bearing := range []GeoPoint{{5000, 0}, {-5000, 0}, {0, 5000}, {0, -5000}} {
+		// move roughly 5 km north/south/east/west and make sure the point stays inside the box
+		point := GeoPoint{
+			Latitude:  origin.Latitude + radiansToDegrees(bearing.Latitude/EarthRadiusMeters)*0.999,
+			Longitude: origin.Longitude + radiansToDegrees(bearing.Longitude/EarthRadiusMeters)/math.Cos(degreesToRadians(origin.Latitude))*0.999,
+		}
+		if point.Latitude < box.MinLatitude || point.Latitude > box.MaxLatitude || point.Longitude < box.MinLongitude || point.Longitude > box.MaxLongitude {
+			t.Errorf("point %+v outside box %+v", point, box)
+		}
+	}
+
+	wrapped := GeoPoint{0, 179.99}.BoxAround(5000)
+	if !wrapped.CrossesAntimeridian() {
+		t.Errorf("expected box %+v to cross the antimeridian", wrapped)
+	}
+	if center := wrapped.Center(); math.Abs(center.Longitude-179.99) > 1e-9 {
+		t.Errorf("got center %+v", center)
+	}
+
+	polar := GeoPoint{89.99, 0}.BoxAround(5000)
+	if polar.MinLongitude != -180 || polar.MaxLongitude != 180 {
+		t.Errorf("expected every longitude near the pole, got %+v", polar)
+	}
+}
@@ -0,0 +1,231 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/laksanagusta/identity/constants"
+	"github.com/laksanagusta/identity/pkg/nullable"
+	"github.com/laksanagusta/identity/pkg/pagination"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// dynamic groups have no stored members, membership is evaluated from their filters
+	GroupTypeDynamic = "dynamic"
+	// static groups list their members, users or other static groups
+	GroupTypeStatic = "static"
+
+	GroupFilterFieldRole                = "role_id"
+	GroupFilterFieldOrganization        = "organization_id"
+	GroupFilterFieldOrganizationSubtree = "organization_subtree"
+	GroupFilterAttributePrefix          = "attributes."
+)
+
+var (
+	// GroupFilterUserFields maps the filterable user fields to their users column
+	GroupFilterUserFields = map[string]string{
+		"username":        "username",
+		"email":           "email",
+		"first_name":      "first_name",
+		"last_name":       "last_name",
+		"phone_number":    "phone_number",
+		"employee_id":     "employee_id",
+		"is_active":       "is_active",
+		"is_approved":     "is_approved",
+		"organization_id": "organization_uuid",
+		"manager_id":      "manager_uuid",
+		"created_at":      "created_at",
+		"last_login_at":   "last_login_at",
+	}
+
+	GroupFilterOperators = []string{"eq", "ne", "gt", "gte", "lt", "lte", "like", "ilike", "in", "nin"}
+
+	// role and organization filters test membership in a set, so only set operators apply
+	groupFilterSetOperators = []string{"eq", "ne", "in", "nin"}
+
+	// pattern operators compare lowercased text, they only apply to text columns and attributes
+	groupFilterTextOperators = []string{"like", "ilike"}
+	groupFilterTextFields    = []string{"username", "email", "first_name", "last_name", "phone_number", "employee_id"}
+)
+
+type Group struct {
+	BaseModel
+	Name        nullable.NullString `json:"name" db:"name"`
+	Description nullable.NullString `json:"description" db:"description"`
+	Type        nullable.NullString `json:"type" db:"type"`
+	Filters     GroupFilters        `json:"filters" db:"filters"`
+
+	Roles []*Role `json:"roles,omitempty" db:"-"`
+}
+
+func (g *Group) IsStatic() bool {
+	return g.Type.GetOrDefault() == GroupTypeStatic
+}
+
+// GroupMember is a direct member of a static group, exactly one of the member UUIDs is set
+type GroupMember struct {
+	BaseModel
+	GroupUUID       string              `json:"group_id" db:"group_uuid"`
+	MemberUserUUID  nullable.NullString `json:"member_user_id" db:"member_user_uuid"`
+	MemberGroupUUID nullable.NullString `json:"member_group_id" db:"member_group_uuid"`
+
+	User  *User  `json:"user,omitempty" db:"-"`
+	Group *Group `json:"group,omitempty" db:"-"`
+}
+
+type GroupRole struct {
+	BaseModel
+	GroupUUID string `json:"group_id" db:"group_uuid"`
+	RoleUUID  string `json:"role_id" db:"role_uuid"`
+}
+
+// GroupFilter is one condition of a dynamic group, all conditions of a group must hold.
+// Operators are the ones of pagination.Filter, in and nin take an array value.
+type GroupFilter struct {
+	Field    string `json:"field"`
+	Operator string `json:"operator"`
+	Value    any    `json:"value"`
+}
+
+// Validate returns an error message, or an empty string when the filter is valid
+func (f GroupFilter) Validate() string {
+	switch {
+	case f.Field == GroupFilterFieldRole || f.Field == GroupFilterFieldOrganizationSubtree:
+		if !slices.Contains(groupFilterSetOperators, f.Operator) {
+			return constants.ErrMsgGroupFilterOperator
+		}
+	case strings.HasPrefix(f.Field, GroupFilterAttributePrefix):
+		if !AttributeKeyRegex.MatchString(strings.TrimPrefix(f.Field, GroupFilterAttributePrefix)) {
+			return constants.ErrMsgGroupFilterField
+		}
+	default:
+		if _, ok := GroupFilterUserFields[f.Field]; !ok {
+			return constants.ErrMsgGroupFilterField
+		}
+		if slices.Contains(groupFilterTextOperators, f.Operator) && !slices.Contains(groupFilterTextFields, f.Field) {
+			return constants.ErrMsgGroupFilterOperator
+		}
+	}
+
+	if !slices.Contains(GroupFilterOperators, f.Operator) {
+		return constants.ErrMsgGroupFilterOperator
+	}
+
+	values, ok := f.Value.([]any)
+	if f.IsSetOperator() {
+		if !ok || len(values) == 0 {
+			return constants.ErrMsgGroupFilterValue
+		}
+	} else {
+		if ok || f.Value == nil {
+			return constants.ErrMsgGroupFilterValue
+		}
+		values = []any{f.Value}
+	}
+
+	for _, value := range values {
+		switch value.(type) {
+		case string, float64, bool:
+		default:
+			return constants.ErrMsgGroupFilterValue
+		}
+	}
+
+	if f.Field == GroupFilterFieldRole || f.Field == GroupFilterFieldOrganizationSubtree {
+		for _, value := range values {
+			if str, _ := value.(string); uuid.Validate(str) != nil {
+				return constants.ErrMsgGroupFilterValue
+			}
+		}
+	}
+
+	return ""
+}
+
+func (f GroupFilter) IsSetOperator() bool {
+	return f.Operator == "in" || f.Operator == "nin"
+}
+
+// IsNegated is true for operators that exclude the given values
+func (f GroupFilter) IsNegated() bool {
+	return f.Operator == "ne" || f.Operator == "nin"
+}
+
+// Values returns the filter value as a list, whatever the operator
+func (f GroupFilter) Values() []any {
+	if values, ok := f.Value.([]any); ok {
+		return values
+	}
+	return []any{f.Value}
+}
+
+func (f GroupFilter) PaginationFilter(field string) pagination.Filter {
+	return pagination.Filter{Field: field, Operator: f.Operator, Value: f.Value}
+}
+
+// GroupFilters is the JSONB representation of the filters of a dynamic group
+type GroupFilters []GroupFilter
+
+// ReplaceOrganization points the organization filters matching oldUUID at newUUID instead and reports
+// whether any filter changed
+func (f GroupFilters) ReplaceOrganization(oldUUID, newUUID string) bool {
+	changed := false
+	for i, filter := range f {
+		if filter.Field != GroupFilterFieldOrganization && filter.Field != GroupFilterFieldOrganizationSubtree {
+			continue
+		}
+
+		if values, ok := filter.Value.([]any); ok {
+			for j, value := range values {
+				if value == oldUUID {
+					values[j] = newUUID
+					changed = true
+				}
+			}
+			continue
+		}
+
+		if filter.Value == oldUUID {
+			f[i].Value = newUUID
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+func (f GroupFilters) Value() (driver.Value, error) {
+	if f == nil {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal(f)
+}
+
+func (f *GroupFilters) Scan(src any) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*f = GroupFilters{}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return errors.New("unsupported type for group filters")
+	}
+
+	filters := GroupFilters{}
+	if err := json.Unmarshal(data, &filters); err != nil {
+		return err
+	}
+
+	*f = filters
+	return nil
+}
@@ -0,0 +1,96 @@
+package entities
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/laksanagusta/identity/constants"
+)
+
+func TestGroupFilter_Validate(t *testing.T) {
+	const roleUUID = "f2f2f2f2-f2f2-f2f2-f2f2-f2f2f2f2f2f2"
+
+	tests := []struct {
+		name   string
+		filter string
+		want   string
+	}{
+		{"user field", `{"field":"is_active","operator":"eq","value":true}`, ""},
+		{"date comparison", `{"field":"created_at","operator":"gte","value":"2024-01-01"}`, ""},
+		{"attribute", `{"field":"attributes.hire_date","operator":"gt","value":"2024-01-01"}`, ""},
+		{"role", `{"field":"role_id","operator":"eq","value":"` + roleUUID + `"}`, ""},
+		{"organization subtree", `{"field":"organization_subtree","operator":"in","value":["` + roleUUID + `"]}`, ""},
+		{"unknown field", `{"field":"password_hash","operator":"eq","value":"x"}`, constants.ErrMsgGroupFilterField},
+		{"malformed attribute key", `{"field":"attributes.a'b","operator":"eq","value":"x"}`, constants.ErrMsgGroupFilterField},
+		{"unknown operator", `{"field":"username","operator":"is","value":"x"}`, constants.ErrMsgGroupFilterOperator},
+		{"pattern on text", `{"field":"email","operator":"ilike","value":"%@example.com"}`, ""},
+		{"pattern on attribute", `{"field":"attributes.shirt_size","operator":"like","value":"x%"}`, ""},
+		{"pattern on boolean", `{"field":"is_active","operator":"like","value":"t%"}`, constants.ErrMsgGroupFilterOperator},
+		{"pattern on timestamp", `{"field":"last_login_at","operator":"ilike","value":"2024%"}`, constants.ErrMsgGroupFilterOperator},
+		{"pattern on uuid", `{"field":"manager_id","operator":"like","value":"f2%"}`, constants.ErrMsgGroupFilterOperator},
+		{"range on role", `{"field":"role_id","operator":"gt","value":"` + roleUUID + `"}`, constants.ErrMsgGroupFilterOperator},
+		{"in without array", `{"field":"username","operator":"in","value":"x"}`, constants.ErrMsgGroupFilterValue},
+		{"eq with array", `{"field":"username","operator":"eq","value":["x"]}`, constants.ErrMsgGroupFilterValue},
+		{"missing value", `{"field":"username","operator":"eq"}`, constants.ErrMsgGroupFilterValue},
+		{"object value", `{"field":"username","operator":"in","value":[{"a":1}]}`, constants.ErrMsgGroupFilterValue},
+		{"role is not a uuid", `{"field":"role_id","operator":"eq","value":"admin"}`, constants.ErrMsgGroupFilterValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var filter GroupFilter
+			if err := json.Unmarshal([]byte(tt.filter), &filter); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := filter.Validate(); got != tt.want {
+				t.Errorf("Validate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupFilters_ScanValue(t *testing.T) {
+	filters := GroupFilters{{Field: "role_id", Operator: "in", Value: []any{"a", "b"}}}
+
+	value, err := filters.Value()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var scanned GroupFilters
+	if err := scanned.Scan(value); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(scanned) != 1 || len(scanned[0].Values()) != 2 || scanned[0].IsNegated() {
+		t.Errorf("unexpected filters %+v", scanned)
+	}
+
+	var empty GroupFilters
+	if err := empty.Scan(nil); err != nil || empty == nil {
+		t.Errorf("expected empty filters, got %v %v", empty, err)
+	}
+}
+
+func TestGroupFilters_ReplaceOrganization(t *testing.T) {
+	filters := GroupFilters{
+		{Field: GroupFilterFieldOrganization, Operator: "eq", Value: "source"},
+		{Field: GroupFilterFieldOrganizationSubtree, Operator: "in", Value: []any{"other", "source"}},
+		{Field: "username", Operator: "eq", Value: "source"},
+	}
+
+	if !filters.ReplaceOrganization("source", "target") {
+		t.Fatal("expected the filters to change")
+	}
+	if filters[0].Value != "target" || filters[1].Values()[1] != "target" || filters[1].Values()[0] != "other" {
+		t.Errorf("unexpected organization filters %+v", filters)
+	}
+	if filters[2].Value != "source" {
+		t.Errorf("expected non organization filters to be kept, got %+v", filters[2])
+	}
+
+	if filters.ReplaceOrganization("source", "target") {
+		t.Error("expected no change once the filters are rewritten")
+	}
+}
@@ -1,23 +1,31 @@
 package entities
 
 import (
-	"log"
+	"strings"
 
 	"github.com/laksanagusta/identity/pkg/nullable"
 )
 
+// OrganizationPathSeparator joins the UUIDs of a materialized path, root first
+const OrganizationPathSeparator = "."
+
 type Organization struct {
 	SoftDeleteModel
-	Name       nullable.NullString `json:"name" db:"name"`
-	Code       nullable.NullString `json:"code" db:"code"`
-	Address    nullable.NullString `json:"address" db:"address"`
-	Latitude   nullable.NullString `json:"latitude" db:"latitude"`
-	Longitude  nullable.NullString `json:"longitude" db:"longitude"`
-	Type       nullable.NullString `json:"type" db:"type"`
-	ParentUUID nullable.NullString `json:"parent_id" db:"parent_uuid"`
-	Level      nullable.NullInt32  `json:"level" db:"level"`
-	Path       nullable.NullString `json:"path" db:"path"`
-	IsActive   bool                `json:"is_active" db:"is_active"`
+	Name       nullable.NullString  `json:"name" db:"name"`
+	Code       nullable.NullString  `json:"code" db:"code"`
+	Address    nullable.NullString  `json:"address" db:"address"`
+	Latitude   nullable.NullFloat64 `json:"latitude" db:"latitude"`
+	Longitude  nullable.NullFloat64 `json:"longitude" db:"longitude"`
+	Type       nullable.NullString  `json:"type" db:"type"`
+	ParentUUID nullable.NullString  `json:"parent_id" db:"parent_uuid"`
+	Level      nullable.NullInt32   `json:"level" db:"level"`
+	Path       nullable.NullString  `json:"path" db:"path"`
+	IsActive   bool                 `json:"is_active" db:"is_active"`
+
+	// UserCount is only loaded when a tree navigation request asks for it
+	UserCount nullable.NullInt64 `json:"user_count" db:"-"`
+	// DistanceMeters is only set by geo searches, measured from the search origin
+	DistanceMeters nullable.NullFloat64 `json:"distance_meters" db:"distance_meters"`
 
 	Parent   *Organization   `json:"parent,omitempty" db:"-"`
 	Children []*Organization `json:"children,omitempty" db:"-"`
@@ -30,9 +38,49 @@ func (o *Organization) BuildPath(parentPath string) {
 	if parentPath == "" {
 		o.Path = nullable.NewString(o.UUID)
 	} else {
-		log.Println("parent path", parentPath)
-		o.Path = nullable.NewString(parentPath + "." + o.UUID)
+		o.Path = nullable.NewString(parentPath + OrganizationPathSeparator + o.UUID)
 	}
+	o.Level = nullable.NewInt32(PathLevel(o.Path.GetOrDefault()))
+}
+
+// IsInSubtreeOf reports whether o is the organization at path or one of its descendants
+func (o *Organization) IsInSubtreeOf(path string) bool {
+	ownPath := o.Path.GetOrDefault()
+	return ownPath == path || strings.HasPrefix(ownPath, path+OrganizationPathSeparator)
+}
+
+// AttachDescendants links descendants, ordered parents before children, below o as nested children
+func (o *Organization) AttachDescendants(descendants []*Organization) {
+	nodes := map[string]*Organization{o.UUID: o}
+	for _, descendant := range descendants {
+		parent, ok := nodes[descendant.ParentUUID.GetOrDefault()]
+		if !ok {
+			continue
+		}
+
+		parent.Children = append(parent.Children, descendant)
+		nodes[descendant.UUID] = descendant
+	}
+}
+
+// PathLevel is the depth of a materialized path, roots are level 0
+func PathLevel(path string) int32 {
+	return int32(strings.Count(path, OrganizationPathSeparator))
+}
+
+// MovedPath rewrites path, inside the subtree rooted at oldRootPath, for the root now at newRootPath
+func MovedPath(path string, oldRootPath string, newRootPath string) string {
+	return newRootPath + strings.TrimPrefix(path, oldRootPath)
+}
+
+// OrganizationPathChange describes how moving a subtree affects one of its organizations
+type OrganizationPathChange struct {
+	UUID     string
+	Name     nullable.NullString
+	OldPath  string
+	NewPath  string
+	OldLevel int32
+	NewLevel int32
 }
 
 func (os Organizations) Uuids() []string {
@@ -52,6 +100,7 @@ type ListOrganizationParams struct {
 	EndTime   nullable.NullTime
 	Search    nullable.NullString
 	Sort      *Sort
+	AsOf      nullable.NullTime
 }
 
 type ListOrganizationProductStockParams struct {
@@ -0,0 +1,245 @@
+package entities
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+// Kinds of inconsistencies between parent_uuid and the path, level and closure derived from it
+const (
+	// HierarchyIssueOrphanedParent is an organization below a soft deleted one, moving it is up to an admin
+	HierarchyIssueOrphanedParent = "orphaned_parent"
+	// HierarchyIssueMissingParent is a parent_uuid of an organization that does not exist
+	HierarchyIssueMissingParent = "missing_parent"
+	// HierarchyIssueCycle is an organization whose parent chain loops, itself or above it
+	HierarchyIssueCycle        = "cycle"
+	HierarchyIssueWrongPath    = "wrong_path"
+	HierarchyIssueWrongLevel   = "wrong_level"
+	HierarchyIssueWrongClosure = "wrong_closure"
+)
+
+// OrganizationClosure is a row of the closure table, an organization is its own ancestor at depth 0
+type OrganizationClosure struct {
+	AncestorUUID   string `db:"ancestor_uuid"`
+	DescendantUUID string `db:"descendant_uuid"`
+	Depth          int32  `db:"depth"`
+}
+
+type OrganizationHierarchyIssue struct {
+	Type             string
+	OrganizationUUID string
+	Expected         string
+	Actual           string
+	// IsRepairable is false where parent_uuid itself is wrong, fixing it needs a decision
+	IsRepairable bool
+}
+
+type OrganizationHierarchyReport struct {
+	Checked int
+	Issues  []OrganizationHierarchyIssue
+	// PathRepairs are the organizations with the path and level their parent chain gives
+	PathRepairs []*Organization
+	// ClosureRepairs are the expected closure rows of every organization whose rows are wrong
+	ClosureRepairs map[string][]OrganizationClosure
+	Repaired       bool
+}
+
+func (r OrganizationHierarchyReport) CountByType() map[string]int {
+	counts := map[string]int{}
+	for _, issue := range r.Issues {
+		counts[issue.Type]++
+	}
+	return counts
+}
+
+// CheckOrganizationHierarchy compares the path, level and closure rows of every organization, soft
+// deleted ones included, with what their parent_uuid chain gives. Organizations whose chain does not
+// reach a root are reported but get no repair.
+func CheckOrganizationHierarchy(organizations []*Organization, closure []OrganizationClosure) OrganizationHierarchyReport {
+	report := OrganizationHierarchyReport{
+		Checked:        len(organizations),
+		Issues:         []OrganizationHierarchyIssue{},
+		PathRepairs:    []*Organization{},
+		ClosureRepairs: map[string][]OrganizationClosure{},
+	}
+
+	ordered := slices.Clone(organizations)
+	slices.SortFunc(ordered, func(a, b *Organization) int { return strings.Compare(a.UUID, b.UUID) })
+
+	nodes := make(map[string]*Organization, len(ordered))
+	for _, organization := range ordered {
+		nodes[organization.UUID] = organization
+	}
+
+	paths := map[string]string{}
+	broken := map[string]string{}
+	for _, organization := range ordered {
+		resolveOrganizationPath(organization, nodes, paths, broken)
+	}
+
+	actualClosure := map[string][]OrganizationClosure{}
+	for _, row := range closure {
+		actualClosure[row.DescendantUUID] = append(actualClosure[row.DescendantUUID], row)
+	}
+
+	for _, organization := range ordered {
+		if issueType, ok := broken[organization.UUID]; ok {
+			report.Issues = append(report.Issues, OrganizationHierarchyIssue{
+				Type:             issueType,
+				OrganizationUUID: organization.UUID,
+				Actual:           organization.ParentUUID.GetOrDefault(),
+			})
+			continue
+		}
+
+		parent := nodes[organization.ParentUUID.GetOrDefault()]
+		if organization.DeletedAt == nil && parent != nil && parent.DeletedAt != nil {
+			report.Issues = append(report.Issues, OrganizationHierarchyIssue{
+				Type:             HierarchyIssueOrphanedParent,
+				OrganizationUUID: organization.UUID,
+				Actual:           parent.UUID,
+			})
+		}
+
+		path := paths[organization.UUID]
+		level := PathLevel(path)
+		isPathWrong := organization.Path.GetOrDefault() != path
+		isLevelWrong := !organization.Level.IsExists || organization.Level.Val == nil || *organization.Level.Val != level
+		if isPathWrong {
+			report.Issues = append(report.Issues, OrganizationHierarchyIssue{
+				Type:             HierarchyIssueWrongPath,
+				OrganizationUUID: organization.UUID,
+				Expected:         path,
+				Actual:           organization.Path.GetOrDefault(),
+				IsRepairable:     true,
+			})
+		}
+		if isLevelWrong {
+			report.Issues = append(report.Issues, OrganizationHierarchyIssue{
+				Type:             HierarchyIssueWrongLevel,
+				OrganizationUUID: organization.UUID,
+				Expected:         strconv.Itoa(int(level)),
+				Actual:           formatLevel(organization.Level),
+				IsRepairable:     true,
+			})
+		}
+		if isPathWrong || isLevelWrong {
+			repaired := *organization
+			repaired.Path = nullable.NewString(path)
+			repaired.Level = nullable.NewInt32(level)
+			report.PathRepairs = append(report.PathRepairs, &repaired)
+		}
+
+		expected := expectedClosure(organization.UUID, path)
+		expectedAncestors := closureAncestors(expected)
+		actualAncestors := closureAncestors(actualClosure[organization.UUID])
+		if expectedAncestors != actualAncestors {
+			report.Issues = append(report.Issues, OrganizationHierarchyIssue{
+				Type:             HierarchyIssueWrongClosure,
+				OrganizationUUID: organization.UUID,
+				Expected:         expectedAncestors,
+				Actual:           actualAncestors,
+				IsRepairable:     true,
+			})
+			report.ClosureRepairs[organization.UUID] = expected
+		}
+	}
+
+	return report
+}
+
+// resolveOrganizationPath walks up from organization until a root or an organization already resolved
+// and records the path of every organization on the way. An organization whose chain loops or points
+// at a missing parent is recorded in broken, together with everything below it on the way.
+func resolveOrganizationPath(organization *Organization, nodes map[string]*Organization, paths map[string]string, broken map[string]string) {
+	var chain []*Organization
+	onChain := map[string]bool{}
+
+	base, issueType := "", ""
+	current := organization
+	for {
+		if path, ok := paths[current.UUID]; ok {
+			base = path
+			break
+		}
+		if brokenType, ok := broken[current.UUID]; ok {
+			issueType = brokenType
+			break
+		}
+		if onChain[current.UUID] {
+			issueType = HierarchyIssueCycle
+			break
+		}
+
+		chain = append(chain, current)
+		onChain[current.UUID] = true
+
+		if !current.ParentUUID.IsNotEmpty() {
+			break
+		}
+
+		parent, ok := nodes[current.ParentUUID.GetOrDefault()]
+		if !ok {
+			issueType = HierarchyIssueMissingParent
+			break
+		}
+		current = parent
+	}
+
+	if issueType != "" {
+		for _, node := range chain {
+			broken[node.UUID] = issueType
+		}
+		return
+	}
+
+	for i := len(chain) - 1; i >= 0; i-- {
+		if base == "" {
+			base = chain[i].UUID
+		} else {
+			base = base + OrganizationPathSeparator + chain[i].UUID
+		}
+		paths[chain[i].UUID] = base
+	}
+}
+
+// expectedClosure returns the closure rows of organizationUUID at path, root first
+func expectedClosure(organizationUUID string, path string) []OrganizationClosure {
+	ancestors := strings.Split(path, OrganizationPathSeparator)
+	rows := make([]OrganizationClosure, 0, len(ancestors))
+	for i, ancestor := range ancestors {
+		rows = append(rows, OrganizationClosure{
+			AncestorUUID:   ancestor,
+			DescendantUUID: organizationUUID,
+			Depth:          int32(len(ancestors) - 1 - i),
+		})
+	}
+	return rows
+}
+
+// closureAncestors formats the closure rows of one organization as ancestor:depth pairs, root first
+func closureAncestors(rows []OrganizationClosure) string {
+	sorted := slices.Clone(rows)
+	slices.SortFunc(sorted, func(a, b OrganizationClosure) int {
+		if a.Depth != b.Depth {
+			return int(b.Depth - a.Depth)
+		}
+		return strings.Compare(a.AncestorUUID, b.AncestorUUID)
+	})
+
+	ancestors := make([]string, 0, len(sorted))
+	for _, row := range sorted {
+		ancestors = append(ancestors, row.AncestorUUID+":"+strconv.Itoa(int(row.Depth)))
+	}
+	return strings.Join(ancestors, ", ")
+}
+
+func formatLevel(level nullable.NullInt32) string {
+	if level.Val == nil {
+		return ""
+	}
+	return strconv.Itoa(int(*level.Val))
+}
@@ -0,0 +1,92 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+func hierarchyNode(uuid, parentUUID, path string, level int32) *Organization {
+	o := &Organization{Path: nullable.NewString(path), Level: nullable.NewInt32(level)}
+	o.UUID = uuid
+	if parentUUID != "" {
+		o.ParentUUID = nullable.NewString(parentUUID)
+	}
+	return o
+}
+
+func closureOf(organizations ...*Organization) []OrganizationClosure {
+	rows := []OrganizationClosure{}
+	for _, o := range organizations {
+		rows = append(rows, expectedClosure(o.UUID, o.Path.GetOrDefault())...)
+	}
+	return rows
+}
+
+func TestCheckOrganizationHierarchy(t *testing.T) {
+	t.Run("consistent hierarchy", func(t *testing.T) {
+		organizations := []*Organization{
+			hierarchyNode("a", "", "a", 0),
+			hierarchyNode("b", "a", "a.b", 1),
+			hierarchyNode("c", "b", "a.b.c", 2),
+		}
+
+		report := CheckOrganizationHierarchy(organizations, closureOf(organizations...))
+		if len(report.Issues) != 0 || report.Checked != 3 {
+			t.Fatalf("expected no issues, got %+v", report.Issues)
+		}
+	})
+
+	t.Run("wrong path and level", func(t *testing.T) {
+		organizations := []*Organization{
+			hierarchyNode("a", "", "a", 0),
+			hierarchyNode("b", "a", "b", 3),
+			hierarchyNode("c", "b", "b.c", 1),
+		}
+
+		report := CheckOrganizationHierarchy(organizations, closureOf(organizations...))
+		counts := report.CountByType()
+		if counts[HierarchyIssueWrongPath] != 2 || counts[HierarchyIssueWrongLevel] != 2 || counts[HierarchyIssueWrongClosure] != 2 {
+			t.Fatalf("unexpected issues %v", counts)
+		}
+		if len(report.PathRepairs) != 2 || report.PathRepairs[1].Path.GetOrDefault() != "a.b.c" || report.PathRepairs[1].Level.GetOrDefault() != 2 {
+			t.Fatalf("unexpected repairs %+v", report.PathRepairs)
+		}
+		if len(report.ClosureRepairs["c"]) != 3 {
+			t.Errorf("expected three closure rows for c, got %+v", report.ClosureRepairs["c"])
+		}
+	})
+
+	t.Run("cycle and missing parent", func(t *testing.T) {
+		organizations := []*Organization{
+			hierarchyNode("a", "b", "a", 0),
+			hierarchyNode("b", "a", "b", 0),
+			hierarchyNode("c", "a", "a.c", 1),
+			hierarchyNode("d", "gone", "gone.d", 1),
+		}
+
+		report := CheckOrganizationHierarchy(organizations, closureOf(organizations...))
+		counts := report.CountByType()
+		if counts[HierarchyIssueCycle] != 3 || counts[HierarchyIssueMissingParent] != 1 || len(report.PathRepairs) != 0 {
+			t.Fatalf("unexpected issues %v", counts)
+		}
+		for _, issue := range report.Issues {
+			if issue.IsRepairable {
+				t.Errorf("expected %s of %s to need a decision", issue.Type, issue.OrganizationUUID)
+			}
+		}
+	})
+
+	t.Run("orphaned parent", func(t *testing.T) {
+		deletedAt := time.Now()
+		parent := hierarchyNode("a", "", "a", 0)
+		parent.DeletedAt = &deletedAt
+		organizations := []*Organization{parent, hierarchyNode("b", "a", "a.b", 1)}
+
+		report := CheckOrganizationHierarchy(organizations, closureOf(organizations...))
+		if len(report.Issues) != 1 || report.Issues[0].Type != HierarchyIssueOrphanedParent || report.Issues[0].OrganizationUUID != "b" {
+			t.Fatalf("unexpected issues %+v", report.Issues)
+		}
+	})
+}
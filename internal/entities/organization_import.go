@@ -0,0 +1,188 @@
+package entities
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/laksanagusta/identity/constants"
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+// What an import does with one of its rows
+const (
+	OrganizationImportCreate    = "create"
+	OrganizationImportUpdate    = "update"
+	OrganizationImportUnchanged = "unchanged"
+)
+
+// OrganizationImportRow is one organization of an import file, identified by its code and placed
+// below the organization with ParentCode, a root when empty. Empty address and coordinates leave the
+// values of an existing organization as they are.
+type OrganizationImportRow struct {
+	Code       string               `json:"code"`
+	ParentCode string               `json:"parent_code"`
+	Name       nullable.NullString  `json:"name"`
+	Type       nullable.NullString  `json:"type"`
+	Address    nullable.NullString  `json:"address"`
+	Latitude   nullable.NullFloat64 `json:"latitude"`
+	Longitude  nullable.NullFloat64 `json:"longitude"`
+}
+
+// OrganizationImportRows are numbered from 1 in file order in error keys, nested children come right
+// after their parent
+type OrganizationImportRows []OrganizationImportRow
+
+// Codes returns the codes of the rows together with the parent codes they refer to
+func (rs OrganizationImportRows) Codes() []string {
+	codes := make([]string, 0, len(rs)*2)
+	for _, row := range rs {
+		codes = append(codes, row.Code)
+		if row.ParentCode != "" {
+			codes = append(codes, row.ParentCode)
+		}
+	}
+
+	slices.Sort(codes)
+	return slices.Compact(codes)
+}
+
+// Validate checks that codes are unique in the file, that every parent code is either in the file or
+// an organization in existing, and that the parents given in the file do not loop. existing holds
+// the organizations with the codes of the rows, keyed by code.
+func (rs OrganizationImportRows) Validate(existing map[string]*Organization) map[string][]string {
+	errs := map[string][]string{}
+	rows := make(map[string]OrganizationImportRow, len(rs))
+	for i, row := range rs {
+		key := rs.errorKey(i)
+		if current, ok := existing[row.Code]; ok && current.DeletedAt != nil {
+			errs[key+"code"] = append(errs[key+"code"], constants.ErrMsgOrganizationImportDeleted)
+		}
+		if _, ok := rows[row.Code]; ok {
+			errs[key+"code"] = append(errs[key+"code"], constants.ErrMsgOrganizationImportDuplicate)
+			continue
+		}
+		rows[row.Code] = row
+	}
+
+	for i, row := range rs {
+		if row.ParentCode == "" {
+			continue
+		}
+
+		key := rs.errorKey(i) + "parent_code"
+		_, inFile := rows[row.ParentCode]
+		parent, inTree := existing[row.ParentCode]
+		if !inFile && (!inTree || parent.DeletedAt != nil) {
+			errs[key] = append(errs[key], constants.ErrMsgNotFound)
+			continue
+		}
+
+		visited := map[string]bool{row.Code: true}
+		for code := row.ParentCode; code != ""; code = rows[code].ParentCode {
+			if visited[code] {
+				errs[key] = append(errs[key], constants.ErrMsgOrganizationCycle)
+				break
+			}
+			visited[code] = true
+		}
+	}
+
+	return errs
+}
+
+// Ordered returns the rows with the parents given in the file before their children, rows keep their
+// file order otherwise. The rows have to be valid.
+func (rs OrganizationImportRows) Ordered() OrganizationImportRows {
+	inFile := make(map[string]bool, len(rs))
+	for _, row := range rs {
+		inFile[row.Code] = true
+	}
+
+	ordered := make(OrganizationImportRows, 0, len(rs))
+	placed := make(map[string]bool, len(rs))
+	for len(ordered) < len(rs) {
+		progressed := false
+		for _, row := range rs {
+			if placed[row.Code] || (inFile[row.ParentCode] && !placed[row.ParentCode]) {
+				continue
+			}
+			ordered = append(ordered, row)
+			placed[row.Code] = true
+			progressed = true
+		}
+		if !progressed {
+			break
+		}
+	}
+
+	return ordered
+}
+
+func (rs OrganizationImportRows) errorKey(i int) string {
+	return "rows." + strconv.Itoa(i+1) + "."
+}
+
+// ErrorKey returns the prefix of the error keys of the row with code
+func (rs OrganizationImportRows) ErrorKey(code string) string {
+	return rs.errorKey(slices.IndexFunc(rs, func(row OrganizationImportRow) bool { return row.Code == code }))
+}
+
+// NewOrganization returns the organization the row creates below parentUUID
+func (r OrganizationImportRow) NewOrganization(username string, parentUUID nullable.NullString) Organization {
+	return Organization{
+		SoftDeleteModel: SoftDeleteModel{BaseModel: NewBaseModel(username)},
+		Name:            r.Name,
+		Code:            nullable.NewString(r.Code),
+		Address:         r.Address,
+		Latitude:        r.Latitude,
+		Longitude:       r.Longitude,
+		Type:            r.Type,
+		ParentUUID:      parentUUID,
+		IsActive:        true,
+	}
+}
+
+// Update returns the fields of existing the row changes, set on an organization that holds only
+// those, and the names of the changed fields. parentUUID is where the row places the organization.
+func (r OrganizationImportRow) Update(existing Organization, parentUUID nullable.NullString, username string) (Organization, []string) {
+	update := Organization{}
+	update.BaseModel = existing.BaseModel
+	update.UpdateModel(username)
+
+	var fields []string
+	if r.Name.GetOrDefault() != existing.Name.GetOrDefault() {
+		update.Name = r.Name
+		fields = append(fields, OrganizationAttributeName)
+	}
+	if r.Type.GetOrDefault() != existing.Type.GetOrDefault() {
+		update.Type = r.Type
+		fields = append(fields, OrganizationAttributeType)
+	}
+	if r.Address.IsNotEmpty() && r.Address.GetOrDefault() != existing.Address.GetOrDefault() {
+		update.Address = r.Address
+		fields = append(fields, OrganizationFieldAddress)
+	}
+	if r.Latitude.Val != nil && (existing.Latitude.Val == nil || *r.Latitude.Val != *existing.Latitude.Val) {
+		update.Latitude = r.Latitude
+		fields = append(fields, OrganizationFieldLatitude)
+	}
+	if r.Longitude.Val != nil && (existing.Longitude.Val == nil || *r.Longitude.Val != *existing.Longitude.Val) {
+		update.Longitude = r.Longitude
+		fields = append(fields, OrganizationFieldLongitude)
+	}
+	if parentUUID.GetOrDefault() != existing.ParentUUID.GetOrDefault() {
+		update.ParentUUID = parentUUID
+		fields = append(fields, OrganizationAttributeParent)
+	}
+
+	return update, fields
+}
+
+// OrganizationImportChange is what an import does, or in a dry run would do, with one row
+type OrganizationImportChange struct {
+	Action           string
+	Code             string
+	OrganizationUUID string
+	// Fields are the attributes an update changes
+	Fields []string
+}
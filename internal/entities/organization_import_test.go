@@ -0,0 +1,98 @@
+package entities
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/laksanagusta/identity/constants"
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+func importRow(code, parentCode string) OrganizationImportRow {
+	return OrganizationImportRow{Code: code, ParentCode: parentCode, Name: nullable.NewString(code), Type: nullable.NewString("unit")}
+}
+
+func TestOrganizationImportRowsValidate(t *testing.T) {
+	deletedAt := time.Now()
+	deleted := &Organization{Code: nullable.NewString("old")}
+	deleted.DeletedAt = &deletedAt
+	existing := map[string]*Organization{
+		"hq":   {Code: nullable.NewString("hq")},
+		"old":  deleted,
+		"gone": deleted,
+	}
+
+	rows := OrganizationImportRows{
+		importRow("a", "hq"),
+		importRow("a", ""),
+		importRow("b", "missing"),
+		importRow("c", "d"),
+		importRow("d", "c"),
+		importRow("e", "gone"),
+		importRow("old", ""),
+	}
+
+	errs := rows.Validate(existing)
+	expected := map[string]string{
+		"rows.2.code":        constants.ErrMsgOrganizationImportDuplicate,
+		"rows.3.parent_code": constants.ErrMsgNotFound,
+		"rows.4.parent_code": constants.ErrMsgOrganizationCycle,
+		"rows.5.parent_code": constants.ErrMsgOrganizationCycle,
+		"rows.6.parent_code": constants.ErrMsgNotFound,
+		"rows.7.code":        constants.ErrMsgOrganizationImportDeleted,
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("unexpected errors %v", errs)
+	}
+	for key, errMsg := range expected {
+		if !slices.Contains(errs[key], errMsg) {
+			t.Errorf("expected %q on %s, got %v", errMsg, key, errs[key])
+		}
+	}
+}
+
+func TestOrganizationImportRowsOrdered(t *testing.T) {
+	rows := OrganizationImportRows{
+		importRow("team", "dept"),
+		importRow("dept", "company"),
+		importRow("other", "hq"),
+		importRow("company", ""),
+	}
+
+	var codes []string
+	for _, row := range rows.Ordered() {
+		codes = append(codes, row.Code)
+	}
+
+	if !slices.Equal(codes, []string{"other", "company", "dept", "team"}) {
+		t.Fatalf("unexpected order %v", codes)
+	}
+}
+
+func TestOrganizationImportRowUpdate(t *testing.T) {
+	existing := Organization{
+		Name:       nullable.NewString("Sales"),
+		Type:       nullable.NewString("unit"),
+		Address:    nullable.NewString("Main street"),
+		Latitude:   nullable.NewFloat64(1),
+		Longitude:  nullable.NewFloat64(2),
+		ParentUUID: nullable.NewString("parent"),
+	}
+	existing.UUID = "sales"
+
+	row := OrganizationImportRow{Code: "sales", Name: nullable.NewString("Sales"), Type: nullable.NewString("unit")}
+	if _, fields := row.Update(existing, nullable.NewString("parent"), "importer"); len(fields) != 0 {
+		t.Fatalf("expected empty address and coordinates to leave the organization unchanged, got %v", fields)
+	}
+
+	row.Name = nullable.NewString("Sales & Marketing")
+	row.Latitude = nullable.NewFloat64(3)
+	update, fields := row.Update(existing, nullable.NullString{}, "importer")
+	if !slices.Equal(fields, []string{OrganizationAttributeName, OrganizationFieldLatitude, OrganizationAttributeParent}) {
+		t.Fatalf("unexpected fields %v", fields)
+	}
+	if update.UUID != "sales" || update.UpdatedBy != "importer" || update.Type.IsExists || update.Address.IsExists {
+		t.Errorf("expected only the changed fields to be set, got %+v", update)
+	}
+}
@@ -0,0 +1,28 @@
+package entities
+
+// OrganizationRestructure is what a merge or split moves from the source organization to the target
+type OrganizationRestructure struct {
+	SourceUUID string
+	TargetUUID string
+	// UserUUIDs are the users whose primary organization or positions moved
+	UserUUIDs []string
+	// Children are the path changes of every organization in the moved subtrees
+	Children []OrganizationPathChange
+	// GroupUUIDs are the dynamic groups whose organization filters now point at the target
+	GroupUUIDs []string
+}
+
+func (r OrganizationRestructure) AuditMetadata() map[string]any {
+	childUUIDs := make([]string, 0, len(r.Children))
+	for _, child := range r.Children {
+		childUUIDs = append(childUUIDs, child.UUID)
+	}
+
+	return map[string]any{
+		"source_id":        r.SourceUUID,
+		"target_id":        r.TargetUUID,
+		"user_ids":         r.UserUUIDs,
+		"organization_ids": childUUIDs,
+		"group_ids":        r.GroupUUIDs,
+	}
+}
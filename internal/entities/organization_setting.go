@@ -0,0 +1,129 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+type OrganizationSettingDefinition struct {
+	BaseModel
+	Key          nullable.NullString `json:"key" db:"key"`
+	Label        nullable.NullString `json:"label" db:"label"`
+	Description  nullable.NullString `json:"description" db:"description"`
+	Type         nullable.NullString `json:"type" db:"type"`
+	EnumValues   []string            `json:"enum_values" db:"enum_values"`
+	DefaultValue SettingValue        `json:"default_value" db:"default_value"`
+}
+
+// ValidateValue checks a single decoded JSON value against the definition and returns the value to
+// store, or an error message. Settings share the types of user attributes.
+func (d *OrganizationSettingDefinition) ValidateValue(value any) (any, string) {
+	return validateTypedValue(d.Type.GetOrDefault(), d.EnumValues, "", value)
+}
+
+type OrganizationSettingDefinitions []*OrganizationSettingDefinition
+
+func (ds OrganizationSettingDefinitions) ByKey() map[string]*OrganizationSettingDefinition {
+	m := make(map[string]*OrganizationSettingDefinition, len(ds))
+	for _, d := range ds {
+		m[d.Key.GetOrDefault()] = d
+	}
+	return m
+}
+
+// OrganizationSetting is a value set on an organization. Depth is only loaded with inherited values,
+// it counts the levels between the organization setting the value and the one it is resolved for.
+type OrganizationSetting struct {
+	BaseModel
+	OrganizationUUID string       `json:"organization_id" db:"organization_uuid"`
+	Key              string       `json:"key" db:"key"`
+	Value            SettingValue `json:"value" db:"value"`
+	Depth            int32        `json:"-" db:"depth"`
+}
+
+type OrganizationSettings []*OrganizationSetting
+
+// EffectiveOrganizationSetting is the value of a setting for an organization and where it comes from
+type EffectiveOrganizationSetting struct {
+	Key   string
+	Value SettingValue
+	// SourceOrganizationUUID is the organization setting the value, empty when the default applies
+	SourceOrganizationUUID nullable.NullString
+}
+
+// IsInheritedBy reports whether the value is set on an ancestor of organizationUUID or is the default
+func (s EffectiveOrganizationSetting) IsInheritedBy(organizationUUID string) bool {
+	return s.SourceOrganizationUUID.GetOrDefault() != organizationUUID
+}
+
+// Resolve returns the effective value of every definition, in definition order. settings are the
+// values set on an organization and its ancestors, the one set nearest to the organization wins and
+// the default applies when none is set. Values of unknown keys are ignored.
+func (ds OrganizationSettingDefinitions) Resolve(settings OrganizationSettings) []EffectiveOrganizationSetting {
+	nearest := make(map[string]*OrganizationSetting, len(settings))
+	for _, setting := range settings {
+		if current, ok := nearest[setting.Key]; !ok || setting.Depth < current.Depth {
+			nearest[setting.Key] = setting
+		}
+	}
+
+	effective := make([]EffectiveOrganizationSetting, 0, len(ds))
+	for _, definition := range ds {
+		key := definition.Key.GetOrDefault()
+		setting, ok := nearest[key]
+		if !ok {
+			effective = append(effective, EffectiveOrganizationSetting{Key: key, Value: definition.DefaultValue})
+			continue
+		}
+
+		effective = append(effective, EffectiveOrganizationSetting{
+			Key:                    key,
+			Value:                  setting.Value,
+			SourceOrganizationUUID: nullable.NewString(setting.OrganizationUUID),
+		})
+	}
+
+	return effective
+}
+
+// SettingValue is the JSONB representation of a single setting value of any JSON type, a nil Val
+// is stored as NULL
+type SettingValue struct {
+	Val any
+}
+
+func (v SettingValue) Value() (driver.Value, error) {
+	if v.Val == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(v.Val)
+}
+
+func (v *SettingValue) Scan(src any) error {
+	var data []byte
+	switch s := src.(type) {
+	case nil:
+		v.Val = nil
+		return nil
+	case []byte:
+		data = s
+	case string:
+		data = []byte(s)
+	default:
+		return errors.New("unsupported type for setting value")
+	}
+
+	return json.Unmarshal(data, &v.Val)
+}
+
+func (v SettingValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.Val)
+}
+
+func (v *SettingValue) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &v.Val)
+}
@@ -0,0 +1,88 @@
+package entities
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/laksanagusta/identity/constants"
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+func TestOrganizationSettingDefinitions_Resolve(t *testing.T) {
+	definitions := OrganizationSettingDefinitions{
+		{Key: nullable.NewString("default_locale"), Type: nullable.NewString(AttributeTypeString), DefaultValue: SettingValue{Val: "en"}},
+		{Key: nullable.NewString("approval_threshold"), Type: nullable.NewString(AttributeTypeNumber)},
+		{Key: nullable.NewString("working_days"), Type: nullable.NewString(AttributeTypeNumber), DefaultValue: SettingValue{Val: float64(5)}},
+	}
+
+	settings := OrganizationSettings{
+		{OrganizationUUID: "root", Key: "approval_threshold", Value: SettingValue{Val: float64(1000)}, Depth: 2},
+		{OrganizationUUID: "branch", Key: "approval_threshold", Value: SettingValue{Val: float64(500)}, Depth: 1},
+		{OrganizationUUID: "team", Key: "default_locale", Value: SettingValue{Val: "id"}, Depth: 0},
+		{OrganizationUUID: "root", Key: "removed_setting", Value: SettingValue{Val: true}, Depth: 2},
+	}
+
+	effective := definitions.Resolve(settings)
+	if len(effective) != 3 {
+		t.Fatalf("expected a setting per definition, got %d", len(effective))
+	}
+
+	tests := []struct {
+		key    string
+		value  any
+		source string
+	}{
+		{"default_locale", "id", "team"},
+		{"approval_threshold", float64(500), "branch"},
+		{"working_days", float64(5), ""},
+	}
+
+	for i, tt := range tests {
+		got := effective[i]
+		if got.Key != tt.key || got.Value.Val != tt.value || got.SourceOrganizationUUID.GetOrDefault() != tt.source {
+			t.Errorf("got %s = %v from %q, want %s = %v from %q", got.Key, got.Value.Val, got.SourceOrganizationUUID.GetOrDefault(), tt.key, tt.value, tt.source)
+		}
+	}
+
+	if effective[0].IsInheritedBy("team") || !effective[1].IsInheritedBy("team") || !effective[2].IsInheritedBy("team") {
+		t.Error("only values set on the organization itself should not be inherited")
+	}
+}
+
+func TestOrganizationSettingDefinition_ValidateValue(t *testing.T) {
+	definition := OrganizationSettingDefinition{Type: nullable.NewString(AttributeTypeEnum), EnumValues: []string{"en", "id"}}
+
+	if _, errMsg := definition.ValidateValue("id"); errMsg != "" {
+		t.Errorf("unexpected error %q", errMsg)
+	}
+	if _, errMsg := definition.ValidateValue("fr"); errMsg != constants.ErrMsgAttributeInvalidEnum {
+		t.Errorf("got %q, want %q", errMsg, constants.ErrMsgAttributeInvalidEnum)
+	}
+	if _, errMsg := definition.ValidateValue(float64(1)); errMsg != constants.ErrMsgAttributeInvalidType {
+		t.Errorf("got %q, want %q", errMsg, constants.ErrMsgAttributeInvalidType)
+	}
+}
+
+func TestSettingValue_ScanValue(t *testing.T) {
+	value, err := SettingValue{Val: []any{"mon", "tue"}}.Value()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var scanned SettingValue
+	if err := scanned.Scan(value); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if days, ok := scanned.Val.([]any); !ok || len(days) != 2 {
+		t.Errorf("unexpected value %v", scanned.Val)
+	}
+
+	if value, err := (SettingValue{}).Value(); value != nil || err != nil {
+		t.Errorf("expected NULL for an empty value, got %v %v", value, err)
+	}
+
+	encoded, _ := json.Marshal(SettingValue{Val: true})
+	if string(encoded) != "true" {
+		t.Errorf("got %s", encoded)
+	}
+}
@@ -0,0 +1,86 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+// Keys of the user status breakdown, every user is counted once as active or inactive and once as
+// approved or pending approval
+const (
+	OrganizationStatsActive          = "active"
+	OrganizationStatsInactive        = "inactive"
+	OrganizationStatsApproved        = "approved"
+	OrganizationStatsPendingApproval = "pending_approval"
+)
+
+// OrganizationStatsMonthLayout is the key of a month in the new joiners breakdown
+const OrganizationStatsMonthLayout = "2006-01"
+
+// OrganizationHeadcount counts the users whose primary organization is the organization itself in
+// Direct and those of its whole subtree in Total
+type OrganizationHeadcount struct {
+	OrganizationUUID string              `db:"organization_uuid"`
+	Name             nullable.NullString `db:"name"`
+	Depth            int32               `db:"depth"`
+	Direct           int64               `db:"direct"`
+	Total            int64               `db:"total"`
+}
+
+// OrganizationStatsBucket is one group of a breakdown, Label is only set where Key is not readable
+type OrganizationStatsBucket struct {
+	Key    string              `db:"key"`
+	Label  nullable.NullString `db:"label"`
+	Direct int64               `db:"direct"`
+	Total  int64               `db:"total"`
+}
+
+type OrganizationStats struct {
+	Headcount OrganizationHeadcount
+	// SubOrganizations are the headcounts of the organizations below, parents first
+	SubOrganizations []OrganizationHeadcount
+	ByStatus         []OrganizationStatsBucket
+	ByDirectRole     []OrganizationStatsBucket
+	JoinersByMonth   []OrganizationStatsBucket
+}
+
+// StatusBuckets returns the status breakdown in a fixed order with the statuses nobody has included
+func StatusBuckets(buckets []OrganizationStatsBucket) []OrganizationStatsBucket {
+	return fillBuckets(buckets, []string{
+		OrganizationStatsActive,
+		OrganizationStatsInactive,
+		OrganizationStatsApproved,
+		OrganizationStatsPendingApproval,
+	})
+}
+
+// MonthBuckets returns the joiners of every month from the one of since up to the one of until,
+// months without joiners included
+func MonthBuckets(buckets []OrganizationStatsBucket, since time.Time, until time.Time) []OrganizationStatsBucket {
+	var months []string
+	last := time.Date(until.Year(), until.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for month := time.Date(since.Year(), since.Month(), 1, 0, 0, 0, 0, time.UTC); !month.After(last); month = month.AddDate(0, 1, 0) {
+		months = append(months, month.Format(OrganizationStatsMonthLayout))
+	}
+
+	return fillBuckets(buckets, months)
+}
+
+func fillBuckets(buckets []OrganizationStatsBucket, keys []string) []OrganizationStatsBucket {
+	byKey := make(map[string]OrganizationStatsBucket, len(buckets))
+	for _, bucket := range buckets {
+		byKey[bucket.Key] = bucket
+	}
+
+	filled := make([]OrganizationStatsBucket, 0, len(keys))
+	for _, key := range keys {
+		bucket, ok := byKey[key]
+		if !ok {
+			bucket = OrganizationStatsBucket{Key: key}
+		}
+		filled = append(filled, bucket)
+	}
+
+	return filled
+}
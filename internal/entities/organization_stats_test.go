@@ -0,0 +1,38 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMonthBuckets(t *testing.T) {
+	since := time.Date(2025, time.November, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, time.February, 17, 8, 0, 0, 0, time.UTC)
+
+	buckets := MonthBuckets([]OrganizationStatsBucket{{Key: "2025-12", Direct: 1, Total: 3}}, since, until)
+
+	expected := []OrganizationStatsBucket{{Key: "2025-11"}, {Key: "2025-12", Direct: 1, Total: 3}, {Key: "2026-01"}, {Key: "2026-02"}}
+	if len(buckets) != len(expected) {
+		t.Fatalf("expected %d months, got %+v", len(expected), buckets)
+	}
+	for i := range expected {
+		if buckets[i] != expected[i] {
+			t.Errorf("month %d: expected %+v, got %+v", i, expected[i], buckets[i])
+		}
+	}
+}
+
+func TestStatusBuckets(t *testing.T) {
+	buckets := StatusBuckets([]OrganizationStatsBucket{
+		{Key: OrganizationStatsPendingApproval, Direct: 2, Total: 2},
+		{Key: OrganizationStatsActive, Direct: 4, Total: 9},
+	})
+
+	keys := []string{OrganizationStatsActive, OrganizationStatsInactive, OrganizationStatsApproved, OrganizationStatsPendingApproval}
+	totals := []int64{9, 0, 0, 2}
+	for i, bucket := range buckets {
+		if bucket.Key != keys[i] || bucket.Total != totals[i] {
+			t.Errorf("expected %s with %d, got %+v", keys[i], totals[i], bucket)
+		}
+	}
+}
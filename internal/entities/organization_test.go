@@ -0,0 +1,73 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+func TestOrganization_BuildPath(t *testing.T) {
+	organization := Organization{}
+	organization.UUID = "c"
+
+	organization.BuildPath("a.b")
+	if organization.Path.GetOrDefault() != "a.b.c" || organization.Level.GetOrDefault() != 2 {
+		t.Errorf("got path %q level %d", organization.Path.GetOrDefault(), organization.Level.GetOrDefault())
+	}
+
+	organization.BuildPath("")
+	if organization.Path.GetOrDefault() != "c" || organization.Level.GetOrDefault() != 0 {
+		t.Errorf("got path %q level %d", organization.Path.GetOrDefault(), organization.Level.GetOrDefault())
+	}
+}
+
+func TestOrganization_IsInSubtreeOf(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"a.b", true},
+		{"a.b.c", true},
+		{"a.bc", false},
+		{"a", false},
+	}
+
+	for _, tt := range tests {
+		organization := Organization{Path: nullable.NewString(tt.path)}
+		if got := organization.IsInSubtreeOf("a.b"); got != tt.want {
+			t.Errorf("IsInSubtreeOf(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestMovedPath(t *testing.T) {
+	if got := MovedPath("a.b.c.d", "a.b", "x.b"); got != "x.b.c.d" {
+		t.Errorf("got %q", got)
+	}
+	if got := MovedPath("a.b", "a.b", "b"); got != "b" || PathLevel(got) != 0 {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestOrganization_AttachDescendants(t *testing.T) {
+	newNode := func(uuid string, parentUUID string) *Organization {
+		organization := &Organization{ParentUUID: nullable.NewString(parentUUID)}
+		organization.UUID = uuid
+		return organization
+	}
+
+	root := newNode("a", "")
+	b := newNode("b", "a")
+	c := newNode("c", "b")
+	d := newNode("d", "a")
+	orphan := newNode("x", "missing")
+
+	root.AttachDescendants([]*Organization{b, c, d, orphan})
+
+	if len(root.Children) != 2 || root.Children[0] != b || root.Children[1] != d {
+		t.Fatalf("unexpected root children %v", root.Children)
+	}
+	if len(b.Children) != 1 || b.Children[0] != c {
+		t.Errorf("unexpected children of b %v", b.Children)
+	}
+}
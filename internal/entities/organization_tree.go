@@ -0,0 +1,68 @@
+package entities
+
+import "strings"
+
+// OrganizationTree is a whole hierarchy held in memory, used to navigate the organizations as they
+// were or will be on another day than today
+type OrganizationTree struct {
+	nodes   map[string]*Organization
+	ordered []*Organization
+}
+
+// NewOrganizationTree indexes organizations, which come ordered parents before children with their
+// path and level set
+func NewOrganizationTree(organizations []*Organization) OrganizationTree {
+	nodes := make(map[string]*Organization, len(organizations))
+	for _, organization := range organizations {
+		nodes[organization.UUID] = organization
+	}
+
+	return OrganizationTree{nodes: nodes, ordered: organizations}
+}
+
+// Node returns the organization with uuid, nil when it is not part of the tree
+func (t OrganizationTree) Node(uuid string) *Organization {
+	return t.nodes[uuid]
+}
+
+// Ancestors returns the organizations above uuid, root first
+func (t OrganizationTree) Ancestors(uuid string) []*Organization {
+	organization, ok := t.nodes[uuid]
+	if !ok {
+		return []*Organization{}
+	}
+
+	labels := strings.Split(organization.Path.GetOrDefault(), OrganizationPathSeparator)
+	ancestors := make([]*Organization, 0, len(labels))
+	for _, label := range labels[:len(labels)-1] {
+		if ancestor, ok := t.nodes[label]; ok {
+			ancestors = append(ancestors, ancestor)
+		}
+	}
+
+	return ancestors
+}
+
+// Descendants returns the organizations below uuid down to maxDepth levels, 0 for all of them,
+// parents before children
+func (t OrganizationTree) Descendants(uuid string, maxDepth int32) []*Organization {
+	descendants := []*Organization{}
+	organization, ok := t.nodes[uuid]
+	if !ok {
+		return descendants
+	}
+
+	path := organization.Path.GetOrDefault()
+	level := organization.Level.GetOrDefault()
+	for _, node := range t.ordered {
+		if node.UUID == uuid || !node.IsInSubtreeOf(path) {
+			continue
+		}
+		if maxDepth > 0 && node.Level.GetOrDefault() > level+maxDepth {
+			continue
+		}
+		descendants = append(descendants, node)
+	}
+
+	return descendants
+}
@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+func newTreeNode(uuid string, parent *Organization) *Organization {
+	organization := &Organization{}
+	organization.UUID = uuid
+
+	parentPath := ""
+	if parent != nil {
+		organization.ParentUUID = nullable.NewString(parent.UUID)
+		parentPath = parent.Path.GetOrDefault()
+	}
+	organization.BuildPath(parentPath)
+
+	return organization
+}
+
+func TestOrganizationTree(t *testing.T) {
+	root := newTreeNode("root", nil)
+	finance := newTreeNode("finance", root)
+	treasury := newTreeNode("treasury", finance)
+	payments := newTreeNode("payments", treasury)
+	sales := newTreeNode("sales", root)
+
+	tree := NewOrganizationTree([]*Organization{root, finance, sales, treasury, payments})
+
+	if tree.Node("missing") != nil {
+		t.Error("expected no node for an unknown uuid")
+	}
+
+	ancestors := Organizations(tree.Ancestors("payments")).Uuids()
+	if len(ancestors) != 3 || ancestors[0] != "root" || ancestors[2] != "treasury" {
+		t.Errorf("got ancestors %v", ancestors)
+	}
+
+	descendants := Organizations(tree.Descendants("root", 0)).Uuids()
+	if len(descendants) != 4 {
+		t.Errorf("got descendants %v", descendants)
+	}
+
+	children := Organizations(tree.Descendants("root", 1)).Uuids()
+	if len(children) != 2 || children[0] != "finance" || children[1] != "sales" {
+		t.Errorf("got children %v", children)
+	}
+}
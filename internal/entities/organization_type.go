@@ -0,0 +1,91 @@
+package entities
+
+import (
+	"slices"
+
+	"github.com/laksanagusta/identity/constants"
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+const (
+	OrganizationFieldAddress   = "address"
+	OrganizationFieldLatitude  = "latitude"
+	OrganizationFieldLongitude = "longitude"
+)
+
+// OrganizationRequirableFields are the optional organization fields a type can make mandatory
+var OrganizationRequirableFields = []string{OrganizationFieldAddress, OrganizationFieldLatitude, OrganizationFieldLongitude}
+
+// OrganizationType is a registry entry for organizations.type, it decides where organizations of
+// the type may sit in the hierarchy and which fields they must fill
+type OrganizationType struct {
+	BaseModel
+	Code               nullable.NullString `json:"code" db:"code"`
+	Label              nullable.NullString `json:"label" db:"label"`
+	IsRootAllowed      bool                `json:"is_root_allowed" db:"is_root_allowed"`
+	AllowedParentTypes []string            `json:"allowed_parent_types" db:"allowed_parent_types"`
+	MaxDepth           nullable.NullInt32  `json:"max_depth" db:"max_depth"`
+	RequiredFields     []string            `json:"required_fields" db:"required_fields"`
+}
+
+// AllowsParent reports whether an organization of type t may be placed below one of parentType,
+// an empty parentType stands for a root organization
+func (t OrganizationType) AllowsParent(parentType string) bool {
+	if parentType == "" {
+		return t.IsRootAllowed
+	}
+
+	return slices.Contains(t.AllowedParentTypes, parentType)
+}
+
+// AllowsLevel reports whether an organization of type t may sit at level, roots are level 0
+func (t OrganizationType) AllowsLevel(level int32) bool {
+	return !t.MaxDepth.IsExists || t.MaxDepth.Val == nil || level <= *t.MaxDepth.Val
+}
+
+// ValidatePlacement returns the problems of placing an organization of type t below an
+// organization of parentType at level, keyed by request field
+func (t OrganizationType) ValidatePlacement(parentType string, level int32) map[string][]string {
+	errs := map[string][]string{}
+	if !t.AllowsParent(parentType) {
+		if parentType == "" {
+			errs["parent_id"] = append(errs["parent_id"], constants.ErrMsgOrganizationTypeRoot)
+		} else {
+			errs["parent_id"] = append(errs["parent_id"], constants.ErrMsgOrganizationTypeParent)
+		}
+	}
+	if !t.AllowsLevel(level) {
+		errs["parent_id"] = append(errs["parent_id"], constants.ErrMsgOrganizationTypeDepth)
+	}
+
+	return errs
+}
+
+// ValidateFields returns the required fields organization leaves empty, keyed by request field
+func (t OrganizationType) ValidateFields(organization Organization) map[string][]string {
+	filled := map[string]bool{
+		OrganizationFieldAddress:   organization.Address.IsNotEmpty(),
+		OrganizationFieldLatitude:  organization.Latitude.Val != nil,
+		OrganizationFieldLongitude: organization.Longitude.Val != nil,
+	}
+
+	errs := map[string][]string{}
+	for _, field := range t.RequiredFields {
+		if isFilled, ok := filled[field]; ok && !isFilled {
+			errs[field] = []string{constants.ErrMsgOrganizationFieldRequired}
+		}
+	}
+
+	return errs
+}
+
+type OrganizationTypes []*OrganizationType
+
+func (ts OrganizationTypes) ByCode() map[string]*OrganizationType {
+	types := make(map[string]*OrganizationType, len(ts))
+	for _, t := range ts {
+		types[t.Code.GetOrDefault()] = t
+	}
+
+	return types
+}
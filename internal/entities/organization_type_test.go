@@ -0,0 +1,58 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/laksanagusta/identity/constants"
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+func TestOrganizationType_ValidatePlacement(t *testing.T) {
+	division := OrganizationType{
+		Code:               nullable.NewString("division"),
+		AllowedParentTypes: []string{"headquarters", "division"},
+		MaxDepth:           nullable.NewInt32(3),
+	}
+
+	tests := []struct {
+		name       string
+		parentType string
+		level      int32
+		want       []string
+	}{
+		{"allowed parent", "headquarters", 1, nil},
+		{"nested below itself", "division", 3, nil},
+		{"not allowed as root", "", 0, []string{constants.ErrMsgOrganizationTypeRoot}},
+		{"parent type not allowed", "branch", 2, []string{constants.ErrMsgOrganizationTypeParent}},
+		{"too deep", "division", 4, []string{constants.ErrMsgOrganizationTypeDepth}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := division.ValidatePlacement(tt.parentType, tt.level)
+			got := errs["parent_id"]
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("got %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestOrganizationType_ValidateFields(t *testing.T) {
+	headquarters := OrganizationType{RequiredFields: []string{OrganizationFieldAddress, OrganizationFieldLatitude}}
+
+	errs := headquarters.ValidateFields(Organization{Address: nullable.NewString("Jl. Sudirman 1")})
+	if len(errs) != 1 || len(errs[OrganizationFieldLatitude]) != 1 {
+		t.Errorf("expected only latitude to be missing, got %v", errs)
+	}
+
+	unrestricted := OrganizationType{}
+	if errs := unrestricted.ValidateFields(Organization{}); len(errs) != 0 {
+		t.Errorf("expected no errors, got %v", errs)
+	}
+}
@@ -0,0 +1,190 @@
+package entities
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+const OrganizationVersionDateLayout = "2006-01-02"
+
+// Organization attributes with an effective dated history
+const (
+	OrganizationAttributeName     = "name"
+	OrganizationAttributeParent   = "parent_uuid"
+	OrganizationAttributeType     = "type"
+	OrganizationAttributeIsActive = "is_active"
+)
+
+var OrganizationVersionedAttributes = []string{
+	OrganizationAttributeName,
+	OrganizationAttributeParent,
+	OrganizationAttributeType,
+	OrganizationAttributeIsActive,
+}
+
+// OrganizationVersion is the value of one organization attribute from ValidFrom until the day before
+// ValidTo, a nil ValidTo is open ended. AppliedAt is nil while the version is scheduled, FailedAt is
+// set with Error when applying it failed and it waits to be scheduled again.
+type OrganizationVersion struct {
+	BaseModel
+	OrganizationUUID string              `json:"organization_id" db:"organization_uuid"`
+	Attribute        string              `json:"attribute" db:"attribute"`
+	Value            nullable.NullString `json:"value" db:"value"`
+	ValidFrom        time.Time           `json:"valid_from" db:"valid_from"`
+	ValidTo          *time.Time          `json:"valid_to" db:"valid_to"`
+	AppliedAt        *time.Time          `json:"applied_at" db:"applied_at"`
+	FailedAt         *time.Time          `json:"failed_at" db:"failed_at"`
+	Error            nullable.NullString `json:"error" db:"error"`
+}
+
+type OrganizationVersions []*OrganizationVersion
+
+// Day truncates t to its date, versions are valid for whole days
+func Day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// NewOrganizationVersions records attributes of organization, all of them when none are given, as
+// of validFrom
+func NewOrganizationVersions(organization Organization, validFrom time.Time, username string, attributes ...string) OrganizationVersions {
+	if len(attributes) == 0 {
+		attributes = OrganizationVersionedAttributes
+	}
+
+	versions := make(OrganizationVersions, 0, len(attributes))
+	for _, attribute := range attributes {
+		versions = append(versions, &OrganizationVersion{
+			BaseModel:        NewBaseModel(username),
+			OrganizationUUID: organization.UUID,
+			Attribute:        attribute,
+			Value:            OrganizationAttributeValue(organization, attribute),
+			ValidFrom:        Day(validFrom),
+		})
+	}
+
+	return versions
+}
+
+// OrganizationAttributeValue is the value of a versioned attribute as stored in a version
+func OrganizationAttributeValue(organization Organization, attribute string) nullable.NullString {
+	switch attribute {
+	case OrganizationAttributeName:
+		return organization.Name
+	case OrganizationAttributeParent:
+		if organization.ParentUUID.IsNotEmpty() {
+			return organization.ParentUUID
+		}
+		return nullable.NewNilString()
+	case OrganizationAttributeType:
+		return organization.Type
+	case OrganizationAttributeIsActive:
+		return nullable.NewString(strconv.FormatBool(organization.IsActive))
+	}
+
+	return nullable.NullString{}
+}
+
+// ApplyTo sets the attribute of organization to the value of the version
+func (v *OrganizationVersion) ApplyTo(organization *Organization) {
+	switch v.Attribute {
+	case OrganizationAttributeName:
+		organization.Name = v.Value
+	case OrganizationAttributeParent:
+		organization.ParentUUID = v.Value
+	case OrganizationAttributeType:
+		organization.Type = v.Value
+	case OrganizationAttributeIsActive:
+		organization.IsActive = v.Value.GetOrDefault() == "true"
+	}
+}
+
+// IsValidOn reports whether the version is in effect on the day of t
+func (v *OrganizationVersion) IsValidOn(t time.Time) bool {
+	day := Day(t)
+	if day.Before(Day(v.ValidFrom)) {
+		return false
+	}
+
+	return v.ValidTo == nil || day.Before(Day(*v.ValidTo))
+}
+
+// Timeline returns the versions of attribute ordered by ValidFrom
+func (vs OrganizationVersions) Timeline(attribute string) OrganizationVersions {
+	timeline := OrganizationVersions{}
+	for _, v := range vs {
+		if v != nil && v.Attribute == attribute {
+			timeline = append(timeline, v)
+		}
+	}
+
+	sort.Slice(timeline, func(i, j int) bool {
+		return timeline[i].ValidFrom.Before(timeline[j].ValidFrom)
+	})
+
+	return timeline
+}
+
+// At returns the version of a timeline in effect on the day of t, nil before the first version
+func (vs OrganizationVersions) At(t time.Time) *OrganizationVersion {
+	for _, v := range vs {
+		if v.IsValidOn(t) {
+			return v
+		}
+	}
+
+	return nil
+}
+
+// Schedule places version in a timeline of the same attribute. A version starting that day takes the
+// new value and is returned as updated, otherwise the version in effect ends the day before and the
+// new version lasts until the next one starts.
+func (vs OrganizationVersions) Schedule(version OrganizationVersion) (updated OrganizationVersions, inserted *OrganizationVersion) {
+	version.ValidFrom = Day(version.ValidFrom)
+	version.ValidTo = nil
+
+	for _, v := range vs {
+		if Day(v.ValidFrom).Equal(version.ValidFrom) {
+			v.Value = version.Value
+			v.AppliedAt = version.AppliedAt
+			v.FailedAt = nil
+			v.Error = nullable.NullString{}
+			v.UpdateModel(version.UpdatedBy)
+			return OrganizationVersions{v}, nil
+		}
+	}
+
+	if current := vs.At(version.ValidFrom); current != nil {
+		version.ValidTo = current.ValidTo
+		validTo := version.ValidFrom
+		current.ValidTo = &validTo
+		current.UpdateModel(version.UpdatedBy)
+		updated = append(updated, current)
+	} else {
+		for _, v := range vs {
+			if v.ValidFrom.After(version.ValidFrom) {
+				validTo := Day(v.ValidFrom)
+				version.ValidTo = &validTo
+				break
+			}
+		}
+	}
+
+	return updated, &version
+}
+
+// Cancel removes a version from its timeline, the version before it takes over its period. The
+// returned version has to be saved, it is nil when the canceled version was the first.
+func (vs OrganizationVersions) Cancel(version *OrganizationVersion, username string) *OrganizationVersion {
+	for _, v := range vs {
+		if v.ValidTo != nil && Day(*v.ValidTo).Equal(Day(version.ValidFrom)) {
+			v.ValidTo = version.ValidTo
+			v.UpdateModel(username)
+			return v
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+func versionDate(value string) time.Time {
+	date, _ := time.Parse(OrganizationVersionDateLayout, value)
+	return date
+}
+
+func newVersion(value, from, to string) *OrganizationVersion {
+	v := &OrganizationVersion{
+		Attribute: OrganizationAttributeName,
+		Value:     nullable.NewString(value),
+		ValidFrom: versionDate(from),
+	}
+	if to != "" {
+		validTo := versionDate(to)
+		v.ValidTo = &validTo
+	}
+	return v
+}
+
+func formatValidTo(v *OrganizationVersion) string {
+	if v.ValidTo == nil {
+		return ""
+	}
+	return v.ValidTo.Format(OrganizationVersionDateLayout)
+}
+
+func TestOrganizationVersions_At(t *testing.T) {
+	timeline := OrganizationVersions{
+		newVersion("Finance", "2026-01-01", "2026-07-01"),
+		newVersion("Treasury", "2026-07-01", ""),
+	}
+
+	tests := []struct {
+		at   string
+		want string
+	}{
+		{"2025-12-31T23:00:00Z", ""},
+		{"2026-01-01T00:00:00Z", "Finance"},
+		{"2026-06-30T23:59:00Z", "Finance"},
+		{"2026-07-01T00:00:00Z", "Treasury"},
+		{"2030-01-01T00:00:00Z", "Treasury"},
+	}
+
+	for _, tt := range tests {
+		at, _ := time.Parse(time.RFC3339, tt.at)
+		got := ""
+		if v := timeline.At(at); v != nil {
+			got = v.Value.GetOrDefault()
+		}
+		if got != tt.want {
+			t.Errorf("At(%s) = %q, want %q", tt.at, got, tt.want)
+		}
+	}
+}
+
+func TestOrganizationVersions_Schedule(t *testing.T) {
+	t.Run("splits the version in effect", func(t *testing.T) {
+		current := newVersion("Finance", "2026-01-01", "")
+		timeline := OrganizationVersions{current}
+
+		updated, inserted := timeline.Schedule(*newVersion("Treasury", "2026-07-01", ""))
+		if len(updated) != 1 || formatValidTo(current) != "2026-07-01" {
+			t.Fatalf("expected the current version to end on 2026-07-01, got %q", formatValidTo(current))
+		}
+		if inserted == nil || inserted.ValidTo != nil {
+			t.Fatalf("expected an open ended version, got %+v", inserted)
+		}
+	})
+
+	t.Run("ends where the next version starts", func(t *testing.T) {
+		current := newVersion("Finance", "2026-01-01", "2026-09-01")
+		timeline := OrganizationVersions{current, newVersion("Group Finance", "2026-09-01", "")}
+
+		_, inserted := timeline.Schedule(*newVersion("Treasury", "2026-07-01", ""))
+		if formatValidTo(current) != "2026-07-01" || formatValidTo(inserted) != "2026-09-01" {
+			t.Fatalf("got current until %q and inserted until %q", formatValidTo(current), formatValidTo(inserted))
+		}
+	})
+
+	t.Run("replaces the value of a version starting the same day", func(t *testing.T) {
+		scheduled := newVersion("Treasury", "2026-07-01", "")
+		timeline := OrganizationVersions{newVersion("Finance", "2026-01-01", "2026-07-01"), scheduled}
+
+		updated, inserted := timeline.Schedule(*newVersion("Group Treasury", "2026-07-01", ""))
+		if inserted != nil || len(updated) != 1 || scheduled.Value.GetOrDefault() != "Group Treasury" {
+			t.Fatalf("expected the scheduled version to be renamed, got %+v", updated)
+		}
+	})
+
+	t.Run("retries a failed version that is scheduled again", func(t *testing.T) {
+		failedAt := time.Now()
+		scheduled := newVersion("Treasury", "2026-07-01", "")
+		scheduled.FailedAt = &failedAt
+		scheduled.Error = nullable.NewString("bad request")
+		timeline := OrganizationVersions{scheduled}
+
+		timeline.Schedule(*newVersion("Group Treasury", "2026-07-01", ""))
+		if scheduled.FailedAt != nil || scheduled.Error.IsExists {
+			t.Fatalf("expected the failure to be cleared, got %+v", scheduled)
+		}
+	})
+
+	t.Run("before the first version", func(t *testing.T) {
+		timeline := OrganizationVersions{newVersion("Finance", "2026-01-01", "")}
+
+		updated, inserted := timeline.Schedule(*newVersion("Finance Project", "2025-10-01", ""))
+		if len(updated) != 0 || formatValidTo(inserted) != "2026-01-01" {
+			t.Fatalf("expected a version until 2026-01-01, got %+v", inserted)
+		}
+	})
+}
+
+func TestOrganizationVersions_Cancel(t *testing.T) {
+	current := newVersion("Finance", "2026-01-01", "2026-07-01")
+	scheduled := newVersion("Treasury", "2026-07-01", "2026-09-01")
+	timeline := OrganizationVersions{current, scheduled, newVersion("Group Finance", "2026-09-01", "")}
+
+	extended := timeline.Cancel(scheduled, "admin")
+	if extended != current || formatValidTo(current) != "2026-09-01" {
+		t.Fatalf("expected the previous version to last until 2026-09-01, got %q", formatValidTo(current))
+	}
+}
+
+func TestOrganizationVersion_ApplyTo(t *testing.T) {
+	organization := Organization{IsActive: true}
+	organization.UUID = "org"
+
+	versions := NewOrganizationVersions(organization, time.Now(), "admin")
+	if len(versions) != len(OrganizationVersionedAttributes) {
+		t.Fatalf("expected a version per attribute, got %d", len(versions))
+	}
+
+	inactive := OrganizationVersion{Attribute: OrganizationAttributeIsActive, Value: nullable.NewString("false")}
+	inactive.ApplyTo(&organization)
+	if organization.IsActive {
+		t.Error("expected the organization to be inactive")
+	}
+
+	parent := OrganizationVersion{Attribute: OrganizationAttributeParent, Value: nullable.NewString("parent")}
+	parent.ApplyTo(&organization)
+	if organization.ParentUUID.GetOrDefault() != "parent" {
+		t.Errorf("got parent %q", organization.ParentUUID.GetOrDefault())
+	}
+}
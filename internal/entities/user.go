@@ -16,15 +16,23 @@ type User struct {
 	PhoneNumber         nullable.NullString `json:"phone_number" db:"phone_number"`
 	PasswordHash        nullable.NullString `json:"-" db:"password_hash"`
 	OrganizationUUID    nullable.NullString `json:"organization_id" db:"organization_uuid"`
+	ManagerUUID         nullable.NullString `json:"manager_id" db:"manager_uuid"`
 	IsActive            bool                `json:"is_active" db:"is_active"`
 	IsApproved          bool                `json:"is_approved" db:"is_approved"`
 	LastLoginAt         *time.Time          `json:"last_login_at" db:"last_login_at"`
 	AvatarGradientStart nullable.NullString `json:"avatar_gradient_start" db:"avatar_gradient_start"`
 	AvatarGradientEnd   nullable.NullString `json:"avatar_gradient_end" db:"avatar_gradient_end"`
+	ErasedAt            *time.Time          `json:"erased_at" db:"erased_at"`
+	Attributes          UserAttributes      `json:"attributes" db:"attributes"`
+	AvatarKey           nullable.NullString `json:"-" db:"avatar_key"`
 
 	Organization *Organization `json:"organization,omitempty" db:"-"`
 	Roles        []*Role       `json:"roles,omitempty" db:"-"`
+	Positions    UserPositions `json:"positions,omitempty" db:"-"`
 	Permissions  []*Permission `json:"permissions"`
+	AvatarURLs   AvatarURLs    `json:"avatar_urls,omitempty" db:"-"`
+	// SearchHighlights maps the fields matching a search term to their <mark> highlighted value
+	SearchHighlights map[string]string `json:"-" db:"-"`
 }
 
 type Users []*User
@@ -36,6 +44,10 @@ func (u *User) GetFullName() string {
 	return u.FirstName.GetOrDefault()
 }
 
+func (u *User) IsErased() bool {
+	return u.ErasedAt != nil
+}
+
 func (us Users) Uuids() []string {
 	uuids := make([]string, 0, len(us))
 	for _, u := range us {
@@ -46,6 +58,15 @@ func (us Users) Uuids() []string {
 	return uuids
 }
 
+func (us Users) Contains(uuid string) bool {
+	for _, u := range us {
+		if u != nil && u.UUID == uuid {
+			return true
+		}
+	}
+	return false
+}
+
 type UserRole struct {
 	BaseModel
 	UserUUID string `json:"user_id" db:"user_uuid"`
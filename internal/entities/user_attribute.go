@@ -0,0 +1,241 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/laksanagusta/identity/constants"
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+const (
+	AttributeTypeString  = "string"
+	AttributeTypeNumber  = "number"
+	AttributeTypeBoolean = "boolean"
+	AttributeTypeDate    = "date"
+	AttributeTypeEnum    = "enum"
+
+	// public attributes are exposed to external API consumers, internal ones only to
+	// authenticated users of this service, hidden ones are never returned by read APIs
+	AttributeVisibilityPublic   = "public"
+	AttributeVisibilityInternal = "internal"
+	AttributeVisibilityHidden   = "hidden"
+
+	AttributeDateLayout = "2006-01-02"
+)
+
+var AttributeKeyRegex = regexp.MustCompile(`^[a-z][a-z0-9_]{0,62}$`)
+
+type UserAttributeDefinition struct {
+	BaseModel
+	Key         nullable.NullString `json:"key" db:"key"`
+	Label       nullable.NullString `json:"label" db:"label"`
+	Description nullable.NullString `json:"description" db:"description"`
+	Type        nullable.NullString `json:"type" db:"type"`
+	IsRequired  bool                `json:"is_required" db:"is_required"`
+	EnumValues  []string            `json:"enum_values" db:"enum_values"`
+	Regex       nullable.NullString `json:"regex" db:"regex"`
+	Visibility  nullable.NullString `json:"visibility" db:"visibility"`
+	IsEditable  bool                `json:"is_editable" db:"is_editable"`
+}
+
+// ValidateValue checks a single decoded JSON value against the definition and
+// returns the value to store, or an error message
+func (d *UserAttributeDefinition) ValidateValue(value any) (any, string) {
+	return validateTypedValue(d.Type.GetOrDefault(), d.EnumValues, d.Regex.GetOrDefault(), value)
+}
+
+// validateTypedValue checks a decoded JSON value against one of the attribute types, pattern is
+// only applied to strings when set
+func validateTypedValue(valueType string, enumValues []string, pattern string, value any) (any, string) {
+	switch valueType {
+	case AttributeTypeString:
+		str, ok := value.(string)
+		if !ok {
+			return nil, constants.ErrMsgAttributeInvalidType
+		}
+		if pattern != "" {
+			re, err := regexp.Compile(pattern)
+			if err != nil || !re.MatchString(str) {
+				return nil, constants.ErrMsgAttributeNoMatch
+			}
+		}
+		return str, ""
+	case AttributeTypeNumber:
+		switch number := value.(type) {
+		case float64:
+			return number, ""
+		case int:
+			return float64(number), ""
+		case int64:
+			return float64(number), ""
+		}
+		return nil, constants.ErrMsgAttributeInvalidType
+	case AttributeTypeBoolean:
+		boolean, ok := value.(bool)
+		if !ok {
+			return nil, constants.ErrMsgAttributeInvalidType
+		}
+		return boolean, ""
+	case AttributeTypeDate:
+		str, ok := value.(string)
+		if !ok {
+			return nil, constants.ErrMsgAttributeInvalidType
+		}
+		if _, err := time.Parse(AttributeDateLayout, str); err != nil {
+			return nil, constants.ErrMsgAttributeInvalidType
+		}
+		return str, ""
+	case AttributeTypeEnum:
+		str, ok := value.(string)
+		if !ok {
+			return nil, constants.ErrMsgAttributeInvalidType
+		}
+		if !slices.Contains(enumValues, str) {
+			return nil, constants.ErrMsgAttributeInvalidEnum
+		}
+		return str, ""
+	}
+
+	return nil, constants.ErrMsgAttributeInvalidType
+}
+
+type UserAttributeDefinitions []*UserAttributeDefinition
+
+func (ds UserAttributeDefinitions) ByKey() map[string]*UserAttributeDefinition {
+	m := make(map[string]*UserAttributeDefinition, len(ds))
+	for _, d := range ds {
+		m[d.Key.GetOrDefault()] = d
+	}
+	return m
+}
+
+// Merge applies patch on top of current. A null value in patch removes the attribute.
+// Locked (non editable) attributes can only be changed when canEditLocked is true.
+// Errors are keyed by "attributes.<key>" so they can be returned as a BadRequestMap.
+func (ds UserAttributeDefinitions) Merge(current, patch UserAttributes, canEditLocked bool) (UserAttributes, map[string][]string) {
+	definitions := ds.ByKey()
+	errs := map[string][]string{}
+
+	merged := make(UserAttributes, len(current)+len(patch))
+	for key, value := range current {
+		merged[key] = value
+	}
+
+	for key, value := range patch {
+		definition, ok := definitions[key]
+		if !ok {
+			errs["attributes."+key] = append(errs["attributes."+key], constants.ErrMsgAttributeUnknown)
+			continue
+		}
+		if !definition.IsEditable && !canEditLocked {
+			errs["attributes."+key] = append(errs["attributes."+key], constants.ErrMsgAttributeNotEditable)
+			continue
+		}
+
+		if value == nil {
+			delete(merged, key)
+			continue
+		}
+
+		normalized, errMsg := definition.ValidateValue(value)
+		if errMsg != "" {
+			errs["attributes."+key] = append(errs["attributes."+key], errMsg)
+			continue
+		}
+
+		merged[key] = normalized
+	}
+
+	for key, definition := range definitions {
+		if _, ok := merged[key]; definition.IsRequired && !ok {
+			if _, reported := errs["attributes."+key]; !reported {
+				errs["attributes."+key] = []string{constants.ErrMsgAttributeRequired}
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return merged, nil
+}
+
+// Visible keeps only attributes whose definition has one of the given visibilities
+func (ds UserAttributeDefinitions) Visible(values UserAttributes, visibilities ...string) UserAttributes {
+	definitions := ds.ByKey()
+
+	visible := UserAttributes{}
+	for key, value := range values {
+		definition, ok := definitions[key]
+		if !ok {
+			continue
+		}
+		if slices.Contains(visibilities, definition.Visibility.GetOrDefault()) {
+			visible[key] = value
+		}
+	}
+
+	return visible
+}
+
+// HiddenFields returns the attributes.<key> filter or sort fields whose attribute is unknown or has
+// none of the given visibilities, callers must not be able to probe values they are not shown
+func (ds UserAttributeDefinitions) HiddenFields(fields []string, visibilities ...string) []string {
+	definitions := ds.ByKey()
+
+	hidden := []string{}
+	for _, field := range fields {
+		column, key, found := strings.Cut(strings.ToLower(strings.TrimSpace(field)), ".")
+		if !found || column != "attributes" {
+			continue
+		}
+
+		definition, ok := definitions[key]
+		if !ok || !slices.Contains(visibilities, definition.Visibility.GetOrDefault()) {
+			hidden = append(hidden, field)
+		}
+	}
+
+	return hidden
+}
+
+// UserAttributes is the JSONB representation of custom attribute values on users
+type UserAttributes map[string]any
+
+func (a UserAttributes) Value() (driver.Value, error) {
+	if a == nil {
+		return []byte("{}"), nil
+	}
+
+	return json.Marshal(a)
+}
+
+func (a *UserAttributes) Scan(src any) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*a = UserAttributes{}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return errors.New("unsupported type for user attributes")
+	}
+
+	attributes := UserAttributes{}
+	if err := json.Unmarshal(data, &attributes); err != nil {
+		return err
+	}
+
+	*a = attributes
+	return nil
+}
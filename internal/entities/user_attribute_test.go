@@ -0,0 +1,190 @@
+package entities
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/laksanagusta/identity/constants"
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+func newDefinition(key, attrType string, mutate func(d *UserAttributeDefinition)) *UserAttributeDefinition {
+	d := &UserAttributeDefinition{
+		Key:        nullable.NewString(key),
+		Type:       nullable.NewString(attrType),
+		Visibility: nullable.NewString(AttributeVisibilityInternal),
+		IsEditable: true,
+	}
+	if mutate != nil {
+		mutate(d)
+	}
+	return d
+}
+
+func TestUserAttributeDefinitions_Merge(t *testing.T) {
+	definitions := UserAttributeDefinitions{
+		newDefinition("nik", AttributeTypeString, func(d *UserAttributeDefinition) {
+			d.Regex = nullable.NewString(`^[0-9]{16}$`)
+			d.IsEditable = false
+		}),
+		newDefinition("job_grade", AttributeTypeNumber, nil),
+		newDefinition("hire_date", AttributeTypeDate, nil),
+		newDefinition("shirt_size", AttributeTypeEnum, func(d *UserAttributeDefinition) {
+			d.EnumValues = []string{"S", "M", "L"}
+			d.IsRequired = true
+		}),
+		newDefinition("remote", AttributeTypeBoolean, nil),
+	}
+
+	tests := []struct {
+		name          string
+		current       UserAttributes
+		patch         UserAttributes
+		canEditLocked bool
+		expected      UserAttributes
+		expectedErrs  map[string]string
+	}{
+		{
+			name:    "Valid values are merged",
+			current: UserAttributes{"shirt_size": "M", "job_grade": float64(3)},
+			patch: UserAttributes{
+				"hire_date": "2024-01-31",
+				"remote":    true,
+				"job_grade": float64(4),
+			},
+			expected: UserAttributes{
+				"shirt_size": "M",
+				"job_grade":  float64(4),
+				"hire_date":  "2024-01-31",
+				"remote":     true,
+			},
+		},
+		{
+			name:     "Null removes an attribute",
+			current:  UserAttributes{"shirt_size": "M", "remote": true},
+			patch:    UserAttributes{"remote": nil},
+			expected: UserAttributes{"shirt_size": "M"},
+		},
+		{
+			name:         "Required attribute cannot be removed",
+			current:      UserAttributes{"shirt_size": "M"},
+			patch:        UserAttributes{"shirt_size": nil},
+			expectedErrs: map[string]string{"attributes.shirt_size": constants.ErrMsgAttributeRequired},
+		},
+		{
+			name:    "Invalid values are reported per key",
+			current: UserAttributes{"shirt_size": "M"},
+			patch: UserAttributes{
+				"shirt_size": "XXL",
+				"job_grade":  "four",
+				"hire_date":  "31-01-2024",
+				"unknown":    "x",
+			},
+			expectedErrs: map[string]string{
+				"attributes.shirt_size": constants.ErrMsgAttributeInvalidEnum,
+				"attributes.job_grade":  constants.ErrMsgAttributeInvalidType,
+				"attributes.hire_date":  constants.ErrMsgAttributeInvalidType,
+				"attributes.unknown":    constants.ErrMsgAttributeUnknown,
+			},
+		},
+		{
+			name:         "Locked attribute cannot be edited by the user",
+			current:      UserAttributes{"shirt_size": "M"},
+			patch:        UserAttributes{"nik": "3201010101010001"},
+			expectedErrs: map[string]string{"attributes.nik": constants.ErrMsgAttributeNotEditable},
+		},
+		{
+			name:          "Locked attribute is validated against its regex",
+			current:       UserAttributes{"shirt_size": "M"},
+			patch:         UserAttributes{"nik": "12345"},
+			canEditLocked: true,
+			expectedErrs:  map[string]string{"attributes.nik": constants.ErrMsgAttributeNoMatch},
+		},
+		{
+			name:          "Locked attribute can be edited by an admin",
+			current:       UserAttributes{"shirt_size": "M"},
+			patch:         UserAttributes{"nik": "3201010101010001"},
+			canEditLocked: true,
+			expected:      UserAttributes{"shirt_size": "M", "nik": "3201010101010001"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, errs := definitions.Merge(tt.current, tt.patch, tt.canEditLocked)
+
+			if len(tt.expectedErrs) > 0 {
+				if len(errs) != len(tt.expectedErrs) {
+					t.Fatalf("expected %d errors, got %v", len(tt.expectedErrs), errs)
+				}
+				for key, msg := range tt.expectedErrs {
+					if len(errs[key]) != 1 || errs[key][0] != msg {
+						t.Errorf("expected error %q for %s, got %v", msg, key, errs[key])
+					}
+				}
+				return
+			}
+
+			if len(errs) > 0 {
+				t.Fatalf("unexpected errors: %v", errs)
+			}
+
+			if len(merged) != len(tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, merged)
+			}
+			for key, value := range tt.expected {
+				if merged[key] != value {
+					t.Errorf("expected %s=%v, got %v", key, value, merged[key])
+				}
+			}
+		})
+	}
+}
+
+func TestUserAttributeDefinitions_Visible(t *testing.T) {
+	definitions := UserAttributeDefinitions{
+		newDefinition("shirt_size", AttributeTypeString, func(d *UserAttributeDefinition) {
+			d.Visibility = nullable.NewString(AttributeVisibilityPublic)
+		}),
+		newDefinition("job_grade", AttributeTypeNumber, nil),
+		newDefinition("nik", AttributeTypeString, func(d *UserAttributeDefinition) {
+			d.Visibility = nullable.NewString(AttributeVisibilityHidden)
+		}),
+	}
+
+	values := UserAttributes{"shirt_size": "M", "job_grade": float64(3), "nik": "3201010101010001", "orphan": "x"}
+
+	external := definitions.Visible(values, AttributeVisibilityPublic)
+	if len(external) != 1 || external["shirt_size"] != "M" {
+		t.Errorf("expected only public attributes, got %v", external)
+	}
+
+	internal := definitions.Visible(values, AttributeVisibilityPublic, AttributeVisibilityInternal)
+	if len(internal) != 2 || internal["nik"] != nil || internal["orphan"] != nil {
+		t.Errorf("expected public and internal attributes, got %v", internal)
+	}
+}
+
+func TestUserAttributeDefinitions_HiddenFields(t *testing.T) {
+	definitions := UserAttributeDefinitions{
+		newDefinition("shirt_size", AttributeTypeString, func(d *UserAttributeDefinition) {
+			d.Visibility = nullable.NewString(AttributeVisibilityPublic)
+		}),
+		newDefinition("job_grade", AttributeTypeNumber, nil),
+		newDefinition("salary_grade", AttributeTypeNumber, func(d *UserAttributeDefinition) {
+			d.Visibility = nullable.NewString(AttributeVisibilityHidden)
+		}),
+	}
+
+	fields := []string{"username", "attributes.shirt_size", "attributes.job_grade", "Attributes.Salary_Grade", "attributes.orphan"}
+
+	external := definitions.HiddenFields(fields, AttributeVisibilityPublic)
+	if !reflect.DeepEqual(external, []string{"attributes.job_grade", "Attributes.Salary_Grade", "attributes.orphan"}) {
+		t.Errorf("unexpected hidden fields for public visibility %v", external)
+	}
+
+	internal := definitions.HiddenFields(fields, AttributeVisibilityPublic, AttributeVisibilityInternal)
+	if !reflect.DeepEqual(internal, []string{"Attributes.Salary_Grade", "attributes.orphan"}) {
+		t.Errorf("unexpected hidden fields for internal visibility %v", internal)
+	}
+}
@@ -0,0 +1,159 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/laksanagusta/identity/pkg/helper"
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+// Statuses of a user import job, a running job that stops being updated is picked up again
+const (
+	UserImportPending   = "pending"
+	UserImportRunning   = "running"
+	UserImportCompleted = "completed"
+	UserImportFailed    = "failed"
+)
+
+// UserImportRow is a user of an import file ready to be created, with its organization and roles
+// resolved and its password hashed. Row numbers the users of the file from 1.
+type UserImportRow struct {
+	Row              int                 `json:"row"`
+	EmployeeID       string              `json:"employee_id"`
+	Username         string              `json:"username"`
+	FirstName        string              `json:"first_name"`
+	LastName         nullable.NullString `json:"last_name"`
+	PhoneNumber      string              `json:"phone_number"`
+	PasswordHash     string              `json:"password_hash"`
+	OrganizationUUID string              `json:"organization_uuid"`
+	RoleUUIDs        []string            `json:"role_uuids"`
+}
+
+type UserImportRows []UserImportRow
+
+func (rs UserImportRows) Value() (driver.Value, error) {
+	if rs == nil {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal(rs)
+}
+
+func (rs *UserImportRows) Scan(src any) error {
+	return scanJSONArray(src, rs)
+}
+
+// UserImportResult is the outcome of one row, UserUUID is set when the user was created and Errors
+// holds the messages per column otherwise
+type UserImportResult struct {
+	Row      int                 `json:"row"`
+	UserUUID string              `json:"user_id,omitempty"`
+	Errors   map[string][]string `json:"errors,omitempty"`
+}
+
+type UserImportResults []UserImportResult
+
+func (rs UserImportResults) Value() (driver.Value, error) {
+	if rs == nil {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal(rs)
+}
+
+func (rs *UserImportResults) Scan(src any) error {
+	return scanJSONArray(src, rs)
+}
+
+// Failed returns the results of the rows that were not imported
+func (rs UserImportResults) Failed() UserImportResults {
+	failed := UserImportResults{}
+	for _, result := range rs {
+		if len(result.Errors) > 0 {
+			failed = append(failed, result)
+		}
+	}
+
+	return failed
+}
+
+type UserImportJob struct {
+	BaseModel
+	Status        string              `db:"status"`
+	FileName      string              `db:"file_name"`
+	Rows          UserImportRows      `db:"rows"`
+	TotalRows     int                 `db:"total_rows"`
+	ProcessedRows int                 `db:"processed_rows"`
+	CreatedCount  int                 `db:"created_count"`
+	FailedCount   int                 `db:"failed_count"`
+	Results       UserImportResults   `db:"results"`
+	Error         nullable.NullString `db:"error"`
+	StartedAt     *time.Time          `db:"started_at"`
+	FinishedAt    *time.Time          `db:"finished_at"`
+}
+
+// NewUserImportJob returns a pending job creating rows
+func NewUserImportJob(username string, fileName string, rows UserImportRows) UserImportJob {
+	return UserImportJob{
+		BaseModel: NewBaseModel(username),
+		Status:    UserImportPending,
+		FileName:  fileName,
+		Rows:      rows,
+		TotalRows: len(rows),
+		Results:   UserImportResults{},
+	}
+}
+
+// PendingRows returns the rows that were not processed yet
+func (j UserImportJob) PendingRows() UserImportRows {
+	if j.ProcessedRows >= len(j.Rows) {
+		return UserImportRows{}
+	}
+
+	return j.Rows[j.ProcessedRows:]
+}
+
+// NewUser returns the user the row creates
+func (r UserImportRow) NewUser(username string) User {
+	gradientStart, gradientEnd := helper.GenerateRandomGradient()
+
+	user := User{
+		SoftDeleteModel:     SoftDeleteModel{BaseModel: NewBaseModel(username)},
+		EmployeeID:          nullable.NewString(r.EmployeeID),
+		Username:            nullable.NewString(r.Username),
+		FirstName:           nullable.NewString(r.FirstName),
+		LastName:            r.LastName,
+		PhoneNumber:         nullable.NewString(r.PhoneNumber),
+		PasswordHash:        nullable.NewString(r.PasswordHash),
+		OrganizationUUID:    nullable.NewString(r.OrganizationUUID),
+		AvatarGradientStart: nullable.NewString(gradientStart),
+		AvatarGradientEnd:   nullable.NewString(gradientEnd),
+	}
+
+	for _, roleUUID := range r.RoleUUIDs {
+		user.Roles = append(user.Roles, &Role{
+			SoftDeleteModel: SoftDeleteModel{BaseModel: BaseModel{UUID: roleUUID}},
+		})
+	}
+
+	return user
+}
+
+func scanJSONArray(src any, dest any) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		data = []byte("[]")
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return errors.New("unsupported type for json array")
+	}
+
+	return json.Unmarshal(data, dest)
+}
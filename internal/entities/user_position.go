@@ -0,0 +1,64 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+const PositionDateLayout = "2006-01-02"
+
+type UserPosition struct {
+	BaseModel
+	UserUUID         string              `json:"user_id" db:"user_uuid"`
+	OrganizationUUID string              `json:"organization_id" db:"organization_uuid"`
+	Title            nullable.NullString `json:"title" db:"title"`
+	IsPrimary        bool                `json:"is_primary" db:"is_primary"`
+	StartDate        time.Time           `json:"start_date" db:"start_date"`
+	EndDate          *time.Time          `json:"end_date" db:"end_date"`
+
+	Organization *Organization `json:"organization,omitempty" db:"-"`
+}
+
+type UserPositions []*UserPosition
+
+// IsActiveAt reports whether the position is held on the day of t, both dates are inclusive
+func (p *UserPosition) IsActiveAt(t time.Time) bool {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	start := time.Date(p.StartDate.Year(), p.StartDate.Month(), p.StartDate.Day(), 0, 0, 0, 0, time.UTC)
+	if day.Before(start) {
+		return false
+	}
+	if p.EndDate == nil {
+		return true
+	}
+
+	end := time.Date(p.EndDate.Year(), p.EndDate.Month(), p.EndDate.Day(), 0, 0, 0, 0, time.UTC)
+	return !day.After(end)
+}
+
+// Active returns the positions held on the day of t
+func (ps UserPositions) Active(t time.Time) UserPositions {
+	active := UserPositions{}
+	for _, p := range ps {
+		if p != nil && p.IsActiveAt(t) {
+			active = append(active, p)
+		}
+	}
+	return active
+}
+
+// CanActAs reports whether the user may use organizationUUID as the active organization,
+// either as the primary organization or through a position held on the day of t
+func (ps UserPositions) CanActAs(primaryOrganizationUUID, organizationUUID string, t time.Time) bool {
+	if organizationUUID == primaryOrganizationUUID {
+		return true
+	}
+
+	for _, p := range ps.Active(t) {
+		if p.OrganizationUUID == organizationUUID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func newPosition(organizationUUID, start string, end string) *UserPosition {
+	p := &UserPosition{OrganizationUUID: organizationUUID}
+	p.StartDate, _ = time.Parse(PositionDateLayout, start)
+	if end != "" {
+		endDate, _ := time.Parse(PositionDateLayout, end)
+		p.EndDate = &endDate
+	}
+	return p
+}
+
+func TestUserPosition_IsActiveAt(t *testing.T) {
+	position := newPosition("org", "2026-01-01", "2026-03-31")
+
+	tests := []struct {
+		at   string
+		want bool
+	}{
+		{"2025-12-31T23:59:00Z", false},
+		{"2026-01-01T00:00:00Z", true},
+		{"2026-03-31T18:00:00Z", true},
+		{"2026-04-01T00:00:00Z", false},
+	}
+
+	for _, tt := range tests {
+		at, _ := time.Parse(time.RFC3339, tt.at)
+		if got := position.IsActiveAt(at); got != tt.want {
+			t.Errorf("IsActiveAt(%s) = %v, want %v", tt.at, got, tt.want)
+		}
+	}
+
+	if !newPosition("org", "2026-01-01", "").IsActiveAt(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Error("open ended position should stay active")
+	}
+}
+
+func TestUserPositions_CanActAs(t *testing.T) {
+	now := time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC)
+	positions := UserPositions{
+		newPosition("acting", "2026-05-01", ""),
+		newPosition("ended", "2025-01-01", "2025-12-31"),
+	}
+
+	if !positions.CanActAs("primary", "primary", now) {
+		t.Error("primary organization should always be allowed")
+	}
+	if !positions.CanActAs("primary", "acting", now) {
+		t.Error("active secondary position should be allowed")
+	}
+	if positions.CanActAs("primary", "ended", now) {
+		t.Error("ended position should not be allowed")
+	}
+	if positions.CanActAs("primary", "other", now) {
+		t.Error("organization without a position should not be allowed")
+	}
+}
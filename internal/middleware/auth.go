@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -54,8 +55,11 @@ func AuthMiddleware(cfg config.Config, userRepo user.Repository) fiber.Handler {
 			})
 		}
 
+		// Tokens issued before organization switching existed carry no active organization
+		activeOrganizationID, _ := claims["active_organization_id"].(string)
+
 		// Get authenticated user data
-		authenticatedUser, err := getUserData(c.Context(), userRepo, userID)
+		authenticatedUser, err := getUserData(c.Context(), userRepo, userID, activeOrganizationID)
 		if err != nil {
 			return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
 				"error": fmt.Sprintf("Authentication failed: %v", err),
@@ -71,7 +75,7 @@ func AuthMiddleware(cfg config.Config, userRepo user.Repository) fiber.Handler {
 }
 
 // getUserData retrieves full user data from repository
-func getUserData(ctx context.Context, userRepo user.Repository, userUUID string) (*entities.AuthenticatedUser, error) {
+func getUserData(ctx context.Context, userRepo user.Repository, userUUID string, activeOrganizationID string) (*entities.AuthenticatedUser, error) {
 	// Find user by UUID
 	userEntity, err := userRepo.FindByUUID(ctx, userUUID)
 	if err != nil {
@@ -82,6 +86,14 @@ func getUserData(ctx context.Context, userRepo user.Repository, userUUID string)
 		return nil, errors.New("user not found")
 	}
 
+	// Tokens outlive an erasure or deactivation, they stop working with the account
+	if userEntity.IsErased() {
+		return nil, errors.New("user has been erased")
+	}
+	if !userEntity.IsActive {
+		return nil, errors.New("user is not active")
+	}
+
 	// Get user roles
 	roles, err := userRepo.FindRoleByUserUUID(ctx, userUUID)
 	if err != nil {
@@ -106,6 +118,24 @@ func getUserData(ctx context.Context, userRepo user.Repository, userUUID string)
 		}
 	}
 
+	// The active organization is checked on every request, it stops working once the position ends
+	activeOrgUUID := orgUUID
+	if activeOrganizationID != "" && activeOrganizationID != userEntity.OrganizationUUID.GetOrDefault() {
+		positions, err := userRepo.FindPositionsByUserUUID(ctx, userUUID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user positions: %w", err)
+		}
+
+		if !positions.CanActAs(userEntity.OrganizationUUID.GetOrDefault(), activeOrganizationID, time.Now()) {
+			return nil, errors.New("no active position in the token organization")
+		}
+
+		activeOrgUUID, err = uuid.Parse(activeOrganizationID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid active organization UUID format: %w", err)
+		}
+	}
+
 	// Convert to AuthenticatedUser entity
 	authenticatedUser := &entities.AuthenticatedUser{
 		ID:          userEntity.UUID,
@@ -120,6 +150,9 @@ func getUserData(ctx context.Context, userRepo user.Repository, userUUID string)
 			Name: "", // TODO: Join with organization table to get name
 			Type: "", // TODO: Join with organization table to get type
 		},
+		ActiveOrganization: entities.UserOrganization{
+			ID: activeOrgUUID,
+		},
 	}
 
 	return authenticatedUser, nil
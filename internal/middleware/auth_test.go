@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/user"
+	"github.com/stretchr/testify/assert"
+)
+
+// userRepoStub only finds users, the tokens it is used for are rejected before anything else is read
+type userRepoStub struct {
+	user.Repository
+	user *entities.User
+}
+
+func (r userRepoStub) FindByUUID(ctx context.Context, uuid string) (*entities.User, error) {
+	return r.user, nil
+}
+
+func TestGetUserData_RejectsErasedAndInactiveUsers(t *testing.T) {
+	erasedAt := time.Now()
+	erased := &entities.User{IsActive: false, ErasedAt: &erasedAt}
+	inactive := &entities.User{IsActive: false}
+
+	authUser, err := getUserData(context.Background(), userRepoStub{user: erased}, "user-a", "")
+	assert.Nil(t, authUser)
+	assert.EqualError(t, err, "user has been erased")
+
+	authUser, err = getUserData(context.Background(), userRepoStub{user: inactive}, "user-a", "")
+	assert.Nil(t, authUser)
+	assert.EqualError(t, err, "user is not active")
+
+	authUser, err = getUserData(context.Background(), userRepoStub{}, "user-a", "")
+	assert.Nil(t, authUser)
+	assert.EqualError(t, err, "user not found")
+}
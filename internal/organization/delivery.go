@@ -8,4 +8,37 @@ type Handlers interface {
 	Index(c *fiber.Ctx) error
 	Update(c *fiber.Ctx) error
 	Delete(c *fiber.Ctx) error
+	OrgChart(c *fiber.Ctx) error
+	Move(c *fiber.Ctx) error
+	Merge(c *fiber.Ctx) error
+	Split(c *fiber.Ctx) error
+	CheckHierarchy(c *fiber.Ctx) error
+	RepairHierarchy(c *fiber.Ctx) error
+	Import(c *fiber.Ctx) error
+	Export(c *fiber.Ctx) error
+	BulkExport(c *fiber.Ctx) error
+	Ancestors(c *fiber.Ctx) error
+	Children(c *fiber.Ctx) error
+	Descendants(c *fiber.Ctx) error
+	Nearest(c *fiber.Ctx) error
+	WithinRadius(c *fiber.Ctx) error
+	WithinBox(c *fiber.Ctx) error
+	Stats(c *fiber.Ctx) error
+
+	IndexOrganizationVersion(c *fiber.Ctx) error
+	ScheduleOrganizationVersion(c *fiber.Ctx) error
+	CancelOrganizationVersion(c *fiber.Ctx) error
+
+	IndexOrganizationSetting(c *fiber.Ctx) error
+	CreateOrganizationSetting(c *fiber.Ctx) error
+	UpdateOrganizationSetting(c *fiber.Ctx) error
+	DeleteOrganizationSetting(c *fiber.Ctx) error
+	OrganizationSettings(c *fiber.Ctx) error
+	MyOrganizationSettings(c *fiber.Ctx) error
+	UpdateOrganizationSettings(c *fiber.Ctx) error
+
+	IndexOrganizationType(c *fiber.Ctx) error
+	CreateOrganizationType(c *fiber.Ctx) error
+	UpdateOrganizationType(c *fiber.Ctx) error
+	DeleteOrganizationType(c *fiber.Ctx) error
 }
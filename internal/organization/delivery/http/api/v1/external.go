@@ -6,6 +6,7 @@ import (
 	"github.com/laksanagusta/identity/config"
 	"github.com/laksanagusta/identity/internal/entities"
 	"github.com/laksanagusta/identity/internal/organization"
+	"github.com/laksanagusta/identity/internal/organization/dtos"
 	"github.com/laksanagusta/identity/internal/organization/dtos/external"
 
 	"github.com/gofiber/fiber/v2"
@@ -36,6 +37,17 @@ func (h *ExternalOrganizationHandler) GetOrganization(c *fiber.Ctx) error {
 		})
 	}
 
+	showOrganizationReq := dtos.ShowOrganizationReq{
+		OrganizationUUID: organizationUUID,
+		AsOf:             c.Query("as_of"),
+	}
+	err := showOrganizationReq.Validate()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Validation error: " + err.Error(),
+		})
+	}
+
 	// Create a dummy authenticated user for external API
 	// External API uses API Key authentication, not JWT
 	authUser := entities.AuthenticatedUser{
@@ -44,7 +56,7 @@ func (h *ExternalOrganizationHandler) GetOrganization(c *fiber.Ctx) error {
 	}
 
 	// Get organization from use case
-	organization, err := h.organizationUc.Show(c.Context(), authUser, organizationUUID)
+	organization, err := h.organizationUc.Show(c.Context(), authUser, showOrganizationReq)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to fetch organization: " + err.Error(),
@@ -107,3 +119,176 @@ func (h *ExternalOrganizationHandler) GetOrganizations(c *fiber.Ctx) error {
 		external.NewListOrganizationResp(organizations, metadata),
 	)
 }
+
+// GetAncestors handles GET /api/v1/external/organizations/{id}/ancestors
+// Returns the organizations above an organization root first, include_self=true gives its breadcrumb
+func (h *ExternalOrganizationHandler) GetAncestors(c *fiber.Ctx) error {
+	var organizationTreeReq external.OrganizationTreeReq
+	err := c.ParamsParser(&organizationTreeReq)
+	if err != nil {
+		return err
+	}
+
+	err = c.QueryParser(&organizationTreeReq)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid query parameters",
+		})
+	}
+
+	req := organizationTreeReq.ToInternalReq()
+	err = req.Validate()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Validation error: " + err.Error(),
+		})
+	}
+
+	// External API uses API Key authentication, not JWT
+	authUser := entities.AuthenticatedUser{
+		ID:       "external-api",
+		Username: "external-api",
+	}
+
+	organizations, err := h.organizationUc.Ancestors(c.Context(), authUser, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: external.NewListOrganizationNodeRes(organizations)})
+}
+
+// GetChildren handles GET /api/v1/external/organizations/{id}/children
+// Returns the direct sub organizations of an organization
+func (h *ExternalOrganizationHandler) GetChildren(c *fiber.Ctx) error {
+	var organizationTreeReq external.OrganizationTreeReq
+	err := c.ParamsParser(&organizationTreeReq)
+	if err != nil {
+		return err
+	}
+
+	err = c.QueryParser(&organizationTreeReq)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid query parameters",
+		})
+	}
+
+	req := organizationTreeReq.ToInternalReq()
+	err = req.Validate()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Validation error: " + err.Error(),
+		})
+	}
+
+	// External API uses API Key authentication, not JWT
+	authUser := entities.AuthenticatedUser{
+		ID:       "external-api",
+		Username: "external-api",
+	}
+
+	organizations, err := h.organizationUc.Children(c.Context(), authUser, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: external.NewListOrganizationNodeRes(organizations)})
+}
+
+// GetDescendants handles GET /api/v1/external/organizations/{id}/descendants
+// Returns an organization with its sub organizations nested up to max_depth levels
+func (h *ExternalOrganizationHandler) GetDescendants(c *fiber.Ctx) error {
+	var organizationTreeReq external.OrganizationTreeReq
+	err := c.ParamsParser(&organizationTreeReq)
+	if err != nil {
+		return err
+	}
+
+	err = c.QueryParser(&organizationTreeReq)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid query parameters",
+		})
+	}
+
+	req := organizationTreeReq.ToInternalReq()
+	err = req.Validate()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Validation error: " + err.Error(),
+		})
+	}
+
+	// External API uses API Key authentication, not JWT
+	authUser := entities.AuthenticatedUser{
+		ID:       "external-api",
+		Username: "external-api",
+	}
+
+	organization, err := h.organizationUc.Descendants(c.Context(), authUser, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: external.NewOrganizationTreeRes(organization)})
+}
+
+// GetStats handles GET /api/v1/external/organizations/{id}/stats
+// Returns the direct and rolled up headcounts of an organization and its sub organizations
+func (h *ExternalOrganizationHandler) GetStats(c *fiber.Ctx) error {
+	var organizationStatsReq external.OrganizationStatsReq
+	err := c.ParamsParser(&organizationStatsReq)
+	if err != nil {
+		return err
+	}
+
+	err = c.QueryParser(&organizationStatsReq)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid query parameters",
+		})
+	}
+
+	req := organizationStatsReq.ToInternalReq()
+	err = req.Validate()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Validation error: " + err.Error(),
+		})
+	}
+
+	// External API uses API Key authentication, not JWT
+	authUser := entities.AuthenticatedUser{
+		ID:       "external-api",
+		Username: "external-api",
+	}
+
+	stats, err := h.organizationUc.Stats(c.Context(), authUser, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: external.NewOrganizationStatsRes(stats)})
+}
+
+// GetSettings handles GET /api/v1/external/organizations/{id}/settings
+// Returns the effective value of every setting, inherited from ancestors where the organization sets none
+func (h *ExternalOrganizationHandler) GetSettings(c *fiber.Ctx) error {
+	organizationSettingsReq := dtos.OrganizationSettingsReq{OrganizationUUID: c.Params("id")}
+	err := organizationSettingsReq.Validate()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Validation error: " + err.Error(),
+		})
+	}
+
+	settings, err := h.organizationUc.EffectiveOrganizationSettings(c.Context(), organizationSettingsReq.OrganizationUUID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{
+		Data: external.NewOrganizationSettingsRes(organizationSettingsReq.OrganizationUUID, settings),
+	})
+}
@@ -55,6 +55,41 @@ func (h *organizationHandler) Organization(c *fiber.Ctx) error {
 }
 
 func (h *organizationHandler) Show(c *fiber.Ctx) error {
+	var showOrganizationReq dtos.ShowOrganizationReq
+	err := c.ParamsParser(&showOrganizationReq)
+	if err != nil {
+		return err
+	}
+
+	err = c.QueryParser(&showOrganizationReq)
+	if err != nil {
+		return err
+	}
+
+	err = showOrganizationReq.Validate()
+	if err != nil {
+		return err
+	}
+
+	// Safely get authenticated user
+	authUser, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	organization, err := h.organizationUc.Show(
+		c.Context(),
+		*authUser,
+		showOrganizationReq,
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: dtos.NewShowOrganizationRes(organization)})
+}
+
+func (h *organizationHandler) OrgChart(c *fiber.Ctx) error {
 	var params struct {
 		OrganizationUUID string `params:"organizationUUID"`
 	}
@@ -70,7 +105,7 @@ func (h *organizationHandler) Show(c *fiber.Ctx) error {
 		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
 	}
 
-	organization, err := h.organizationUc.Show(
+	organization, err := h.organizationUc.OrgChart(
 		c.Context(),
 		*authUser,
 		params.OrganizationUUID,
@@ -79,7 +114,7 @@ func (h *organizationHandler) Show(c *fiber.Ctx) error {
 		return err
 	}
 
-	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: dtos.NewShowOrganizationRes(organization)})
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: dtos.NewOrgChartRes(organization)})
 }
 
 func (h *organizationHandler) Index(c *fiber.Ctx) error {
@@ -176,3 +211,338 @@ func (h *organizationHandler) Delete(c *fiber.Ctx) error {
 
 	return c.SendStatus(http.StatusOK)
 }
+
+// Move reparents an organization, {"dry_run": true} only reports the affected organizations
+func (h *organizationHandler) Move(c *fiber.Ctx) error {
+	var moveOrganizationReq dtos.MoveOrganizationReq
+	err := c.ParamsParser(&moveOrganizationReq)
+	if err != nil {
+		return err
+	}
+
+	err = c.BodyParser(&moveOrganizationReq)
+	if err != nil {
+		return err
+	}
+
+	err = moveOrganizationReq.Validate()
+	if err != nil {
+		return err
+	}
+
+	authUser, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	changes, err := h.organizationUc.Move(c.Context(), *authUser, moveOrganizationReq)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{
+		Data: dtos.NewMoveOrganizationRes(moveOrganizationReq.DryRun, changes),
+	})
+}
+
+// Merge moves everything of an organization to target_id and archives it, {"dry_run": true} only
+// reports what would move
+func (h *organizationHandler) Merge(c *fiber.Ctx) error {
+	var mergeOrganizationReq dtos.MergeOrganizationReq
+	err := c.ParamsParser(&mergeOrganizationReq)
+	if err != nil {
+		return err
+	}
+
+	err = c.BodyParser(&mergeOrganizationReq)
+	if err != nil {
+		return err
+	}
+
+	err = mergeOrganizationReq.Validate()
+	if err != nil {
+		return err
+	}
+
+	authUser, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	restructure, err := h.organizationUc.Merge(c.Context(), *authUser, mergeOrganizationReq)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{
+		Data: dtos.NewOrganizationRestructureRes(mergeOrganizationReq.DryRun, restructure),
+	})
+}
+
+// Split moves selected users and sub organizations into a new sibling, {"dry_run": true} only
+// reports what would move
+func (h *organizationHandler) Split(c *fiber.Ctx) error {
+	var splitOrganizationReq dtos.SplitOrganizationReq
+	err := c.ParamsParser(&splitOrganizationReq)
+	if err != nil {
+		return err
+	}
+
+	err = c.BodyParser(&splitOrganizationReq)
+	if err != nil {
+		return err
+	}
+
+	err = splitOrganizationReq.Validate()
+	if err != nil {
+		return err
+	}
+
+	authUser, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	restructure, err := h.organizationUc.Split(c.Context(), *authUser, splitOrganizationReq)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{
+		Data: dtos.NewOrganizationRestructureRes(splitOrganizationReq.DryRun, restructure),
+	})
+}
+
+// CheckHierarchy handles GET /api/v1/organizations/hierarchy/check and only reports inconsistencies
+func (h *organizationHandler) CheckHierarchy(c *fiber.Ctx) error {
+	return h.checkHierarchy(c, dtos.CheckOrganizationHierarchyReq{})
+}
+
+// RepairHierarchy handles POST /api/v1/organizations/hierarchy/repair, recomputing path, level and
+// closure rows from parent_uuid where the parent chain reaches a root
+func (h *organizationHandler) RepairHierarchy(c *fiber.Ctx) error {
+	return h.checkHierarchy(c, dtos.CheckOrganizationHierarchyReq{Repair: true})
+}
+
+func (h *organizationHandler) checkHierarchy(c *fiber.Ctx, req dtos.CheckOrganizationHierarchyReq) error {
+	authUser, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	report, err := h.organizationUc.CheckHierarchy(c.Context(), *authUser, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{
+		Data: dtos.NewOrganizationHierarchyReportRes(report),
+	})
+}
+
+// Ancestors handles GET /api/v1/organizations/{organizationUUID}/ancestors
+func (h *organizationHandler) Ancestors(c *fiber.Ctx) error {
+	var organizationTreeReq dtos.OrganizationTreeReq
+	err := c.ParamsParser(&organizationTreeReq)
+	if err != nil {
+		return err
+	}
+
+	err = c.QueryParser(&organizationTreeReq)
+	if err != nil {
+		return err
+	}
+
+	err = organizationTreeReq.Validate()
+	if err != nil {
+		return err
+	}
+
+	authUser, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	organizations, err := h.organizationUc.Ancestors(c.Context(), *authUser, organizationTreeReq)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: dtos.NewListOrganizationNodeRes(organizations)})
+}
+
+// Children handles GET /api/v1/organizations/{organizationUUID}/children
+func (h *organizationHandler) Children(c *fiber.Ctx) error {
+	var organizationTreeReq dtos.OrganizationTreeReq
+	err := c.ParamsParser(&organizationTreeReq)
+	if err != nil {
+		return err
+	}
+
+	err = c.QueryParser(&organizationTreeReq)
+	if err != nil {
+		return err
+	}
+
+	err = organizationTreeReq.Validate()
+	if err != nil {
+		return err
+	}
+
+	authUser, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	organizations, err := h.organizationUc.Children(c.Context(), *authUser, organizationTreeReq)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: dtos.NewListOrganizationNodeRes(organizations)})
+}
+
+// Descendants handles GET /api/v1/organizations/{organizationUUID}/descendants
+func (h *organizationHandler) Descendants(c *fiber.Ctx) error {
+	var organizationTreeReq dtos.OrganizationTreeReq
+	err := c.ParamsParser(&organizationTreeReq)
+	if err != nil {
+		return err
+	}
+
+	err = c.QueryParser(&organizationTreeReq)
+	if err != nil {
+		return err
+	}
+
+	err = organizationTreeReq.Validate()
+	if err != nil {
+		return err
+	}
+
+	authUser, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	organization, err := h.organizationUc.Descendants(c.Context(), *authUser, organizationTreeReq)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: dtos.NewOrganizationTreeRes(organization)})
+}
+
+// Stats handles GET /api/v1/organizations/{organizationUUID}/stats
+// Returns direct and rolled up headcounts with breakdowns by status, role and month joined
+func (h *organizationHandler) Stats(c *fiber.Ctx) error {
+	var organizationStatsReq dtos.OrganizationStatsReq
+	err := c.ParamsParser(&organizationStatsReq)
+	if err != nil {
+		return err
+	}
+
+	err = c.QueryParser(&organizationStatsReq)
+	if err != nil {
+		return err
+	}
+
+	err = organizationStatsReq.Validate()
+	if err != nil {
+		return err
+	}
+
+	authUser, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	organizationStatsReq.Breakdowns = true
+	stats, err := h.organizationUc.Stats(c.Context(), *authUser, organizationStatsReq)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: dtos.NewOrganizationStatsRes(stats)})
+}
+
+// Nearest handles GET /api/v1/organizations/geo/nearest
+// Returns the limit organizations closest to latitude and longitude, nearest first
+func (h *organizationHandler) Nearest(c *fiber.Ctx) error {
+	var nearestOrganizationReq dtos.NearestOrganizationReq
+	err := c.QueryParser(&nearestOrganizationReq)
+	if err != nil {
+		return err
+	}
+
+	err = nearestOrganizationReq.Validate()
+	if err != nil {
+		return err
+	}
+
+	authUser, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	organizations, err := h.organizationUc.SearchNearby(c.Context(), *authUser, nearestOrganizationReq.GeoSearchParams())
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: dtos.NewListNearbyOrganizationRes(organizations)})
+}
+
+// WithinRadius handles GET /api/v1/organizations/geo/radius
+// Returns the organizations within radius meters of latitude and longitude, nearest first
+func (h *organizationHandler) WithinRadius(c *fiber.Ctx) error {
+	var radiusOrganizationReq dtos.RadiusOrganizationReq
+	err := c.QueryParser(&radiusOrganizationReq)
+	if err != nil {
+		return err
+	}
+
+	err = radiusOrganizationReq.Validate()
+	if err != nil {
+		return err
+	}
+
+	authUser, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	organizations, err := h.organizationUc.SearchNearby(c.Context(), *authUser, radiusOrganizationReq.GeoSearchParams())
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: dtos.NewListNearbyOrganizationRes(organizations)})
+}
+
+// WithinBox handles GET /api/v1/organizations/geo/bbox
+// Returns the organizations inside the box, nearest to latitude and longitude or the box center first
+func (h *organizationHandler) WithinBox(c *fiber.Ctx) error {
+	var boundingBoxOrganizationReq dtos.BoundingBoxOrganizationReq
+	err := c.QueryParser(&boundingBoxOrganizationReq)
+	if err != nil {
+		return err
+	}
+
+	err = boundingBoxOrganizationReq.Validate()
+	if err != nil {
+		return err
+	}
+
+	authUser, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	organizations, err := h.organizationUc.SearchNearby(c.Context(), *authUser, boundingBoxOrganizationReq.GeoSearchParams())
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: dtos.NewListNearbyOrganizationRes(organizations)})
+}
@@ -0,0 +1,49 @@
+package v1
+
+import (
+	"bufio"
+	"log"
+	"net/http"
+
+	"github.com/laksanagusta/identity/internal/middleware"
+	"github.com/laksanagusta/identity/internal/organization/dtos"
+	"github.com/laksanagusta/identity/pkg/bulkexport"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// BulkExport streams every organization matching the filters of Index as ?format=csv, xlsx or ndjson,
+// written while they are read from the database
+func (h *organizationHandler) BulkExport(c *fiber.Ctx) error {
+	var bulkExportReq dtos.BulkExportOrganizationReq
+	err := c.QueryParser(&bulkExportReq)
+	if err != nil {
+		return err
+	}
+
+	err = bulkExportReq.Validate()
+	if err != nil {
+		return err
+	}
+
+	authUser, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	organizations, err := h.organizationUc.BulkExport(c.Context(), *authUser, bulkExportReq)
+	if err != nil {
+		return err
+	}
+
+	c.Attachment("organizations." + bulkExportReq.Format)
+	c.Set(fiber.HeaderContentType, bulkexport.ContentType(bulkExportReq.Format))
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		// The status is already sent, a failure can only cut the file short
+		if err := bulkexport.Write(w, bulkExportReq.Format, dtos.BulkExportOrganizationTable, organizations); err != nil {
+			log.Printf("bulk export of organizations failed: %v", err)
+		}
+	})
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package v1
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/middleware"
+	"github.com/laksanagusta/identity/internal/organization/dtos"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Import handles POST /api/v1/organizations/import, the body is a nested JSON file, the data of a
+// JSON export, or a CSV file with code and parent_code columns. ?dry_run=true only reports the diff.
+func (h *organizationHandler) Import(c *fiber.Ctx) error {
+	var importOrganizationReq dtos.ImportOrganizationReq
+	err := c.QueryParser(&importOrganizationReq)
+	if err != nil {
+		return err
+	}
+
+	if importOrganizationReq.Format == "" {
+		importOrganizationReq.Format = dtos.OrganizationFileJSON
+		if c.Is(dtos.OrganizationFileCSV) {
+			importOrganizationReq.Format = dtos.OrganizationFileCSV
+		}
+	}
+
+	err = importOrganizationReq.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = importOrganizationReq.ParseRows(c.Body())
+	if err != nil {
+		return err
+	}
+
+	authUser, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	changes, err := h.organizationUc.Import(c.Context(), *authUser, importOrganizationReq)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{
+		Data: dtos.NewOrganizationImportRes(importOrganizationReq.DryRun, changes),
+	})
+}
+
+// Export handles GET /api/v1/organizations/export, ?root_id limits it to a subtree and ?as_of shows
+// the hierarchy of another day. ?format=csv downloads a CSV file.
+func (h *organizationHandler) Export(c *fiber.Ctx) error {
+	var exportOrganizationReq dtos.ExportOrganizationReq
+	err := c.QueryParser(&exportOrganizationReq)
+	if err != nil {
+		return err
+	}
+
+	err = exportOrganizationReq.Validate()
+	if err != nil {
+		return err
+	}
+
+	authUser, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	organizations, err := h.organizationUc.Export(c.Context(), *authUser, exportOrganizationReq)
+	if err != nil {
+		return err
+	}
+
+	if exportOrganizationReq.Format == dtos.OrganizationFileCSV {
+		var file bytes.Buffer
+		err = dtos.WriteOrganizationCSV(&file, organizations)
+		if err != nil {
+			return err
+		}
+
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		c.Attachment("organizations.csv")
+
+		return c.Status(http.StatusOK).Send(file.Bytes())
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{
+		Data: dtos.NewOrganizationFile(organizations),
+	})
+}
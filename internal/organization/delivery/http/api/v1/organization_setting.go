@@ -0,0 +1,180 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/middleware"
+	"github.com/laksanagusta/identity/internal/organization/dtos"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func (h *organizationHandler) IndexOrganizationSetting(c *fiber.Ctx) error {
+	definitions, err := h.organizationUc.IndexOrganizationSetting(c.Context())
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: dtos.NewListOrganizationSettingDefinitionRes(definitions)})
+}
+
+func (h *organizationHandler) CreateOrganizationSetting(c *fiber.Ctx) error {
+	var createOrganizationSettingReq dtos.CreateOrganizationSettingReq
+	err := c.BodyParser(&createOrganizationSettingReq)
+	if err != nil {
+		return err
+	}
+
+	err = createOrganizationSettingReq.Validate()
+	if err != nil {
+		return err
+	}
+
+	// Safely get authenticated user
+	cred, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	uuid, err := h.organizationUc.CreateOrganizationSetting(
+		c.Context(),
+		createOrganizationSettingReq.NewOrganizationSettingDefinition(*cred),
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: map[string]string{"id": uuid}})
+}
+
+func (h *organizationHandler) UpdateOrganizationSetting(c *fiber.Ctx) error {
+	var updateOrganizationSettingReq dtos.UpdateOrganizationSettingReq
+	err := c.ParamsParser(&updateOrganizationSettingReq)
+	if err != nil {
+		return err
+	}
+
+	err = c.BodyParser(&updateOrganizationSettingReq)
+	if err != nil {
+		return err
+	}
+
+	err = updateOrganizationSettingReq.Validate()
+	if err != nil {
+		return err
+	}
+
+	// Safely get authenticated user
+	cred, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	err = h.organizationUc.UpdateOrganizationSetting(
+		c.Context(),
+		*cred,
+		updateOrganizationSettingReq,
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(http.StatusOK)
+}
+
+func (h *organizationHandler) DeleteOrganizationSetting(c *fiber.Ctx) error {
+	var params struct {
+		SettingUUID string `params:"settingUUID"`
+	}
+
+	err := c.ParamsParser(&params)
+	if err != nil {
+		return err
+	}
+
+	err = h.organizationUc.DeleteOrganizationSetting(
+		c.Context(),
+		params.SettingUUID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(http.StatusOK)
+}
+
+// OrganizationSettings handles GET /api/v1/organizations/{organizationUUID}/settings and returns the
+// effective value of every setting with the organization it is inherited from
+func (h *organizationHandler) OrganizationSettings(c *fiber.Ctx) error {
+	var organizationSettingsReq dtos.OrganizationSettingsReq
+	err := c.ParamsParser(&organizationSettingsReq)
+	if err != nil {
+		return err
+	}
+
+	err = organizationSettingsReq.Validate()
+	if err != nil {
+		return err
+	}
+
+	settings, err := h.organizationUc.EffectiveOrganizationSettings(c.Context(), organizationSettingsReq.OrganizationUUID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{
+		Data: dtos.NewListEffectiveOrganizationSettingRes(organizationSettingsReq.OrganizationUUID, settings),
+	})
+}
+
+// MyOrganizationSettings handles GET /api/v1/organizations/me/settings, the settings of the
+// organization the caller is acting in
+func (h *organizationHandler) MyOrganizationSettings(c *fiber.Ctx) error {
+	cred, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	organizationUUID := cred.ActiveOrganization.ID.String()
+	settings, err := h.organizationUc.EffectiveOrganizationSettings(c.Context(), organizationUUID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{
+		Data: dtos.NewListEffectiveOrganizationSettingRes(organizationUUID, settings),
+	})
+}
+
+// UpdateOrganizationSettings handles PATCH /api/v1/organizations/{organizationUUID}/settings,
+// {"settings": {"key": null}} removes the value so the organization inherits it again
+func (h *organizationHandler) UpdateOrganizationSettings(c *fiber.Ctx) error {
+	var updateOrganizationSettingsReq dtos.UpdateOrganizationSettingsReq
+	err := c.ParamsParser(&updateOrganizationSettingsReq)
+	if err != nil {
+		return err
+	}
+
+	err = c.BodyParser(&updateOrganizationSettingsReq)
+	if err != nil {
+		return err
+	}
+
+	err = updateOrganizationSettingsReq.Validate()
+	if err != nil {
+		return err
+	}
+
+	cred, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	err = h.organizationUc.UpdateOrganizationSettings(c.Context(), *cred, updateOrganizationSettingsReq)
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(http.StatusOK)
+}
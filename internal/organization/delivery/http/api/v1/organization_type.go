@@ -0,0 +1,118 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/middleware"
+	"github.com/laksanagusta/identity/internal/organization/dtos"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// IndexOrganizationType lists organization types, ?parent_id= narrows them to the types that can be
+// created below that organization
+func (h *organizationHandler) IndexOrganizationType(c *fiber.Ctx) error {
+	var listOrganizationTypeReq dtos.ListOrganizationTypeReq
+	err := c.QueryParser(&listOrganizationTypeReq)
+	if err != nil {
+		return err
+	}
+
+	err = listOrganizationTypeReq.Validate()
+	if err != nil {
+		return err
+	}
+
+	organizationTypes, err := h.organizationUc.IndexOrganizationType(c.Context(), listOrganizationTypeReq)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: dtos.NewListOrganizationTypeRes(organizationTypes)})
+}
+
+func (h *organizationHandler) CreateOrganizationType(c *fiber.Ctx) error {
+	var createOrganizationTypeReq dtos.CreateOrganizationTypeReq
+	err := c.BodyParser(&createOrganizationTypeReq)
+	if err != nil {
+		return err
+	}
+
+	err = createOrganizationTypeReq.Validate()
+	if err != nil {
+		return err
+	}
+
+	// Safely get authenticated user
+	cred, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	uuid, err := h.organizationUc.CreateOrganizationType(
+		c.Context(),
+		createOrganizationTypeReq.NewOrganizationType(*cred),
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: map[string]string{"id": uuid}})
+}
+
+func (h *organizationHandler) UpdateOrganizationType(c *fiber.Ctx) error {
+	var updateOrganizationTypeReq dtos.UpdateOrganizationTypeReq
+	err := c.ParamsParser(&updateOrganizationTypeReq)
+	if err != nil {
+		return err
+	}
+
+	err = c.BodyParser(&updateOrganizationTypeReq)
+	if err != nil {
+		return err
+	}
+
+	err = updateOrganizationTypeReq.Validate()
+	if err != nil {
+		return err
+	}
+
+	// Safely get authenticated user
+	cred, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	err = h.organizationUc.UpdateOrganizationType(
+		c.Context(),
+		*cred,
+		updateOrganizationTypeReq,
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(http.StatusOK)
+}
+
+func (h *organizationHandler) DeleteOrganizationType(c *fiber.Ctx) error {
+	var params struct {
+		OrganizationTypeUUID string `params:"organizationTypeUUID"`
+	}
+
+	err := c.ParamsParser(&params)
+	if err != nil {
+		return err
+	}
+
+	err = h.organizationUc.DeleteOrganizationType(
+		c.Context(),
+		params.OrganizationTypeUUID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(http.StatusOK)
+}
@@ -0,0 +1,92 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/middleware"
+	"github.com/laksanagusta/identity/internal/organization/dtos"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// IndexOrganizationVersion handles GET /api/v1/organizations/{organizationUUID}/versions
+func (h *organizationHandler) IndexOrganizationVersion(c *fiber.Ctx) error {
+	var params struct {
+		OrganizationUUID string `params:"organizationUUID"`
+	}
+
+	err := c.ParamsParser(&params)
+	if err != nil {
+		return err
+	}
+
+	authUser, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	versions, err := h.organizationUc.IndexOrganizationVersion(c.Context(), *authUser, params.OrganizationUUID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: dtos.NewListOrganizationVersionRes(versions)})
+}
+
+// ScheduleOrganizationVersion handles POST /api/v1/organizations/{organizationUUID}/versions
+func (h *organizationHandler) ScheduleOrganizationVersion(c *fiber.Ctx) error {
+	var scheduleOrganizationVersionReq dtos.ScheduleOrganizationVersionReq
+	err := c.ParamsParser(&scheduleOrganizationVersionReq)
+	if err != nil {
+		return err
+	}
+
+	err = c.BodyParser(&scheduleOrganizationVersionReq)
+	if err != nil {
+		return err
+	}
+
+	err = scheduleOrganizationVersionReq.Validate()
+	if err != nil {
+		return err
+	}
+
+	authUser, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	versions, err := h.organizationUc.ScheduleOrganizationVersion(c.Context(), *authUser, scheduleOrganizationVersionReq)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: dtos.NewListOrganizationVersionRes(versions)})
+}
+
+// CancelOrganizationVersion handles DELETE /api/v1/organizations/{organizationUUID}/versions/{versionUUID}
+func (h *organizationHandler) CancelOrganizationVersion(c *fiber.Ctx) error {
+	var organizationVersionReq dtos.OrganizationVersionReq
+	err := c.ParamsParser(&organizationVersionReq)
+	if err != nil {
+		return err
+	}
+
+	err = organizationVersionReq.Validate()
+	if err != nil {
+		return err
+	}
+
+	authUser, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	err = h.organizationUc.CancelOrganizationVersion(c.Context(), *authUser, organizationVersionReq)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: map[string]string{"id": organizationVersionReq.VersionUUID}})
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/laksanagusta/identity/config"
 	"github.com/laksanagusta/identity/internal/entities"
 	"github.com/laksanagusta/identity/internal/organization"
+	"github.com/laksanagusta/identity/internal/organization/dtos"
 	"github.com/laksanagusta/identity/internal/organization/dtos/public"
 )
 
@@ -80,6 +81,17 @@ func (h *PublicOrganizationHandler) GetOrganization(c *fiber.Ctx) error {
 		})
 	}
 
+	showOrganizationReq := dtos.ShowOrganizationReq{
+		OrganizationUUID: organizationUUID,
+		AsOf:             c.Query("as_of"),
+	}
+	err := showOrganizationReq.Validate()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Validation error: " + err.Error(),
+		})
+	}
+
 	// Create a dummy authenticated user for public API
 	// Public API doesn't require authentication
 	authUser := entities.AuthenticatedUser{
@@ -88,7 +100,7 @@ func (h *PublicOrganizationHandler) GetOrganization(c *fiber.Ctx) error {
 	}
 
 	// Get organization from use case
-	organization, err := h.organizationUc.Show(c.Context(), authUser, organizationUUID)
+	organization, err := h.organizationUc.Show(c.Context(), authUser, showOrganizationReq)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to fetch organization: " + err.Error(),
@@ -106,3 +118,213 @@ func (h *PublicOrganizationHandler) GetOrganization(c *fiber.Ctx) error {
 
 	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: organizationData})
 }
+
+// GetAncestors handles GET /api/public/v1/organizations/{id}/ancestors
+// Returns the organizations above an organization root first, include_self=true gives its breadcrumb
+func (h *PublicOrganizationHandler) GetAncestors(c *fiber.Ctx) error {
+	var organizationTreeReq public.OrganizationTreeReq
+	err := c.ParamsParser(&organizationTreeReq)
+	if err != nil {
+		return err
+	}
+
+	err = c.QueryParser(&organizationTreeReq)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid query parameters",
+		})
+	}
+
+	req := organizationTreeReq.ToInternalReq()
+	err = req.Validate()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Validation error: " + err.Error(),
+		})
+	}
+
+	// Public API doesn't require authentication
+	authUser := entities.AuthenticatedUser{
+		ID:       "public-api",
+		Username: "public-api",
+	}
+
+	organizations, err := h.organizationUc.Ancestors(c.Context(), authUser, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: public.NewListPublicOrganizationNodeRes(organizations)})
+}
+
+// GetChildren handles GET /api/public/v1/organizations/{id}/children
+// Returns the direct sub organizations of an organization
+func (h *PublicOrganizationHandler) GetChildren(c *fiber.Ctx) error {
+	var organizationTreeReq public.OrganizationTreeReq
+	err := c.ParamsParser(&organizationTreeReq)
+	if err != nil {
+		return err
+	}
+
+	err = c.QueryParser(&organizationTreeReq)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid query parameters",
+		})
+	}
+
+	req := organizationTreeReq.ToInternalReq()
+	err = req.Validate()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Validation error: " + err.Error(),
+		})
+	}
+
+	// Public API doesn't require authentication
+	authUser := entities.AuthenticatedUser{
+		ID:       "public-api",
+		Username: "public-api",
+	}
+
+	organizations, err := h.organizationUc.Children(c.Context(), authUser, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: public.NewListPublicOrganizationNodeRes(organizations)})
+}
+
+// GetDescendants handles GET /api/public/v1/organizations/{id}/descendants
+// Returns an organization with its sub organizations nested up to max_depth levels
+func (h *PublicOrganizationHandler) GetDescendants(c *fiber.Ctx) error {
+	var organizationTreeReq public.OrganizationTreeReq
+	err := c.ParamsParser(&organizationTreeReq)
+	if err != nil {
+		return err
+	}
+
+	err = c.QueryParser(&organizationTreeReq)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid query parameters",
+		})
+	}
+
+	req := organizationTreeReq.ToInternalReq()
+	err = req.Validate()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Validation error: " + err.Error(),
+		})
+	}
+
+	// Public API doesn't require authentication
+	authUser := entities.AuthenticatedUser{
+		ID:       "public-api",
+		Username: "public-api",
+	}
+
+	organization, err := h.organizationUc.Descendants(c.Context(), authUser, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: public.NewPublicOrganizationTreeRes(organization)})
+}
+
+// GetNearest handles GET /api/public/v1/organizations/geo/nearest
+// Returns the limit organizations closest to latitude and longitude, nearest first
+func (h *PublicOrganizationHandler) GetNearest(c *fiber.Ctx) error {
+	var nearestOrganizationReq dtos.NearestOrganizationReq
+	err := c.QueryParser(&nearestOrganizationReq)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid query parameters",
+		})
+	}
+
+	err = nearestOrganizationReq.Validate()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Validation error: " + err.Error(),
+		})
+	}
+
+	// Public API doesn't require authentication
+	authUser := entities.AuthenticatedUser{
+		ID:       "public-api",
+		Username: "public-api",
+	}
+
+	organizations, err := h.organizationUc.SearchNearby(c.Context(), authUser, nearestOrganizationReq.GeoSearchParams())
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: public.NewListPublicNearbyOrganizationRes(organizations)})
+}
+
+// GetWithinRadius handles GET /api/public/v1/organizations/geo/radius
+// Returns the organizations within radius meters of latitude and longitude, nearest first
+func (h *PublicOrganizationHandler) GetWithinRadius(c *fiber.Ctx) error {
+	var radiusOrganizationReq dtos.RadiusOrganizationReq
+	err := c.QueryParser(&radiusOrganizationReq)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid query parameters",
+		})
+	}
+
+	err = radiusOrganizationReq.Validate()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Validation error: " + err.Error(),
+		})
+	}
+
+	// Public API doesn't require authentication
+	authUser := entities.AuthenticatedUser{
+		ID:       "public-api",
+		Username: "public-api",
+	}
+
+	organizations, err := h.organizationUc.SearchNearby(c.Context(), authUser, radiusOrganizationReq.GeoSearchParams())
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: public.NewListPublicNearbyOrganizationRes(organizations)})
+}
+
+// GetWithinBox handles GET /api/public/v1/organizations/geo/bbox
+// Returns the organizations inside the box, nearest to latitude and longitude or the box center first
+func (h *PublicOrganizationHandler) GetWithinBox(c *fiber.Ctx) error {
+	var boundingBoxOrganizationReq dtos.BoundingBoxOrganizationReq
+	err := c.QueryParser(&boundingBoxOrganizationReq)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid query parameters",
+		})
+	}
+
+	err = boundingBoxOrganizationReq.Validate()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Validation error: " + err.Error(),
+		})
+	}
+
+	// Public API doesn't require authentication
+	authUser := entities.AuthenticatedUser{
+		ID:       "public-api",
+		Username: "public-api",
+	}
+
+	organizations, err := h.organizationUc.SearchNearby(c.Context(), authUser, boundingBoxOrganizationReq.GeoSearchParams())
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: public.NewListPublicNearbyOrganizationRes(organizations)})
+}
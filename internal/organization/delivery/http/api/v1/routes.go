@@ -10,10 +10,44 @@ import (
 func MapOrganization(routes fiber.Router, h organization.Handlers) {
 	organizationGroup := routes.Group("/organizations")
 	organizationGroup.Post("/", h.Organization)
+	organizationGroup.Get("/geo/nearest", h.Nearest)
+	organizationGroup.Get("/geo/radius", h.WithinRadius)
+	organizationGroup.Get("/geo/bbox", h.WithinBox)
+	organizationGroup.Get("/me/settings", h.MyOrganizationSettings)
+	organizationGroup.Get("/hierarchy/check", h.CheckHierarchy)
+	organizationGroup.Post("/hierarchy/repair", h.RepairHierarchy)
+	organizationGroup.Post("/import", h.Import)
+	organizationGroup.Get("/export", h.Export)
+	organizationGroup.Get("/bulk-export", h.BulkExport)
 	organizationGroup.Get("/:organizationUUID", h.Show)
+	organizationGroup.Get("/:organizationUUID/org-chart", h.OrgChart)
+	organizationGroup.Post("/:organizationUUID/move", h.Move)
+	organizationGroup.Post("/:organizationUUID/merge", h.Merge)
+	organizationGroup.Post("/:organizationUUID/split", h.Split)
+	organizationGroup.Get("/:organizationUUID/ancestors", h.Ancestors)
+	organizationGroup.Get("/:organizationUUID/children", h.Children)
+	organizationGroup.Get("/:organizationUUID/descendants", h.Descendants)
+	organizationGroup.Get("/:organizationUUID/stats", h.Stats)
+	organizationGroup.Get("/:organizationUUID/versions", h.IndexOrganizationVersion)
+	organizationGroup.Post("/:organizationUUID/versions", h.ScheduleOrganizationVersion)
+	organizationGroup.Delete("/:organizationUUID/versions/:versionUUID", h.CancelOrganizationVersion)
+	organizationGroup.Get("/:organizationUUID/settings", h.OrganizationSettings)
+	organizationGroup.Patch("/:organizationUUID/settings", h.UpdateOrganizationSettings)
 	organizationGroup.Get("/", h.Index)
 	organizationGroup.Patch("/:organizationUUID", h.Update)
 	organizationGroup.Delete("/:organizationUUID", h.Delete)
+
+	organizationSettingGroup := routes.Group("/organization-settings")
+	organizationSettingGroup.Get("/", h.IndexOrganizationSetting)
+	organizationSettingGroup.Post("/", h.CreateOrganizationSetting)
+	organizationSettingGroup.Patch("/:settingUUID", h.UpdateOrganizationSetting)
+	organizationSettingGroup.Delete("/:settingUUID", h.DeleteOrganizationSetting)
+
+	organizationTypeGroup := routes.Group("/organization-types")
+	organizationTypeGroup.Get("/", h.IndexOrganizationType)
+	organizationTypeGroup.Post("/", h.CreateOrganizationType)
+	organizationTypeGroup.Patch("/:organizationTypeUUID", h.UpdateOrganizationType)
+	organizationTypeGroup.Delete("/:organizationTypeUUID", h.DeleteOrganizationType)
 }
 
 // MapExternalOrganization maps external API routes with API Key authentication
@@ -22,6 +56,11 @@ func MapExternalOrganization(routes fiber.Router, h *ExternalOrganizationHandler
 	organizationsGroup := routes.Group("/organizations")
 	organizationsGroup.Get("/", h.GetOrganizations)
 	organizationsGroup.Get("/:id", h.GetOrganization)
+	organizationsGroup.Get("/:id/ancestors", h.GetAncestors)
+	organizationsGroup.Get("/:id/children", h.GetChildren)
+	organizationsGroup.Get("/:id/descendants", h.GetDescendants)
+	organizationsGroup.Get("/:id/settings", h.GetSettings)
+	organizationsGroup.Get("/:id/stats", h.GetStats)
 }
 
 // MapPublicOrganization maps public API routes without authentication
@@ -29,5 +68,11 @@ func MapPublicOrganization(routes fiber.Router, h *PublicOrganizationHandler, cf
 	// Public organization endpoints (routes parameter already includes /api/public/v1 prefix)
 	organizationsGroup := routes.Group("/organizations")
 	organizationsGroup.Get("/", h.GetOrganizations)
+	organizationsGroup.Get("/geo/nearest", h.GetNearest)
+	organizationsGroup.Get("/geo/radius", h.GetWithinRadius)
+	organizationsGroup.Get("/geo/bbox", h.GetWithinBox)
 	organizationsGroup.Get("/:id", h.GetOrganization)
+	organizationsGroup.Get("/:id/ancestors", h.GetAncestors)
+	organizationsGroup.Get("/:id/children", h.GetChildren)
+	organizationsGroup.Get("/:id/descendants", h.GetDescendants)
 }
@@ -0,0 +1,107 @@
+package dtos
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/pkg/bulkexport"
+	"github.com/laksanagusta/identity/pkg/nullable"
+
+	"github.com/invopop/validation"
+)
+
+// BulkExportOrganizationReq exports every organization matching the filters of ListOrganizationReq
+type BulkExportOrganizationReq struct {
+	Format    string     `query:"format"`
+	Search    *string    `query:"search"`
+	Sort      *string    `query:"sort"`
+	StartTime *time.Time `query:"start_time"`
+	EndTime   *time.Time `query:"end_time"`
+	AsOf      string     `query:"as_of"`
+}
+
+func (r BulkExportOrganizationReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Format, validation.Required, validation.In(bulkexport.Formats...)),
+		validation.Field(&r.Search, validation.Length(1, 255)),
+		validation.Field(&r.Sort, validation.Length(1, 255)),
+		validation.Field(&r.AsOf, validation.Date(entities.OrganizationVersionDateLayout)),
+	)
+}
+
+// NewListOrganizationParams returns the params of the same list without a page
+func (r BulkExportOrganizationReq) NewListOrganizationParams() (entities.ListOrganizationParams, error) {
+	listReq := ListOrganizationReq{
+		Page:      1,
+		Search:    r.Search,
+		Sort:      r.Sort,
+		StartTime: r.StartTime,
+		EndTime:   r.EndTime,
+		AsOf:      r.AsOf,
+	}
+
+	return listReq.NewListOrganizationParams()
+}
+
+// BulkExportOrganizationRes is a line of an NDJSON export
+type BulkExportOrganizationRes struct {
+	UUID       string               `json:"id"`
+	Code       nullable.NullString  `json:"code"`
+	ParentUUID nullable.NullString  `json:"parent_id"`
+	ParentCode nullable.NullString  `json:"parent_code"`
+	Name       nullable.NullString  `json:"name"`
+	Type       nullable.NullString  `json:"type"`
+	Address    nullable.NullString  `json:"address"`
+	Latitude   nullable.NullFloat64 `json:"latitude"`
+	Longitude  nullable.NullFloat64 `json:"longitude"`
+	IsActive   bool                 `json:"is_active"`
+	CreatedAt  time.Time            `json:"created_at"`
+	CreatedBy  string               `json:"created_by"`
+}
+
+func NewBulkExportOrganizationRes(organization *entities.Organization) BulkExportOrganizationRes {
+	res := BulkExportOrganizationRes{
+		UUID:       organization.UUID,
+		Code:       organization.Code,
+		ParentUUID: organization.ParentUUID,
+		Name:       organization.Name,
+		Type:       organization.Type,
+		Address:    organization.Address,
+		Latitude:   organization.Latitude,
+		Longitude:  organization.Longitude,
+		IsActive:   organization.IsActive,
+		CreatedAt:  organization.CreatedAt,
+		CreatedBy:  organization.CreatedBy,
+	}
+	if organization.Parent != nil {
+		res.ParentCode = organization.Parent.Code
+	}
+
+	return res
+}
+
+// BulkExportOrganizationTable starts with the columns of the CSV import so an export can be imported again
+var BulkExportOrganizationTable = bulkexport.Table[*entities.Organization]{
+	Columns: append(append([]string{}, organizationCSVColumns...), "id", "parent_id", "is_active", "created_at"),
+	Row: func(organization *entities.Organization) []string {
+		res := NewBulkExportOrganizationRes(organization)
+
+		return []string{
+			res.Code.GetOrDefault(),
+			res.ParentCode.GetOrDefault(),
+			res.Name.GetOrDefault(),
+			res.Type.GetOrDefault(),
+			res.Address.GetOrDefault(),
+			formatCoordinate(res.Latitude),
+			formatCoordinate(res.Longitude),
+			res.UUID,
+			res.ParentUUID.GetOrDefault(),
+			strconv.FormatBool(res.IsActive),
+			res.CreatedAt.Format(time.RFC3339),
+		}
+	},
+	Record: func(organization *entities.Organization) any {
+		return NewBulkExportOrganizationRes(organization)
+	},
+}
@@ -11,24 +11,24 @@ import (
 	"github.com/invopop/validation/is"
 )
 
+// CreateNewOrganizationReq leaves address and coordinates to the rules of the organization type
 type CreateNewOrganizationReq struct {
-	Name      nullable.NullString `json:"name"`
-	Address   nullable.NullString `json:"address"`
-	Latitude  nullable.NullString `json:"latitude"`
-	Longitude nullable.NullString `json:"longitude"`
-	Type      nullable.NullString `json:"type"`
-	ParentId  nullable.NullString `json:"parent_id"`
+	Name      nullable.NullString  `json:"name"`
+	Address   nullable.NullString  `json:"address"`
+	Latitude  nullable.NullFloat64 `json:"latitude"`
+	Longitude nullable.NullFloat64 `json:"longitude"`
+	Type      nullable.NullString  `json:"type"`
+	ParentId  nullable.NullString  `json:"parent_id"`
 }
 
 func (r CreateNewOrganizationReq) Validate() error {
 	return validation.ValidateStruct(&r,
 		validation.Field(&r.Name, validation.Required, validation.Length(1, 255)),
-		validation.Field(&r.Address, validation.Required, validation.Length(1, 255)),
-		validation.Field(&r.Latitude, validation.Required, is.Latitude),
-		validation.Field(&r.Longitude, validation.Required, is.Longitude),
-		validation.Field(&r.Latitude, validation.Required),
-		validation.Field(&r.Longitude, validation.Required),
-		validation.Field(&r.Type, validation.Required, validation.Length(1, 255)),
+		validation.Field(&r.Address, validation.Length(1, 255)),
+		validation.Field(&r.Latitude, coordinateRules(latitudeRules, r.Longitude)...),
+		validation.Field(&r.Longitude, coordinateRules(longitudeRules, r.Latitude)...),
+		validation.Field(&r.Type, validation.Required, validation.Length(1, 100)),
+		validation.Field(&r.ParentId, is.UUID),
 	)
 }
 
@@ -66,6 +66,7 @@ func (r CreateNewOrganizationReq) NewOrganization(cred entities.AuthenticatedUse
 		Longitude:  r.Longitude,
 		Type:       r.Type,
 		ParentUUID: r.ParentId,
+		IsActive:   true,
 	}
 
 	baseModel := entities.NewBaseModel(cred.Username)
@@ -0,0 +1,22 @@
+package external
+
+import "github.com/laksanagusta/identity/internal/entities"
+
+// OrganizationSettingsRes maps every setting key to its effective value for the organization
+type OrganizationSettingsRes struct {
+	OrganizationUUID string                           `json:"organization_id"`
+	Settings         map[string]entities.SettingValue `json:"settings"`
+}
+
+func NewOrganizationSettingsRes(organizationUUID string, settings []entities.EffectiveOrganizationSetting) OrganizationSettingsRes {
+	res := OrganizationSettingsRes{
+		OrganizationUUID: organizationUUID,
+		Settings:         make(map[string]entities.SettingValue, len(settings)),
+	}
+
+	for _, setting := range settings {
+		res.Settings[setting.Key] = setting.Value
+	}
+
+	return res
+}
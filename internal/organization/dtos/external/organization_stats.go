@@ -0,0 +1,34 @@
+package external
+
+import (
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/organization/dtos"
+)
+
+// OrganizationStatsReq represents the external API request for headcounts, breakdowns are internal
+type OrganizationStatsReq struct {
+	OrganizationUUID string `params:"id"`
+	MaxDepth         int    `query:"max_depth"`
+}
+
+// ToInternalReq converts external request to internal request
+func (r *OrganizationStatsReq) ToInternalReq() dtos.OrganizationStatsReq {
+	return dtos.OrganizationStatsReq{
+		OrganizationUUID: r.OrganizationUUID,
+		MaxDepth:         r.MaxDepth,
+	}
+}
+
+// OrganizationStatsRes holds the direct and rolled up headcounts of an organization and the
+// organizations below it
+type OrganizationStatsRes struct {
+	dtos.OrganizationHeadcountRes
+	SubOrganizations []dtos.OrganizationHeadcountRes `json:"sub_organizations"`
+}
+
+func NewOrganizationStatsRes(stats *entities.OrganizationStats) OrganizationStatsRes {
+	return OrganizationStatsRes{
+		OrganizationHeadcountRes: dtos.NewOrganizationHeadcountRes(stats.Headcount),
+		SubOrganizations:         dtos.NewListOrganizationHeadcountRes(stats.SubOrganizations),
+	}
+}
@@ -0,0 +1,85 @@
+package external
+
+import (
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/organization/dtos"
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+// OrganizationTreeReq represents the external API request for ancestors, children and descendants
+type OrganizationTreeReq struct {
+	OrganizationUUID string `params:"id"`
+	MaxDepth         int    `query:"max_depth"`
+	IncludeSelf      bool   `query:"include_self"`
+	AsOf             string `query:"as_of"`
+	IncludeUserCount bool   `query:"include_user_count"`
+}
+
+// ToInternalReq converts external request to internal request
+func (r *OrganizationTreeReq) ToInternalReq() dtos.OrganizationTreeReq {
+	return dtos.OrganizationTreeReq{
+		OrganizationUUID: r.OrganizationUUID,
+		MaxDepth:         r.MaxDepth,
+		IncludeSelf:      r.IncludeSelf,
+		AsOf:             r.AsOf,
+		IncludeUserCount: r.IncludeUserCount,
+	}
+}
+
+// OrganizationNodeRes represents a single organization of the hierarchy in the external API response
+type OrganizationNodeRes struct {
+	UUID       string              `json:"id"`
+	Name       nullable.NullString `json:"name"`
+	Code       nullable.NullString `json:"code"`
+	Type       nullable.NullString `json:"type"`
+	ParentUUID nullable.NullString `json:"parent_id"`
+	Level      nullable.NullInt32  `json:"level"`
+	Path       nullable.NullString `json:"path"`
+	IsActive   bool                `json:"is_active"`
+	UserCount  nullable.NullInt64  `json:"user_count"`
+}
+
+// OrganizationTreeRes represents an organization with its nested sub organizations
+type OrganizationTreeRes struct {
+	OrganizationNodeRes
+	Organizations []OrganizationTreeRes `json:"organizations"`
+}
+
+// NewOrganizationNodeRes converts entities.Organization to OrganizationNodeRes
+func NewOrganizationNodeRes(organization *entities.Organization) OrganizationNodeRes {
+	return OrganizationNodeRes{
+		UUID:       organization.UUID,
+		Name:       organization.Name,
+		Code:       organization.Code,
+		Type:       organization.Type,
+		ParentUUID: organization.ParentUUID,
+		Level:      organization.Level,
+		Path:       organization.Path,
+		IsActive:   organization.IsActive,
+		UserCount:  organization.UserCount,
+	}
+}
+
+// NewListOrganizationNodeRes converts a flat list of organizations
+func NewListOrganizationNodeRes(organizations []*entities.Organization) []OrganizationNodeRes {
+	res := make([]OrganizationNodeRes, 0, len(organizations))
+	for _, organization := range organizations {
+		res = append(res, NewOrganizationNodeRes(organization))
+	}
+
+	return res
+}
+
+// NewOrganizationTreeRes converts an organization and its loaded descendants
+func NewOrganizationTreeRes(organization *entities.Organization) OrganizationTreeRes {
+	res := OrganizationTreeRes{
+		OrganizationNodeRes: NewOrganizationNodeRes(organization),
+		Organizations:       make([]OrganizationTreeRes, 0, len(organization.Children)),
+	}
+
+	for _, child := range organization.Children {
+		res.Organizations = append(res.Organizations, NewOrganizationTreeRes(child))
+	}
+
+	return res
+}
@@ -13,8 +13,8 @@ type ExternalOrganizationRes struct {
 	Name       nullable.NullString      `json:"name"`
 	Code       nullable.NullString      `json:"code"`
 	Address    nullable.NullString      `json:"address"`
-	Latitude   nullable.NullString      `json:"latitude"`
-	Longitude  nullable.NullString      `json:"longitude"`
+	Latitude   nullable.NullFloat64     `json:"latitude"`
+	Longitude  nullable.NullFloat64     `json:"longitude"`
 	Type       nullable.NullString      `json:"type"`
 	ParentUUID nullable.NullString      `json:"parent_id"`
 	Parent     *ExternalOrganizationRes `json:"parent,omitempty"`
@@ -0,0 +1,175 @@
+package dtos
+
+import (
+	"errors"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/pkg/nullable"
+
+	"github.com/invopop/validation"
+)
+
+const (
+	DefaultNearbyOrganizationLimit = 5
+	MaxNearbyOrganizationLimit     = 50
+	// MaxSearchRadiusMeters keeps radius searches to a city sized area
+	MaxSearchRadiusMeters = 100000
+)
+
+// Coordinate rules shared by organization requests and geo searches
+var (
+	latitudeRules  = []validation.Rule{validation.Min(-90.0), validation.Max(90.0)}
+	longitudeRules = []validation.Rule{validation.Min(-180.0), validation.Max(180.0)}
+)
+
+// coordinateRules validates one half of a coordinate, other is the half that makes it a pair. Both
+// halves are set, cleared or left alone together.
+func coordinateRules(rules []validation.Rule, other nullable.NullFloat64) []validation.Rule {
+	pair := validation.By(func(value interface{}) error {
+		coordinate, _ := value.(nullable.NullFloat64)
+		if coordinate.IsExists != other.IsExists || (coordinate.Val == nil) != (other.Val == nil) {
+			return errors.New("must be given together with the other coordinate")
+		}
+		return nil
+	})
+
+	return append([]validation.Rule{pair}, rules...)
+}
+
+// NearestOrganizationReq finds the Limit organizations closest to a GPS fix
+type NearestOrganizationReq struct {
+	Latitude  *float64 `query:"latitude"`
+	Longitude *float64 `query:"longitude"`
+	Limit     int      `query:"limit"`
+}
+
+func (r NearestOrganizationReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Latitude, append([]validation.Rule{validation.NotNil}, latitudeRules...)...),
+		validation.Field(&r.Longitude, append([]validation.Rule{validation.NotNil}, longitudeRules...)...),
+		validation.Field(&r.Limit, validation.Min(0), validation.Max(MaxNearbyOrganizationLimit)),
+	)
+}
+
+func (r NearestOrganizationReq) GeoSearchParams() entities.GeoSearchParams {
+	return entities.GeoSearchParams{
+		Origin: entities.GeoPoint{Latitude: *r.Latitude, Longitude: *r.Longitude},
+		Limit:  nearbyOrganizationLimit(r.Limit),
+	}
+}
+
+// RadiusOrganizationReq finds the organizations within Radius meters of a GPS fix, nearest first
+type RadiusOrganizationReq struct {
+	Latitude  *float64 `query:"latitude"`
+	Longitude *float64 `query:"longitude"`
+	Radius    float64  `query:"radius"`
+	Limit     int      `query:"limit"`
+}
+
+func (r RadiusOrganizationReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Latitude, append([]validation.Rule{validation.NotNil}, latitudeRules...)...),
+		validation.Field(&r.Longitude, append([]validation.Rule{validation.NotNil}, longitudeRules...)...),
+		validation.Field(&r.Radius, validation.Required, validation.Min(1.0), validation.Max(float64(MaxSearchRadiusMeters))),
+		validation.Field(&r.Limit, validation.Min(0), validation.Max(MaxNearbyOrganizationLimit)),
+	)
+}
+
+func (r RadiusOrganizationReq) GeoSearchParams() entities.GeoSearchParams {
+	origin := entities.GeoPoint{Latitude: *r.Latitude, Longitude: *r.Longitude}
+	box := origin.BoxAround(r.Radius)
+
+	return entities.GeoSearchParams{
+		Origin:       origin,
+		Box:          &box,
+		RadiusMeters: nullable.NewFloat64(r.Radius),
+		Limit:        nearbyOrganizationLimit(r.Limit),
+	}
+}
+
+// BoundingBoxOrganizationReq finds the organizations inside a map viewport. Distances are measured
+// from latitude and longitude when given, otherwise from the center of the box. A min_longitude
+// greater than max_longitude is a box crossing the antimeridian.
+type BoundingBoxOrganizationReq struct {
+	MinLatitude  *float64 `query:"min_latitude"`
+	MinLongitude *float64 `query:"min_longitude"`
+	MaxLatitude  *float64 `query:"max_latitude"`
+	MaxLongitude *float64 `query:"max_longitude"`
+	Latitude     *float64 `query:"latitude"`
+	Longitude    *float64 `query:"longitude"`
+	Limit        int      `query:"limit"`
+}
+
+func (r BoundingBoxOrganizationReq) Validate() error {
+	maxLatitudeRules := append([]validation.Rule{validation.NotNil}, latitudeRules...)
+	if r.MinLatitude != nil {
+		maxLatitudeRules = append(maxLatitudeRules, validation.Min(*r.MinLatitude).Error("must be no less than min_latitude"))
+	}
+
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.MinLatitude, append([]validation.Rule{validation.NotNil}, latitudeRules...)...),
+		validation.Field(&r.MinLongitude, append([]validation.Rule{validation.NotNil}, longitudeRules...)...),
+		validation.Field(&r.MaxLatitude, maxLatitudeRules...),
+		validation.Field(&r.MaxLongitude, append([]validation.Rule{validation.NotNil}, longitudeRules...)...),
+		validation.Field(&r.Latitude, append([]validation.Rule{validation.When(r.Longitude != nil, validation.NotNil)}, latitudeRules...)...),
+		validation.Field(&r.Longitude, append([]validation.Rule{validation.When(r.Latitude != nil, validation.NotNil)}, longitudeRules...)...),
+		validation.Field(&r.Limit, validation.Min(0), validation.Max(MaxNearbyOrganizationLimit)),
+	)
+}
+
+func (r BoundingBoxOrganizationReq) GeoSearchParams() entities.GeoSearchParams {
+	box := entities.GeoBox{
+		MinLatitude:  *r.MinLatitude,
+		MinLongitude: *r.MinLongitude,
+		MaxLatitude:  *r.MaxLatitude,
+		MaxLongitude: *r.MaxLongitude,
+	}
+
+	origin := box.Center()
+	if r.Latitude != nil && r.Longitude != nil {
+		origin = entities.GeoPoint{Latitude: *r.Latitude, Longitude: *r.Longitude}
+	}
+
+	return entities.GeoSearchParams{
+		Origin: origin,
+		Box:    &box,
+		Limit:  nearbyOrganizationLimit(r.Limit),
+	}
+}
+
+func nearbyOrganizationLimit(limit int) int {
+	if limit == 0 {
+		return DefaultNearbyOrganizationLimit
+	}
+
+	return limit
+}
+
+type NearbyOrganizationRes struct {
+	UUID           string               `json:"id"`
+	Name           nullable.NullString  `json:"name"`
+	Code           nullable.NullString  `json:"code"`
+	Type           nullable.NullString  `json:"type"`
+	Address        nullable.NullString  `json:"address"`
+	Latitude       nullable.NullFloat64 `json:"latitude"`
+	Longitude      nullable.NullFloat64 `json:"longitude"`
+	DistanceMeters nullable.NullFloat64 `json:"distance_meters"`
+}
+
+func NewListNearbyOrganizationRes(organizations []*entities.Organization) []NearbyOrganizationRes {
+	res := make([]NearbyOrganizationRes, 0, len(organizations))
+	for _, organization := range organizations {
+		res = append(res, NearbyOrganizationRes{
+			UUID:           organization.UUID,
+			Name:           organization.Name,
+			Code:           organization.Code,
+			Type:           organization.Type,
+			Address:        organization.Address,
+			Latitude:       organization.Latitude,
+			Longitude:      organization.Longitude,
+			DistanceMeters: organization.DistanceMeters,
+		})
+	}
+
+	return res
+}
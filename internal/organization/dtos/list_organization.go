@@ -19,17 +19,18 @@ type ListOrganizationReq struct {
 	Sort      *string    `query:"sort"`
 	StartTime *time.Time `query:"start_time"`
 	EndTime   *time.Time `query:"end_time"`
+	AsOf      string     `query:"as_of"`
 }
 
 type ListOrganizationRespData struct {
-	UUID      string              `json:"id"`
-	Name      string              `json:"name"`
-	Address   nullable.NullString `json:"address"`
-	Latitude  nullable.NullString `json:"latitude"`
-	Longitude nullable.NullString `json:"longitude"`
-	Type      nullable.NullString `json:"type"`
-	CreatedAt nullable.NullString `json:"created_at"`
-	CreatedBy string              `json:"created_by"`
+	UUID      string               `json:"id"`
+	Name      string               `json:"name"`
+	Address   nullable.NullString  `json:"address"`
+	Latitude  nullable.NullFloat64 `json:"latitude"`
+	Longitude nullable.NullFloat64 `json:"longitude"`
+	Type      nullable.NullString  `json:"type"`
+	CreatedAt nullable.NullString  `json:"created_at"`
+	CreatedBy string               `json:"created_by"`
 }
 
 type ListOrganizationRespMetadata struct {
@@ -52,6 +53,7 @@ func (r ListOrganizationReq) Validate() error {
 		validation.Field(&r.Sort, validation.Length(1, 255)),
 		validation.Field(&r.StartTime, validation.Length(1, 255), validation.Date(time.RFC3339)),
 		validation.Field(&r.EndTime, validation.Length(1, 255), validation.Date(time.RFC3339)),
+		validation.Field(&r.AsOf, validation.Date(entities.OrganizationVersionDateLayout)),
 	)
 
 	return err
@@ -67,6 +69,7 @@ func (r *ListOrganizationReq) NewListOrganizationParams() (entities.ListOrganiza
 	listOrganizationParams := entities.ListOrganizationParams{
 		Offset: (r.Page - 1) * r.Limit,
 		Limit:  r.Limit,
+		AsOf:   parseAsOf(r.AsOf),
 	}
 
 	if r.StartTime != nil {
@@ -0,0 +1,60 @@
+package dtos
+
+import (
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/pkg/nullable"
+
+	"github.com/invopop/validation"
+	"github.com/invopop/validation/is"
+)
+
+// MoveOrganizationReq moves an organization below parent_id, a null parent_id makes it a root
+type MoveOrganizationReq struct {
+	OrganizationUUID string              `params:"organizationUUID"`
+	ParentUUID       nullable.NullString `json:"parent_id"`
+	DryRun           bool                `json:"dry_run"`
+}
+
+func (r MoveOrganizationReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.OrganizationUUID, validation.Required, is.UUID),
+		validation.Field(&r.ParentUUID, is.UUID),
+	)
+}
+
+type MoveOrganizationRes struct {
+	DryRun   bool                        `json:"dry_run"`
+	Affected []OrganizationPathChangeRes `json:"affected"`
+}
+
+type OrganizationPathChangeRes struct {
+	UUID     string              `json:"id"`
+	Name     nullable.NullString `json:"name"`
+	OldPath  string              `json:"old_path"`
+	NewPath  string              `json:"new_path"`
+	OldLevel int32               `json:"old_level"`
+	NewLevel int32               `json:"new_level"`
+}
+
+func NewMoveOrganizationRes(dryRun bool, changes []entities.OrganizationPathChange) MoveOrganizationRes {
+	return MoveOrganizationRes{
+		DryRun:   dryRun,
+		Affected: newListOrganizationPathChangeRes(changes),
+	}
+}
+
+func newListOrganizationPathChangeRes(changes []entities.OrganizationPathChange) []OrganizationPathChangeRes {
+	res := make([]OrganizationPathChangeRes, 0, len(changes))
+	for _, change := range changes {
+		res = append(res, OrganizationPathChangeRes{
+			UUID:     change.UUID,
+			Name:     change.Name,
+			OldPath:  change.OldPath,
+			NewPath:  change.NewPath,
+			OldLevel: change.OldLevel,
+			NewLevel: change.NewLevel,
+		})
+	}
+
+	return res
+}
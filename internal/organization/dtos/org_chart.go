@@ -0,0 +1,91 @@
+package dtos
+
+import (
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+// OrgChartRes is one organization of the chart with its people and sub organizations.
+// Reporting lines are expressed by manager_id, a manager may belong to another organization.
+type OrgChartRes struct {
+	UUID          string              `json:"id"`
+	Name          nullable.NullString `json:"name"`
+	Code          nullable.NullString `json:"code"`
+	Type          nullable.NullString `json:"type"`
+	Level         nullable.NullInt32  `json:"level"`
+	People        []OrgChartPersonRes `json:"people"`
+	Organizations []OrgChartRes       `json:"organizations"`
+}
+
+type OrgChartPersonRes struct {
+	UUID                string              `json:"id"`
+	EmployeeID          nullable.NullString `json:"employee_id"`
+	FirstName           nullable.NullString `json:"first_name"`
+	LastName            nullable.NullString `json:"last_name"`
+	Email               nullable.NullString `json:"email"`
+	ManagerUUID         nullable.NullString `json:"manager_id"`
+	DirectReportsCount  int                 `json:"direct_reports_count"`
+	AvatarGradientStart nullable.NullString `json:"avatar_gradient_start"`
+	AvatarGradientEnd   nullable.NullString `json:"avatar_gradient_end"`
+	AvatarURLs          entities.AvatarURLs `json:"avatar_urls"`
+}
+
+func NewOrgChartRes(organization *entities.Organization) OrgChartRes {
+	if organization == nil {
+		return OrgChartRes{}
+	}
+
+	directReports := make(map[string]int)
+	countDirectReports(organization, directReports)
+
+	return newOrgChartNode(organization, directReports)
+}
+
+func countDirectReports(organization *entities.Organization, directReports map[string]int) {
+	for _, user := range organization.Users {
+		if user.ManagerUUID.IsNotEmpty() {
+			directReports[user.ManagerUUID.GetOrDefault()]++
+		}
+	}
+
+	for _, child := range organization.Children {
+		if child != nil {
+			countDirectReports(child, directReports)
+		}
+	}
+}
+
+func newOrgChartNode(organization *entities.Organization, directReports map[string]int) OrgChartRes {
+	res := OrgChartRes{
+		UUID:          organization.UUID,
+		Name:          organization.Name,
+		Code:          organization.Code,
+		Type:          organization.Type,
+		Level:         organization.Level,
+		People:        make([]OrgChartPersonRes, 0, len(organization.Users)),
+		Organizations: []OrgChartRes{},
+	}
+
+	for _, user := range organization.Users {
+		res.People = append(res.People, OrgChartPersonRes{
+			UUID:                user.UUID,
+			EmployeeID:          user.EmployeeID,
+			FirstName:           user.FirstName,
+			LastName:            user.LastName,
+			Email:               user.Email,
+			ManagerUUID:         user.ManagerUUID,
+			DirectReportsCount:  directReports[user.UUID],
+			AvatarGradientStart: user.AvatarGradientStart,
+			AvatarGradientEnd:   user.AvatarGradientEnd,
+			AvatarURLs:          user.AvatarURLs,
+		})
+	}
+
+	for _, child := range organization.Children {
+		if child != nil {
+			res.Organizations = append(res.Organizations, newOrgChartNode(child, directReports))
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,49 @@
+package dtos
+
+import (
+	"testing"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+func newChartUser(uuid, managerUUID string) *entities.User {
+	u := &entities.User{}
+	u.UUID = uuid
+	if managerUUID != "" {
+		u.ManagerUUID = nullable.NewString(managerUUID)
+	}
+	return u
+}
+
+func TestNewOrgChartRes_CountsReportsAcrossOrganizations(t *testing.T) {
+	child := &entities.Organization{Users: []*entities.User{
+		newChartUser("lead", "head"),
+		newChartUser("engineer-1", "lead"),
+		newChartUser("engineer-2", "lead"),
+	}}
+	child.UUID = "engineering"
+
+	root := &entities.Organization{
+		Users:    []*entities.User{newChartUser("head", "")},
+		Children: []*entities.Organization{child},
+	}
+	root.UUID = "company"
+
+	res := NewOrgChartRes(root)
+
+	if len(res.People) != 1 || res.People[0].DirectReportsCount != 1 {
+		t.Fatalf("expected head with 1 direct report, got %+v", res.People)
+	}
+	if len(res.Organizations) != 1 {
+		t.Fatalf("expected 1 child organization, got %d", len(res.Organizations))
+	}
+
+	counts := map[string]int{}
+	for _, person := range res.Organizations[0].People {
+		counts[person.UUID] = person.DirectReportsCount
+	}
+	if counts["lead"] != 2 || counts["engineer-1"] != 0 {
+		t.Fatalf("unexpected direct report counts %v", counts)
+	}
+}
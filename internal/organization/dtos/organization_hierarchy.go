@@ -0,0 +1,43 @@
+package dtos
+
+import "github.com/laksanagusta/identity/internal/entities"
+
+// CheckOrganizationHierarchyReq checks the hierarchy, with Repair the repairable issues are fixed
+type CheckOrganizationHierarchyReq struct {
+	Repair bool
+}
+
+type OrganizationHierarchyIssueRes struct {
+	Type             string `json:"type"`
+	OrganizationUUID string `json:"organization_id"`
+	Expected         string `json:"expected"`
+	Actual           string `json:"actual"`
+	IsRepairable     bool   `json:"is_repairable"`
+}
+
+type OrganizationHierarchyReportRes struct {
+	Checked  int                             `json:"checked"`
+	Repaired bool                            `json:"repaired"`
+	Counts   map[string]int                  `json:"counts"`
+	Issues   []OrganizationHierarchyIssueRes `json:"issues"`
+}
+
+func NewOrganizationHierarchyReportRes(report *entities.OrganizationHierarchyReport) OrganizationHierarchyReportRes {
+	issues := make([]OrganizationHierarchyIssueRes, 0, len(report.Issues))
+	for _, issue := range report.Issues {
+		issues = append(issues, OrganizationHierarchyIssueRes{
+			Type:             issue.Type,
+			OrganizationUUID: issue.OrganizationUUID,
+			Expected:         issue.Expected,
+			Actual:           issue.Actual,
+			IsRepairable:     issue.IsRepairable,
+		})
+	}
+
+	return OrganizationHierarchyReportRes{
+		Checked:  report.Checked,
+		Repaired: report.Repaired,
+		Counts:   report.CountByType(),
+		Issues:   issues,
+	}
+}
@@ -0,0 +1,375 @@
+package dtos
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/laksanagusta/identity/constants"
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/pkg/errorhelper"
+	"github.com/laksanagusta/identity/pkg/nullable"
+
+	"github.com/invopop/validation"
+	"github.com/invopop/validation/is"
+)
+
+// Formats of organization import and export files
+const (
+	OrganizationFileJSON = "json"
+	OrganizationFileCSV  = "csv"
+)
+
+// organizationCSVColumns is the header of exported CSV files, imports only require code, name and type
+var organizationCSVColumns = []string{"code", "parent_code", "name", "type", "address", "latitude", "longitude"}
+
+// OrganizationFileNode is an organization of a nested JSON file. ParentCode is only read on top level
+// nodes, where it places the node below an organization that is not part of the file.
+type OrganizationFileNode struct {
+	Code       string                 `json:"code"`
+	ParentCode string                 `json:"parent_code,omitempty"`
+	Name       nullable.NullString    `json:"name"`
+	Type       nullable.NullString    `json:"type"`
+	Address    nullable.NullString    `json:"address"`
+	Latitude   nullable.NullFloat64   `json:"latitude"`
+	Longitude  nullable.NullFloat64   `json:"longitude"`
+	Children   []OrganizationFileNode `json:"children,omitempty"`
+}
+
+type OrganizationFile struct {
+	Organizations []OrganizationFileNode `json:"organizations"`
+}
+
+func (n OrganizationFileNode) row(parentCode string) entities.OrganizationImportRow {
+	return entities.OrganizationImportRow{
+		Code:       n.Code,
+		ParentCode: parentCode,
+		Name:       n.Name,
+		Type:       n.Type,
+		Address:    n.Address,
+		Latitude:   n.Latitude,
+		Longitude:  n.Longitude,
+	}
+}
+
+// ImportOrganizationReq upserts the organizations of a JSON or CSV file by code. Format defaults to
+// the content type of the body.
+type ImportOrganizationReq struct {
+	Format string `query:"format"`
+	DryRun bool   `query:"dry_run"`
+
+	Rows entities.OrganizationImportRows `query:"-"`
+}
+
+func (r ImportOrganizationReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Format, validation.Required.Error(constants.ErrMsgOrganizationImportFormat), validation.In(OrganizationFileJSON, OrganizationFileCSV).Error(constants.ErrMsgOrganizationImportFormat)),
+	)
+}
+
+// ParseRows reads the rows of body in r.Format and checks every row on its own
+func (r *ImportOrganizationReq) ParseRows(body []byte) error {
+	var err error
+	switch r.Format {
+	case OrganizationFileCSV:
+		r.Rows, err = parseOrganizationCSV(body)
+	default:
+		r.Rows, err = parseOrganizationJSON(body)
+	}
+	if err != nil {
+		return err
+	}
+
+	errs := map[string][]string{}
+	for i, row := range r.Rows {
+		err := validateOrganizationImportRow(row)
+		if err == nil {
+			continue
+		}
+
+		var rowErrs validation.Errors
+		if !errors.As(err, &rowErrs) {
+			return err
+		}
+		for field, fieldErr := range rowErrs {
+			key := "rows." + strconv.Itoa(i+1) + "." + field
+			errs[key] = append(errs[key], fieldErr.Error())
+		}
+	}
+
+	if len(errs) > 0 {
+		return errorhelper.BadRequestMap(errs)
+	}
+
+	return nil
+}
+
+func validateOrganizationImportRow(row entities.OrganizationImportRow) error {
+	return validation.ValidateStruct(&row,
+		validation.Field(&row.Code, validation.Required, validation.Length(1, 100)),
+		validation.Field(&row.ParentCode, validation.Length(1, 100)),
+		validation.Field(&row.Name, validation.Required, validation.Length(1, 255)),
+		validation.Field(&row.Type, validation.Required, validation.Length(1, 100)),
+		validation.Field(&row.Address, validation.Length(1, 255)),
+		validation.Field(&row.Latitude, coordinateRules(latitudeRules, row.Longitude)...),
+		validation.Field(&row.Longitude, coordinateRules(longitudeRules, row.Latitude)...),
+	)
+}
+
+// parseOrganizationJSON flattens the nested organizations, children right after their parent
+func parseOrganizationJSON(body []byte) (entities.OrganizationImportRows, error) {
+	var file OrganizationFile
+	err := json.Unmarshal(body, &file)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := entities.OrganizationImportRows{}
+	var flatten func(nodes []OrganizationFileNode, parentCode string, isTop bool)
+	flatten = func(nodes []OrganizationFileNode, parentCode string, isTop bool) {
+		for _, node := range nodes {
+			if isTop {
+				parentCode = node.ParentCode
+			}
+			rows = append(rows, node.row(parentCode))
+			flatten(node.Children, node.Code, false)
+		}
+	}
+	flatten(file.Organizations, "", true)
+
+	return rows, nil
+}
+
+// parseOrganizationCSV reads rows of a file with a header line, columns may come in any order and
+// empty cells are left unset
+func parseOrganizationCSV(body []byte) (entities.OrganizationImportRows, error) {
+	reader := csv.NewReader(strings.NewReader(string(body)))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return entities.OrganizationImportRows{}, nil
+	}
+	if err != nil {
+		return nil, errorhelper.BadRequest(err.Error())
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+
+	errs := map[string][]string{}
+	for _, column := range []string{"code", "name", "type"} {
+		if _, ok := columns[column]; !ok {
+			errs["columns."+column] = []string{constants.ErrMsgOrganizationImportColumn}
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errorhelper.BadRequestMap(errs)
+	}
+
+	rows := entities.OrganizationImportRows{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errorhelper.BadRequest(err.Error())
+		}
+
+		cell := func(column string) string {
+			i, ok := columns[column]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+		text := func(column string) nullable.NullString {
+			if value := cell(column); value != "" {
+				return nullable.NewString(value)
+			}
+			return nullable.NullString{}
+		}
+		number := func(column string) nullable.NullFloat64 {
+			value := cell(column)
+			if value == "" {
+				return nullable.NullFloat64{}
+			}
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				key := "rows." + strconv.Itoa(len(rows)+1) + "." + column
+				errs[key] = append(errs[key], constants.ErrMsgOrganizationImportNumber)
+			}
+			return nullable.NewFloat64(parsed)
+		}
+
+		rows = append(rows, entities.OrganizationImportRow{
+			Code:       cell("code"),
+			ParentCode: cell("parent_code"),
+			Name:       text("name"),
+			Type:       text("type"),
+			Address:    text("address"),
+			Latitude:   number("latitude"),
+			Longitude:  number("longitude"),
+		})
+	}
+
+	if len(errs) > 0 {
+		return nil, errorhelper.BadRequestMap(errs)
+	}
+
+	return rows, nil
+}
+
+type OrganizationImportChangeRes struct {
+	Action           string   `json:"action"`
+	Code             string   `json:"code"`
+	OrganizationUUID string   `json:"organization_id,omitempty"`
+	Fields           []string `json:"fields,omitempty"`
+}
+
+type OrganizationImportRes struct {
+	DryRun  bool                          `json:"dry_run"`
+	Counts  map[string]int                `json:"counts"`
+	Changes []OrganizationImportChangeRes `json:"changes"`
+}
+
+func NewOrganizationImportRes(dryRun bool, changes []entities.OrganizationImportChange) OrganizationImportRes {
+	res := OrganizationImportRes{
+		DryRun: dryRun,
+		Counts: map[string]int{
+			entities.OrganizationImportCreate:    0,
+			entities.OrganizationImportUpdate:    0,
+			entities.OrganizationImportUnchanged: 0,
+		},
+		Changes: make([]OrganizationImportChangeRes, 0, len(changes)),
+	}
+
+	for _, change := range changes {
+		res.Counts[change.Action]++
+		res.Changes = append(res.Changes, OrganizationImportChangeRes{
+			Action:           change.Action,
+			Code:             change.Code,
+			OrganizationUUID: change.OrganizationUUID,
+			Fields:           change.Fields,
+		})
+	}
+
+	return res
+}
+
+// ExportOrganizationReq exports the subtree of RootUUID, or every organization when it is empty, as
+// it is today or as of AsOf
+type ExportOrganizationReq struct {
+	Format   string `query:"format"`
+	RootUUID string `query:"root_id"`
+	AsOf     string `query:"as_of"`
+}
+
+func (r ExportOrganizationReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Format, validation.In(OrganizationFileJSON, OrganizationFileCSV).Error(constants.ErrMsgOrganizationImportFormat)),
+		validation.Field(&r.RootUUID, is.UUID),
+		validation.Field(&r.AsOf, validation.Date(entities.OrganizationVersionDateLayout)),
+	)
+}
+
+func (r ExportOrganizationReq) AsOfDate() nullable.NullTime {
+	return parseAsOf(r.AsOf)
+}
+
+// NewOrganizationFile nests organizations, ordered parents first, the way imports read them. The
+// exported root keeps the code of its parent when it has one.
+func NewOrganizationFile(organizations []*entities.Organization) OrganizationFile {
+	nodes := make(map[string]*OrganizationFileNode, len(organizations))
+	var tops []*OrganizationFileNode
+	for _, organization := range organizations {
+		node := &OrganizationFileNode{
+			Code:      organization.Code.GetOrDefault(),
+			Name:      organization.Name,
+			Type:      organization.Type,
+			Address:   organization.Address,
+			Latitude:  organization.Latitude,
+			Longitude: organization.Longitude,
+		}
+		nodes[organization.UUID] = node
+
+		if _, ok := nodes[organization.ParentUUID.GetOrDefault()]; !ok {
+			node.ParentCode = exportedParentCode(organization)
+			tops = append(tops, node)
+		}
+	}
+
+	// Children are copied into their parent once the whole subtree below them is linked
+	for i := len(organizations) - 1; i >= 0; i-- {
+		organization := organizations[i]
+		parent, ok := nodes[organization.ParentUUID.GetOrDefault()]
+		if !ok {
+			continue
+		}
+		parent.Children = append([]OrganizationFileNode{*nodes[organization.UUID]}, parent.Children...)
+	}
+
+	file := OrganizationFile{Organizations: make([]OrganizationFileNode, 0, len(tops))}
+	for _, top := range tops {
+		file.Organizations = append(file.Organizations, *top)
+	}
+
+	return file
+}
+
+// WriteOrganizationCSV writes organizations, ordered parents first, with a header line
+func WriteOrganizationCSV(w io.Writer, organizations []*entities.Organization) error {
+	codes := make(map[string]string, len(organizations))
+	for _, organization := range organizations {
+		codes[organization.UUID] = organization.Code.GetOrDefault()
+	}
+
+	writer := csv.NewWriter(w)
+	err := writer.Write(organizationCSVColumns)
+	if err != nil {
+		return err
+	}
+
+	for _, organization := range organizations {
+		parentCode, ok := codes[organization.ParentUUID.GetOrDefault()]
+		if !ok {
+			parentCode = exportedParentCode(organization)
+		}
+
+		err = writer.Write([]string{
+			organization.Code.GetOrDefault(),
+			parentCode,
+			organization.Name.GetOrDefault(),
+			organization.Type.GetOrDefault(),
+			organization.Address.GetOrDefault(),
+			formatCoordinate(organization.Latitude),
+			formatCoordinate(organization.Longitude),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func exportedParentCode(organization *entities.Organization) string {
+	if organization.Parent == nil {
+		return ""
+	}
+	return organization.Parent.Code.GetOrDefault()
+}
+
+func formatCoordinate(coordinate nullable.NullFloat64) string {
+	if coordinate.Val == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*coordinate.Val, 'f', -1, 64)
+}
@@ -0,0 +1,93 @@
+package dtos
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+func newExportedOrganization(uuid, parentUUID, code string) *entities.Organization {
+	o := &entities.Organization{Code: nullable.NewString(code), Name: nullable.NewString(code), Type: nullable.NewString("unit")}
+	o.UUID = uuid
+	if parentUUID != "" {
+		o.ParentUUID = nullable.NewString(parentUUID)
+	}
+	return o
+}
+
+func exportedSubtree() []*entities.Organization {
+	root := newExportedOrganization("1", "0", "company")
+	root.Parent = newExportedOrganization("0", "", "holding")
+	dept := newExportedOrganization("2", "1", "dept")
+	dept.Latitude = nullable.NewFloat64(-6.2)
+	dept.Longitude = nullable.NewFloat64(106.816666)
+
+	return []*entities.Organization{root, dept, newExportedOrganization("3", "1", "sales"), newExportedOrganization("4", "2", "team")}
+}
+
+func importedCodes(rows entities.OrganizationImportRows) []string {
+	var codes []string
+	for _, row := range rows {
+		codes = append(codes, row.ParentCode+">"+row.Code)
+	}
+	return codes
+}
+
+func TestOrganizationFileRoundTrip(t *testing.T) {
+	expected := []string{"holding>company", "company>dept", "dept>team", "company>sales"}
+
+	data, err := json.Marshal(NewOrganizationFile(exportedSubtree()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := ImportOrganizationReq{Format: OrganizationFileJSON}
+	err = req.ParseRows(data)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if codes := importedCodes(req.Rows); len(codes) != len(expected) || codes[0] != expected[0] || codes[2] != expected[2] || codes[3] != expected[3] {
+		t.Fatalf("unexpected rows %v from %s", codes, data)
+	}
+
+	var file bytes.Buffer
+	err = WriteOrganizationCSV(&file, exportedSubtree())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req = ImportOrganizationReq{Format: OrganizationFileCSV}
+	err = req.ParseRows(file.Bytes())
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if codes := importedCodes(req.Rows); len(codes) != 4 || codes[0] != "holding>company" || codes[3] != "dept>team" {
+		t.Fatalf("unexpected rows %v from %s", codes, file.String())
+	}
+	if req.Rows[1].Longitude.GetOrDefault() != 106.816666 {
+		t.Errorf("expected coordinates to survive the round trip, got %+v", req.Rows[1])
+	}
+}
+
+func TestImportOrganizationReqParseRows_ReportsRowErrors(t *testing.T) {
+	req := ImportOrganizationReq{Format: OrganizationFileCSV}
+	err := req.ParseRows([]byte("code,name,type,latitude\nhq,HQ,office,north\n,Branch,office,\n"))
+	if err == nil {
+		t.Fatal("expected an error for a bad coordinate")
+	}
+
+	req = ImportOrganizationReq{Format: OrganizationFileCSV}
+	err = req.ParseRows([]byte("code,name\nhq,HQ\n"))
+	if err == nil {
+		t.Fatal("expected an error for the missing type column")
+	}
+
+	req = ImportOrganizationReq{Format: OrganizationFileJSON}
+	err = req.ParseRows([]byte(`{"organizations": [{"code": "hq", "name": "HQ", "type": "office", "latitude": 1}]}`))
+	if err == nil {
+		t.Fatal("expected an error for a latitude without longitude")
+	}
+}
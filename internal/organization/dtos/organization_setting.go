@@ -0,0 +1,173 @@
+package dtos
+
+import (
+	"encoding/json"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/pkg/nullable"
+
+	"github.com/invopop/validation"
+	"github.com/invopop/validation/is"
+)
+
+// settingTypes are the user attribute types, settings are validated the same way
+var settingTypes = []interface{}{
+	entities.AttributeTypeString,
+	entities.AttributeTypeNumber,
+	entities.AttributeTypeBoolean,
+	entities.AttributeTypeDate,
+	entities.AttributeTypeEnum,
+}
+
+type CreateOrganizationSettingReq struct {
+	Key          nullable.NullString   `json:"key"`
+	Label        nullable.NullString   `json:"label"`
+	Description  nullable.NullString   `json:"description"`
+	Type         nullable.NullString   `json:"type"`
+	EnumValues   []string              `json:"enum_values"`
+	DefaultValue entities.SettingValue `json:"default_value"`
+}
+
+func (r CreateOrganizationSettingReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Key, validation.Required, validation.Match(entities.AttributeKeyRegex)),
+		validation.Field(&r.Label, validation.Required, validation.Length(1, 255)),
+		validation.Field(&r.Type, validation.Required, validation.In(settingTypes...)),
+		validation.Field(&r.EnumValues,
+			validation.When(r.Type.GetOrDefault() == entities.AttributeTypeEnum, validation.Required),
+			validation.Each(validation.Required, validation.Length(1, 255)),
+		),
+	)
+}
+
+func (r CreateOrganizationSettingReq) NewOrganizationSettingDefinition(cred entities.AuthenticatedUser) entities.OrganizationSettingDefinition {
+	definition := entities.OrganizationSettingDefinition{
+		Key:          r.Key,
+		Label:        r.Label,
+		Description:  r.Description,
+		Type:         r.Type,
+		EnumValues:   r.EnumValues,
+		DefaultValue: r.DefaultValue,
+	}
+
+	if definition.EnumValues == nil {
+		definition.EnumValues = []string{}
+	}
+
+	definition.BaseModel = entities.NewBaseModel(cred.Username)
+
+	return definition
+}
+
+// UpdateOrganizationSettingReq does not allow changing key and type, stored values depend on them.
+// A null default_value removes the default.
+type UpdateOrganizationSettingReq struct {
+	UUID         string              `params:"settingUUID"`
+	Label        nullable.NullString `json:"label"`
+	Description  nullable.NullString `json:"description"`
+	EnumValues   []string            `json:"enum_values"`
+	DefaultValue json.RawMessage     `json:"default_value"`
+}
+
+func (r UpdateOrganizationSettingReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.UUID, validation.Required, is.UUID),
+		validation.Field(&r.Label, validation.Length(1, 255)),
+		validation.Field(&r.EnumValues, validation.Each(validation.Required, validation.Length(1, 255))),
+	)
+}
+
+// Apply copies the provided fields onto the stored definition
+func (r UpdateOrganizationSettingReq) Apply(definition *entities.OrganizationSettingDefinition, cred entities.AuthenticatedUser) error {
+	if r.Label.IsExists {
+		definition.Label = r.Label
+	}
+	if r.Description.IsExists {
+		definition.Description = r.Description
+	}
+	if r.EnumValues != nil {
+		definition.EnumValues = r.EnumValues
+	}
+	if r.DefaultValue != nil {
+		var defaultValue entities.SettingValue
+		if err := json.Unmarshal(r.DefaultValue, &defaultValue); err != nil {
+			return err
+		}
+		definition.DefaultValue = defaultValue
+	}
+
+	definition.UpdateModel(cred.Username)
+
+	return nil
+}
+
+type OrganizationSettingsReq struct {
+	OrganizationUUID string `params:"organizationUUID"`
+}
+
+func (r OrganizationSettingsReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.OrganizationUUID, validation.Required, is.UUID),
+	)
+}
+
+// UpdateOrganizationSettingsReq sets values on an organization by key, a null value removes the
+// value so the organization inherits it again
+type UpdateOrganizationSettingsReq struct {
+	OrganizationUUID string         `params:"organizationUUID"`
+	Settings         map[string]any `json:"settings"`
+}
+
+func (r UpdateOrganizationSettingsReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.OrganizationUUID, validation.Required, is.UUID),
+		validation.Field(&r.Settings, validation.Required),
+	)
+}
+
+type OrganizationSettingDefinitionRes struct {
+	UUID         string                `json:"id"`
+	Key          nullable.NullString   `json:"key"`
+	Label        nullable.NullString   `json:"label"`
+	Description  nullable.NullString   `json:"description"`
+	Type         nullable.NullString   `json:"type"`
+	EnumValues   []string              `json:"enum_values"`
+	DefaultValue entities.SettingValue `json:"default_value"`
+}
+
+func NewListOrganizationSettingDefinitionRes(definitions entities.OrganizationSettingDefinitions) []OrganizationSettingDefinitionRes {
+	res := make([]OrganizationSettingDefinitionRes, 0, len(definitions))
+	for _, definition := range definitions {
+		res = append(res, OrganizationSettingDefinitionRes{
+			UUID:         definition.UUID,
+			Key:          definition.Key,
+			Label:        definition.Label,
+			Description:  definition.Description,
+			Type:         definition.Type,
+			EnumValues:   definition.EnumValues,
+			DefaultValue: definition.DefaultValue,
+		})
+	}
+	return res
+}
+
+type EffectiveOrganizationSettingRes struct {
+	Key                    string                `json:"key"`
+	Value                  entities.SettingValue `json:"value"`
+	SourceOrganizationUUID nullable.NullString   `json:"source_organization_id"`
+	IsInherited            bool                  `json:"is_inherited"`
+}
+
+// NewListEffectiveOrganizationSettingRes describes the settings resolved for organizationUUID
+func NewListEffectiveOrganizationSettingRes(organizationUUID string, settings []entities.EffectiveOrganizationSetting) []EffectiveOrganizationSettingRes {
+	res := make([]EffectiveOrganizationSettingRes, 0, len(settings))
+	for _, setting := range settings {
+		res = append(res, EffectiveOrganizationSettingRes{
+			Key:                    setting.Key,
+			Value:                  setting.Value,
+			SourceOrganizationUUID: setting.SourceOrganizationUUID,
+			IsInherited:            setting.IsInheritedBy(organizationUUID),
+		})
+	}
+	return res
+}
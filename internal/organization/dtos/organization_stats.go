@@ -0,0 +1,111 @@
+package dtos
+
+import (
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/pkg/nullable"
+
+	"github.com/invopop/validation"
+	"github.com/invopop/validation/is"
+)
+
+// MaxOrganizationStatsMonths bounds months, the number of months of new joiners counted up to today
+const MaxOrganizationStatsMonths = 60
+
+const defaultOrganizationStatsMonths = 12
+
+// OrganizationStatsReq counts the users of an organization and of the sub organizations down to
+// MaxDepth levels, 1 by default. Breakdowns adds the counts by status, role and month joined.
+type OrganizationStatsReq struct {
+	OrganizationUUID string `params:"organizationUUID"`
+	MaxDepth         int    `query:"max_depth"`
+	Months           int    `query:"months"`
+	Breakdowns       bool   `query:"-"`
+}
+
+func (r OrganizationStatsReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.OrganizationUUID, validation.Required, is.UUID),
+		validation.Field(&r.MaxDepth, validation.Min(0), validation.Max(MaxOrganizationTreeDepth)),
+		validation.Field(&r.Months, validation.Min(0), validation.Max(MaxOrganizationStatsMonths)),
+	)
+}
+
+func (r OrganizationStatsReq) Depth() int32 {
+	if r.MaxDepth == 0 {
+		return 1
+	}
+	return int32(r.MaxDepth)
+}
+
+func (r OrganizationStatsReq) MonthCount() int {
+	if r.Months == 0 {
+		return defaultOrganizationStatsMonths
+	}
+	return r.Months
+}
+
+type OrganizationHeadcountRes struct {
+	OrganizationUUID string              `json:"organization_id"`
+	Name             nullable.NullString `json:"name"`
+	Depth            int32               `json:"depth"`
+	Direct           int64               `json:"direct"`
+	Total            int64               `json:"total"`
+}
+
+type OrganizationStatsBucketRes struct {
+	Key    string              `json:"key"`
+	Label  nullable.NullString `json:"label,omitempty"`
+	Direct int64               `json:"direct"`
+	Total  int64               `json:"total"`
+}
+
+type OrganizationStatsRes struct {
+	OrganizationHeadcountRes
+	SubOrganizations []OrganizationHeadcountRes   `json:"sub_organizations"`
+	ByStatus         []OrganizationStatsBucketRes `json:"by_status"`
+	ByDirectRole     []OrganizationStatsBucketRes `json:"by_direct_role"`
+	JoinersByMonth   []OrganizationStatsBucketRes `json:"joiners_by_month"`
+}
+
+func NewOrganizationHeadcountRes(headcount entities.OrganizationHeadcount) OrganizationHeadcountRes {
+	return OrganizationHeadcountRes{
+		OrganizationUUID: headcount.OrganizationUUID,
+		Name:             headcount.Name,
+		Depth:            headcount.Depth,
+		Direct:           headcount.Direct,
+		Total:            headcount.Total,
+	}
+}
+
+func NewListOrganizationHeadcountRes(headcounts []entities.OrganizationHeadcount) []OrganizationHeadcountRes {
+	res := make([]OrganizationHeadcountRes, 0, len(headcounts))
+	for _, headcount := range headcounts {
+		res = append(res, NewOrganizationHeadcountRes(headcount))
+	}
+
+	return res
+}
+
+func newListOrganizationStatsBucketRes(buckets []entities.OrganizationStatsBucket) []OrganizationStatsBucketRes {
+	res := make([]OrganizationStatsBucketRes, 0, len(buckets))
+	for _, bucket := range buckets {
+		res = append(res, OrganizationStatsBucketRes{
+			Key:    bucket.Key,
+			Label:  bucket.Label,
+			Direct: bucket.Direct,
+			Total:  bucket.Total,
+		})
+	}
+
+	return res
+}
+
+func NewOrganizationStatsRes(stats *entities.OrganizationStats) OrganizationStatsRes {
+	return OrganizationStatsRes{
+		OrganizationHeadcountRes: NewOrganizationHeadcountRes(stats.Headcount),
+		SubOrganizations:         NewListOrganizationHeadcountRes(stats.SubOrganizations),
+		ByStatus:                 newListOrganizationStatsBucketRes(stats.ByStatus),
+		ByDirectRole:             newListOrganizationStatsBucketRes(stats.ByDirectRole),
+		JoinersByMonth:           newListOrganizationStatsBucketRes(stats.JoinersByMonth),
+	}
+}
@@ -0,0 +1,94 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/pkg/nullable"
+
+	"github.com/invopop/validation"
+	"github.com/invopop/validation/is"
+)
+
+// MaxOrganizationTreeDepth bounds max_depth, a max_depth of 0 returns the whole subtree
+const MaxOrganizationTreeDepth = 50
+
+// OrganizationTreeReq navigates the hierarchy around one organization. MaxDepth only applies to
+// descendants, IncludeSelf only to ancestors where it turns the list into a breadcrumb. AsOf shows
+// the hierarchy as it was or will be on that date.
+type OrganizationTreeReq struct {
+	OrganizationUUID string `params:"organizationUUID"`
+	MaxDepth         int    `query:"max_depth"`
+	IncludeSelf      bool   `query:"include_self"`
+	IncludeUserCount bool   `query:"include_user_count"`
+	AsOf             string `query:"as_of"`
+}
+
+func (r OrganizationTreeReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.OrganizationUUID, validation.Required, is.UUID),
+		validation.Field(&r.MaxDepth, validation.Min(0), validation.Max(MaxOrganizationTreeDepth)),
+		validation.Field(&r.AsOf, validation.Date(entities.OrganizationVersionDateLayout)),
+	)
+}
+
+func (r OrganizationTreeReq) AsOfDate() nullable.NullTime {
+	return parseAsOf(r.AsOf)
+}
+
+type OrganizationNodeRes struct {
+	UUID       string              `json:"id"`
+	Name       nullable.NullString `json:"name"`
+	Code       nullable.NullString `json:"code"`
+	Type       nullable.NullString `json:"type"`
+	ParentUUID nullable.NullString `json:"parent_id"`
+	Level      nullable.NullInt32  `json:"level"`
+	Path       nullable.NullString `json:"path"`
+	IsActive   bool                `json:"is_active"`
+	UserCount  nullable.NullInt64  `json:"user_count"`
+	CreatedAt  time.Time           `json:"created_at"`
+	CreatedBy  string              `json:"created_by"`
+}
+
+type OrganizationTreeRes struct {
+	OrganizationNodeRes
+	Organizations []OrganizationTreeRes `json:"organizations"`
+}
+
+func NewOrganizationNodeRes(organization *entities.Organization) OrganizationNodeRes {
+	return OrganizationNodeRes{
+		UUID:       organization.UUID,
+		Name:       organization.Name,
+		Code:       organization.Code,
+		Type:       organization.Type,
+		ParentUUID: organization.ParentUUID,
+		Level:      organization.Level,
+		Path:       organization.Path,
+		IsActive:   organization.IsActive,
+		UserCount:  organization.UserCount,
+		CreatedAt:  organization.CreatedAt,
+		CreatedBy:  organization.CreatedBy,
+	}
+}
+
+func NewListOrganizationNodeRes(organizations []*entities.Organization) []OrganizationNodeRes {
+	res := make([]OrganizationNodeRes, 0, len(organizations))
+	for _, organization := range organizations {
+		res = append(res, NewOrganizationNodeRes(organization))
+	}
+
+	return res
+}
+
+func NewOrganizationTreeRes(organization *entities.Organization) OrganizationTreeRes {
+	res := OrganizationTreeRes{
+		OrganizationNodeRes: NewOrganizationNodeRes(organization),
+		Organizations:       make([]OrganizationTreeRes, 0, len(organization.Children)),
+	}
+
+	for _, child := range organization.Children {
+		res.Organizations = append(res.Organizations, NewOrganizationTreeRes(child))
+	}
+
+	return res
+}
@@ -0,0 +1,155 @@
+package dtos
+
+import (
+	"errors"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/pkg/nullable"
+
+	"github.com/invopop/validation"
+	"github.com/invopop/validation/is"
+)
+
+var organizationRequirableFields = func() []interface{} {
+	fields := make([]interface{}, 0, len(entities.OrganizationRequirableFields))
+	for _, field := range entities.OrganizationRequirableFields {
+		fields = append(fields, field)
+	}
+	return fields
+}()
+
+func validMaxDepth(value interface{}) error {
+	maxDepth, ok := value.(nullable.NullInt32)
+	if !ok || maxDepth.Val == nil {
+		return nil
+	}
+
+	if *maxDepth.Val < 0 {
+		return errors.New("must be no less than 0")
+	}
+
+	return nil
+}
+
+type ListOrganizationTypeReq struct {
+	ParentUUID string `query:"parent_id"`
+}
+
+func (r ListOrganizationTypeReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.ParentUUID, is.UUID),
+	)
+}
+
+type CreateOrganizationTypeReq struct {
+	Code               nullable.NullString `json:"code"`
+	Label              nullable.NullString `json:"label"`
+	IsRootAllowed      bool                `json:"is_root_allowed"`
+	AllowedParentTypes []string            `json:"allowed_parent_types"`
+	MaxDepth           nullable.NullInt32  `json:"max_depth"`
+	RequiredFields     []string            `json:"required_fields"`
+}
+
+func (r CreateOrganizationTypeReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Code, validation.Required, validation.Length(1, 100)),
+		validation.Field(&r.Label, validation.Required, validation.Length(1, 255)),
+		validation.Field(&r.AllowedParentTypes, validation.Each(validation.Required, validation.Length(1, 100))),
+		validation.Field(&r.MaxDepth, validation.By(validMaxDepth)),
+		validation.Field(&r.RequiredFields, validation.Each(validation.In(organizationRequirableFields...))),
+	)
+}
+
+func (r CreateOrganizationTypeReq) NewOrganizationType(cred entities.AuthenticatedUser) entities.OrganizationType {
+	organizationType := entities.OrganizationType{
+		Code:               r.Code,
+		Label:              r.Label,
+		IsRootAllowed:      r.IsRootAllowed,
+		AllowedParentTypes: r.AllowedParentTypes,
+		MaxDepth:           r.MaxDepth,
+		RequiredFields:     r.RequiredFields,
+	}
+
+	if organizationType.AllowedParentTypes == nil {
+		organizationType.AllowedParentTypes = []string{}
+	}
+	if organizationType.RequiredFields == nil {
+		organizationType.RequiredFields = []string{}
+	}
+
+	organizationType.BaseModel = entities.NewBaseModel(cred.Username)
+
+	return organizationType
+}
+
+// UpdateOrganizationTypeReq does not allow changing code, organizations and other types refer to it.
+// A null max_depth removes the depth limit.
+type UpdateOrganizationTypeReq struct {
+	UUID               string              `params:"organizationTypeUUID"`
+	Label              nullable.NullString `json:"label"`
+	IsRootAllowed      *bool               `json:"is_root_allowed"`
+	AllowedParentTypes []string            `json:"allowed_parent_types"`
+	MaxDepth           nullable.NullInt32  `json:"max_depth"`
+	RequiredFields     []string            `json:"required_fields"`
+}
+
+func (r UpdateOrganizationTypeReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.UUID, validation.Required, is.UUID),
+		validation.Field(&r.Label, validation.Length(1, 255)),
+		validation.Field(&r.AllowedParentTypes, validation.Each(validation.Required, validation.Length(1, 100))),
+		validation.Field(&r.MaxDepth, validation.By(validMaxDepth)),
+		validation.Field(&r.RequiredFields, validation.Each(validation.In(organizationRequirableFields...))),
+	)
+}
+
+// Apply copies the provided fields onto the stored organization type
+func (r UpdateOrganizationTypeReq) Apply(organizationType *entities.OrganizationType, cred entities.AuthenticatedUser) {
+	if r.Label.IsNotEmpty() {
+		organizationType.Label = r.Label
+	}
+	if r.IsRootAllowed != nil {
+		organizationType.IsRootAllowed = *r.IsRootAllowed
+	}
+	if r.AllowedParentTypes != nil {
+		organizationType.AllowedParentTypes = r.AllowedParentTypes
+	}
+	if r.MaxDepth.IsExists {
+		organizationType.MaxDepth = r.MaxDepth
+	}
+	if r.RequiredFields != nil {
+		organizationType.RequiredFields = r.RequiredFields
+	}
+
+	organizationType.UpdateModel(cred.Username)
+}
+
+type OrganizationTypeRes struct {
+	UUID               string              `json:"id"`
+	Code               nullable.NullString `json:"code"`
+	Label              nullable.NullString `json:"label"`
+	IsRootAllowed      bool                `json:"is_root_allowed"`
+	AllowedParentTypes []string            `json:"allowed_parent_types"`
+	MaxDepth           nullable.NullInt32  `json:"max_depth"`
+	RequiredFields     []string            `json:"required_fields"`
+}
+
+func NewOrganizationTypeRes(organizationType entities.OrganizationType) OrganizationTypeRes {
+	return OrganizationTypeRes{
+		UUID:               organizationType.UUID,
+		Code:               organizationType.Code,
+		Label:              organizationType.Label,
+		IsRootAllowed:      organizationType.IsRootAllowed,
+		AllowedParentTypes: organizationType.AllowedParentTypes,
+		MaxDepth:           organizationType.MaxDepth,
+		RequiredFields:     organizationType.RequiredFields,
+	}
+}
+
+func NewListOrganizationTypeRes(organizationTypes []*entities.OrganizationType) []OrganizationTypeRes {
+	res := make([]OrganizationTypeRes, 0, len(organizationTypes))
+	for _, organizationType := range organizationTypes {
+		res = append(res, NewOrganizationTypeRes(*organizationType))
+	}
+	return res
+}
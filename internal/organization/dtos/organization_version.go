@@ -0,0 +1,142 @@
+package dtos
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/laksanagusta/identity/constants"
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/pkg/nullable"
+
+	"github.com/invopop/validation"
+	"github.com/invopop/validation/is"
+)
+
+// parseAsOf converts a validated as_of parameter, a date in entities.OrganizationVersionDateLayout.
+// An empty value means today.
+func parseAsOf(value string) nullable.NullTime {
+	if value == "" {
+		return nullable.NullTime{}
+	}
+
+	date, _ := time.Parse(entities.OrganizationVersionDateLayout, value)
+	return nullable.NewTime(date)
+}
+
+// ScheduleOrganizationVersionReq changes attributes of an organization from ValidFrom on, a change
+// for today takes effect right away. A null parent_id makes the organization a root.
+type ScheduleOrganizationVersionReq struct {
+	OrganizationUUID string              `params:"organizationUUID"`
+	ValidFrom        string              `json:"valid_from"`
+	Name             nullable.NullString `json:"name"`
+	ParentId         nullable.NullString `json:"parent_id"`
+	Type             nullable.NullString `json:"type"`
+	IsActive         *bool               `json:"is_active"`
+}
+
+func (r ScheduleOrganizationVersionReq) Validate() error {
+	err := validation.ValidateStruct(&r,
+		validation.Field(&r.OrganizationUUID, validation.Required, is.UUID),
+		validation.Field(&r.ValidFrom, validation.Required, validation.Date(entities.OrganizationVersionDateLayout)),
+		validation.Field(&r.Name, validation.When(r.Name.IsExists, validation.NotNil), validation.Length(1, 255)),
+		validation.Field(&r.ParentId, is.UUID),
+		validation.Field(&r.Type, validation.When(r.Type.IsExists, validation.NotNil), validation.Length(1, 100)),
+	)
+	if err != nil {
+		return err
+	}
+
+	if !r.Name.IsExists && !r.ParentId.IsExists && !r.Type.IsExists && r.IsActive == nil {
+		return validation.Errors{"valid_from": errors.New(constants.ErrMsgOrganizationVersionEmpty)}
+	}
+
+	return nil
+}
+
+func (r ScheduleOrganizationVersionReq) ValidFromDate() time.Time {
+	date, _ := time.Parse(entities.OrganizationVersionDateLayout, r.ValidFrom)
+	return date
+}
+
+// NewOrganizationVersions returns a version for every attribute in the request
+func (r ScheduleOrganizationVersionReq) NewOrganizationVersions(cred entities.AuthenticatedUser) entities.OrganizationVersions {
+	values := map[string]nullable.NullString{}
+	if r.Name.IsExists {
+		values[entities.OrganizationAttributeName] = r.Name
+	}
+	if r.ParentId.IsExists {
+		values[entities.OrganizationAttributeParent] = r.ParentId
+	}
+	if r.Type.IsExists {
+		values[entities.OrganizationAttributeType] = r.Type
+	}
+	if r.IsActive != nil {
+		values[entities.OrganizationAttributeIsActive] = nullable.NewString(strconv.FormatBool(*r.IsActive))
+	}
+
+	versions := entities.OrganizationVersions{}
+	for _, attribute := range entities.OrganizationVersionedAttributes {
+		value, ok := values[attribute]
+		if !ok {
+			continue
+		}
+
+		versions = append(versions, &entities.OrganizationVersion{
+			BaseModel:        entities.NewBaseModel(cred.Username),
+			OrganizationUUID: r.OrganizationUUID,
+			Attribute:        attribute,
+			Value:            value,
+			ValidFrom:        r.ValidFromDate(),
+		})
+	}
+
+	return versions
+}
+
+type OrganizationVersionReq struct {
+	OrganizationUUID string `params:"organizationUUID"`
+	VersionUUID      string `params:"versionUUID"`
+}
+
+func (r OrganizationVersionReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.OrganizationUUID, validation.Required, is.UUID),
+		validation.Field(&r.VersionUUID, validation.Required, is.UUID),
+	)
+}
+
+type OrganizationVersionRes struct {
+	UUID      string              `json:"id"`
+	Attribute string              `json:"attribute"`
+	Value     nullable.NullString `json:"value"`
+	ValidFrom string              `json:"valid_from"`
+	ValidTo   *string             `json:"valid_to"`
+	IsApplied bool                `json:"is_applied"`
+	CreatedAt time.Time           `json:"created_at"`
+	CreatedBy string              `json:"created_by"`
+}
+
+func NewListOrganizationVersionRes(versions entities.OrganizationVersions) []OrganizationVersionRes {
+	res := make([]OrganizationVersionRes, 0, len(versions))
+	for _, version := range versions {
+		versionRes := OrganizationVersionRes{
+			UUID:      version.UUID,
+			Attribute: version.Attribute,
+			Value:     version.Value,
+			ValidFrom: version.ValidFrom.Format(entities.OrganizationVersionDateLayout),
+			IsApplied: version.AppliedAt != nil,
+			CreatedAt: version.CreatedAt,
+			CreatedBy: version.CreatedBy,
+		}
+
+		if version.ValidTo != nil {
+			validTo := version.ValidTo.Format(entities.OrganizationVersionDateLayout)
+			versionRes.ValidTo = &validTo
+		}
+
+		res = append(res, versionRes)
+	}
+
+	return res
+}
@@ -0,0 +1,35 @@
+package public
+
+import (
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+// PublicNearbyOrganizationRes represents an organization found by a geo search in public API response
+type PublicNearbyOrganizationRes struct {
+	UUID           string               `json:"id"`
+	Name           string               `json:"name"`
+	Type           nullable.NullString  `json:"type"`
+	Address        nullable.NullString  `json:"address"`
+	Latitude       nullable.NullFloat64 `json:"latitude"`
+	Longitude      nullable.NullFloat64 `json:"longitude"`
+	DistanceMeters nullable.NullFloat64 `json:"distance_meters"`
+}
+
+// NewListPublicNearbyOrganizationRes creates public responses for geo search results, nearest first
+func NewListPublicNearbyOrganizationRes(organizations []*entities.Organization) []PublicNearbyOrganizationRes {
+	res := make([]PublicNearbyOrganizationRes, 0, len(organizations))
+	for _, organization := range organizations {
+		res = append(res, PublicNearbyOrganizationRes{
+			UUID:           organization.UUID,
+			Name:           organization.Name.GetOrDefault(),
+			Type:           organization.Type,
+			Address:        organization.Address,
+			Latitude:       organization.Latitude,
+			Longitude:      organization.Longitude,
+			DistanceMeters: organization.DistanceMeters,
+		})
+	}
+
+	return res
+}
@@ -17,17 +17,18 @@ type ListOrganizationReq struct {
 	Sort      *string    `query:"sort"`
 	StartTime *time.Time `query:"start_time"`
 	EndTime   *time.Time `query:"end_time"`
+	AsOf      string     `query:"as_of"`
 }
 
 // ListOrganizationRespData represents a single organization in the public API response
 type ListOrganizationRespData struct {
-	UUID      string              `json:"id"`
-	Name      string              `json:"name"`
-	Address   nullable.NullString `json:"address"`
-	Latitude  nullable.NullString `json:"latitude"`
-	Longitude nullable.NullString `json:"longitude"`
-	Type      nullable.NullString `json:"type"`
-	CreatedAt nullable.NullString `json:"created_at"`
+	UUID      string               `json:"id"`
+	Name      string               `json:"name"`
+	Address   nullable.NullString  `json:"address"`
+	Latitude  nullable.NullFloat64 `json:"latitude"`
+	Longitude nullable.NullFloat64 `json:"longitude"`
+	Type      nullable.NullString  `json:"type"`
+	CreatedAt nullable.NullString  `json:"created_at"`
 }
 
 // ListOrganizationRespMetadata represents pagination metadata
@@ -46,13 +47,13 @@ type ListOrganizationResp struct {
 
 // PublicOrganizationRes represents a single organization in public API response
 type PublicOrganizationRes struct {
-	UUID      string              `json:"id"`
-	Name      string              `json:"name"`
-	Address   nullable.NullString `json:"address"`
-	Latitude  nullable.NullString `json:"latitude"`
-	Longitude nullable.NullString `json:"longitude"`
-	Type      nullable.NullString `json:"type"`
-	CreatedAt nullable.NullString `json:"created_at"`
+	UUID      string               `json:"id"`
+	Name      string               `json:"name"`
+	Address   nullable.NullString  `json:"address"`
+	Latitude  nullable.NullFloat64 `json:"latitude"`
+	Longitude nullable.NullFloat64 `json:"longitude"`
+	Type      nullable.NullString  `json:"type"`
+	CreatedAt nullable.NullString  `json:"created_at"`
 }
 
 // Validate validates the list organization request
@@ -64,6 +65,7 @@ func (r ListOrganizationReq) Validate() error {
 		validation.Field(&r.Sort, validation.Length(1, 255)),
 		validation.Field(&r.StartTime, validation.Length(1, 255), validation.Date(time.RFC3339)),
 		validation.Field(&r.EndTime, validation.Length(1, 255), validation.Date(time.RFC3339)),
+		validation.Field(&r.AsOf, validation.Date(entities.OrganizationVersionDateLayout)),
 	)
 
 	return err
@@ -78,6 +80,7 @@ func (r *ListOrganizationReq) ToInternalReq() dtos.ListOrganizationReq {
 		Sort:      r.Sort,
 		StartTime: r.StartTime,
 		EndTime:   r.EndTime,
+		AsOf:      r.AsOf,
 	}
 }
 
@@ -0,0 +1,76 @@
+package public
+
+import (
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/organization/dtos"
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+// OrganizationTreeReq represents the public API request for ancestors, children and descendants.
+// User counts are not offered without authentication.
+type OrganizationTreeReq struct {
+	OrganizationUUID string `params:"id"`
+	MaxDepth         int    `query:"max_depth"`
+	IncludeSelf      bool   `query:"include_self"`
+	AsOf             string `query:"as_of"`
+}
+
+// ToInternalReq converts public request to internal request
+func (r *OrganizationTreeReq) ToInternalReq() dtos.OrganizationTreeReq {
+	return dtos.OrganizationTreeReq{
+		OrganizationUUID: r.OrganizationUUID,
+		MaxDepth:         r.MaxDepth,
+		IncludeSelf:      r.IncludeSelf,
+		AsOf:             r.AsOf,
+	}
+}
+
+// PublicOrganizationNodeRes represents a single organization of the hierarchy in public API response
+type PublicOrganizationNodeRes struct {
+	UUID       string              `json:"id"`
+	Name       string              `json:"name"`
+	Type       nullable.NullString `json:"type"`
+	ParentUUID nullable.NullString `json:"parent_id"`
+	Level      nullable.NullInt32  `json:"level"`
+}
+
+// PublicOrganizationTreeRes represents an organization with its nested sub organizations
+type PublicOrganizationTreeRes struct {
+	PublicOrganizationNodeRes
+	Organizations []PublicOrganizationTreeRes `json:"organizations"`
+}
+
+// NewPublicOrganizationNodeRes creates a new public organization node response
+func NewPublicOrganizationNodeRes(organization *entities.Organization) PublicOrganizationNodeRes {
+	return PublicOrganizationNodeRes{
+		UUID:       organization.UUID,
+		Name:       organization.Name.GetOrDefault(),
+		Type:       organization.Type,
+		ParentUUID: organization.ParentUUID,
+		Level:      organization.Level,
+	}
+}
+
+// NewListPublicOrganizationNodeRes creates public responses for a flat list of organizations
+func NewListPublicOrganizationNodeRes(organizations []*entities.Organization) []PublicOrganizationNodeRes {
+	res := make([]PublicOrganizationNodeRes, 0, len(organizations))
+	for _, organization := range organizations {
+		res = append(res, NewPublicOrganizationNodeRes(organization))
+	}
+
+	return res
+}
+
+// NewPublicOrganizationTreeRes creates a public response for an organization and its loaded descendants
+func NewPublicOrganizationTreeRes(organization *entities.Organization) PublicOrganizationTreeRes {
+	res := PublicOrganizationTreeRes{
+		PublicOrganizationNodeRes: NewPublicOrganizationNodeRes(organization),
+		Organizations:             make([]PublicOrganizationTreeRes, 0, len(organization.Children)),
+	}
+
+	for _, child := range organization.Children {
+		res.Organizations = append(res.Organizations, NewPublicOrganizationTreeRes(child))
+	}
+
+	return res
+}
@@ -0,0 +1,91 @@
+package dtos
+
+import (
+	"errors"
+
+	"github.com/laksanagusta/identity/constants"
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/pkg/nullable"
+
+	"github.com/invopop/validation"
+	"github.com/invopop/validation/is"
+)
+
+// MergeOrganizationReq moves the users, sub organizations and organization scoped groups of an
+// organization to target_id and archives it
+type MergeOrganizationReq struct {
+	OrganizationUUID string `params:"organizationUUID"`
+	TargetUUID       string `json:"target_id"`
+	DryRun           bool   `json:"dry_run"`
+}
+
+func (r MergeOrganizationReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.OrganizationUUID, validation.Required, is.UUID),
+		validation.Field(&r.TargetUUID, validation.Required, is.UUID),
+	)
+}
+
+// SplitOrganizationReq moves user_ids and the sub organizations child_ids of an organization into a
+// new sibling. The sibling takes the type of the organization when type is empty.
+type SplitOrganizationReq struct {
+	OrganizationUUID string              `params:"organizationUUID"`
+	Name             nullable.NullString `json:"name"`
+	Address          nullable.NullString `json:"address"`
+	Type             nullable.NullString `json:"type"`
+	UserUUIDs        []string            `json:"user_ids"`
+	ChildUUIDs       []string            `json:"child_ids"`
+	DryRun           bool                `json:"dry_run"`
+}
+
+func (r SplitOrganizationReq) Validate() error {
+	err := validation.ValidateStruct(&r,
+		validation.Field(&r.OrganizationUUID, validation.Required, is.UUID),
+		validation.Field(&r.Name, validation.Required, validation.Length(1, 255)),
+		validation.Field(&r.Address, validation.Length(1, 255)),
+		validation.Field(&r.Type, validation.Length(1, 100)),
+		validation.Field(&r.UserUUIDs, validation.Each(validation.Required, is.UUID)),
+		validation.Field(&r.ChildUUIDs, validation.Each(validation.Required, is.UUID)),
+	)
+	if err != nil {
+		return err
+	}
+
+	if len(r.UserUUIDs) == 0 && len(r.ChildUUIDs) == 0 {
+		return validation.Errors{"user_ids": errors.New(constants.ErrMsgOrganizationSplitEmpty)}
+	}
+
+	return nil
+}
+
+// NewOrganization returns the new sibling, placed below parentUUID
+func (r SplitOrganizationReq) NewOrganization(cred entities.AuthenticatedUser, parentUUID nullable.NullString) entities.Organization {
+	createReq := CreateNewOrganizationReq{
+		Name:     r.Name,
+		Address:  r.Address,
+		Type:     r.Type,
+		ParentId: parentUUID,
+	}
+
+	return createReq.NewOrganization(cred)
+}
+
+type OrganizationRestructureRes struct {
+	DryRun     bool                        `json:"dry_run"`
+	SourceUUID string                      `json:"source_id"`
+	TargetUUID string                      `json:"target_id"`
+	UserUUIDs  []string                    `json:"user_ids"`
+	GroupUUIDs []string                    `json:"group_ids"`
+	Affected   []OrganizationPathChangeRes `json:"affected"`
+}
+
+func NewOrganizationRestructureRes(dryRun bool, restructure *entities.OrganizationRestructure) OrganizationRestructureRes {
+	return OrganizationRestructureRes{
+		DryRun:     dryRun,
+		SourceUUID: restructure.SourceUUID,
+		TargetUUID: restructure.TargetUUID,
+		UserUUIDs:  restructure.UserUUIDs,
+		GroupUUIDs: restructure.GroupUUIDs,
+		Affected:   newListOrganizationPathChangeRes(restructure.Children),
+	}
+}
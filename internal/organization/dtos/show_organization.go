@@ -5,15 +5,34 @@ import (
 
 	"github.com/laksanagusta/identity/internal/entities"
 	"github.com/laksanagusta/identity/pkg/nullable"
+
+	"github.com/invopop/validation"
 )
 
+// ShowOrganizationReq loads an organization with its sub organizations, as of AsOf when given
+type ShowOrganizationReq struct {
+	OrganizationUUID string `params:"organizationUUID"`
+	AsOf             string `query:"as_of"`
+}
+
+func (r ShowOrganizationReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.OrganizationUUID, validation.Required),
+		validation.Field(&r.AsOf, validation.Date(entities.OrganizationVersionDateLayout)),
+	)
+}
+
+func (r ShowOrganizationReq) AsOfDate() nullable.NullTime {
+	return parseAsOf(r.AsOf)
+}
+
 type ShowOrganizationRes struct {
 	UUID      string                 `json:"id"`
 	Name      nullable.NullString    `json:"name"`
 	Code      nullable.NullString    `json:"code"`
 	Address   nullable.NullString    `json:"address"`
-	Latitude  nullable.NullString    `json:"latitude"`
-	Longitude nullable.NullString    `json:"longitude"`
+	Latitude  nullable.NullFloat64   `json:"latitude"`
+	Longitude nullable.NullFloat64   `json:"longitude"`
 	Type      nullable.NullString    `json:"type"`
 	Parent    *ParentOrganizationRes `json:"parent,omitempty"`
 
@@ -25,13 +44,13 @@ type ShowOrganizationRes struct {
 
 // ParentOrganizationRes - simplified parent without children to avoid circular reference
 type ParentOrganizationRes struct {
-	UUID      string              `json:"id"`
-	Name      nullable.NullString `json:"name"`
-	Code      nullable.NullString `json:"code"`
-	Address   nullable.NullString `json:"address"`
-	Latitude  nullable.NullString `json:"latitude"`
-	Longitude nullable.NullString `json:"longitude"`
-	Type      nullable.NullString `json:"type"`
+	UUID      string               `json:"id"`
+	Name      nullable.NullString  `json:"name"`
+	Code      nullable.NullString  `json:"code"`
+	Address   nullable.NullString  `json:"address"`
+	Latitude  nullable.NullFloat64 `json:"latitude"`
+	Longitude nullable.NullFloat64 `json:"longitude"`
+	Type      nullable.NullString  `json:"type"`
 }
 
 func NewShowOrganizationRes(organization *entities.Organization) ShowOrganizationRes {
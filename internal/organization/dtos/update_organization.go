@@ -9,19 +9,21 @@ import (
 )
 
 type UpdateOrganizationReq struct {
-	OrganizationUUID string              `params:"organizationUUID"`
-	Name             nullable.NullString `json:"name"`
-	Address          nullable.NullString `json:"address"`
-	Latitude         nullable.NullString `json:"latitude"`
-	Longitude        nullable.NullString `json:"longitude"`
+	OrganizationUUID string               `params:"organizationUUID"`
+	Name             nullable.NullString  `json:"name"`
+	Address          nullable.NullString  `json:"address"`
+	Latitude         nullable.NullFloat64 `json:"latitude"`
+	Longitude        nullable.NullFloat64 `json:"longitude"`
+	Type             nullable.NullString  `json:"type"`
 }
 
 func (r UpdateOrganizationReq) Validate() error {
 	return validation.ValidateStruct(&r,
 		validation.Field(&r.Name, validation.Length(1, 255)),
 		validation.Field(&r.Address, validation.Length(1, 255)),
-		validation.Field(&r.Latitude, is.Latitude),
-		validation.Field(&r.Longitude, is.Longitude),
+		validation.Field(&r.Latitude, coordinateRules(latitudeRules, r.Longitude)...),
+		validation.Field(&r.Longitude, coordinateRules(longitudeRules, r.Latitude)...),
+		validation.Field(&r.Type, validation.Length(1, 100)),
 		validation.Field(&r.OrganizationUUID, validation.Required, is.UUIDv4),
 	)
 }
@@ -34,6 +36,11 @@ func (r UpdateOrganizationReq) NewUpdateOrganization(cred entities.Authenticated
 		Longitude: r.Longitude,
 	}
 
+	// The type can be changed but never cleared
+	if r.Type.IsNotEmpty() {
+		organization.Type = r.Type
+	}
+
 	organization.UUID = r.OrganizationUUID
 	organization.UpdateModel(cred.Username)
 
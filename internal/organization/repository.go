@@ -2,9 +2,12 @@ package organization
 
 import (
 	"context"
+	"iter"
+	"time"
 
 	"github.com/laksanagusta/identity/internal/entities"
 	"github.com/laksanagusta/identity/pkg/database"
+	"github.com/laksanagusta/identity/pkg/nullable"
 )
 
 type Repository interface {
@@ -14,6 +17,64 @@ type Repository interface {
 	FindOrganizationByUUID(ctx context.Context, uuid string) (*entities.Organization, error)
 	Update(ctx context.Context, organization entities.Organization) error
 	IndexOrganization(ctx context.Context, params entities.ListOrganizationParams) ([]entities.Organization, *entities.Metadata, error)
+	StreamOrganizations(ctx context.Context, params entities.ListOrganizationParams) (iter.Seq2[*entities.Organization, error], error)
 	Delete(ctx context.Context, uuid string, username string) error
 	FindOrganizationByUUIDs(ctx context.Context, uuids []string) ([]*entities.Organization, error)
+	FindOrganizationNodeByUUID(ctx context.Context, uuid string) (*entities.Organization, error)
+	FindSubtree(ctx context.Context, uuid string) ([]*entities.Organization, error)
+	FindAncestors(ctx context.Context, uuid string) ([]*entities.Organization, error)
+	FindDescendants(ctx context.Context, uuid string, maxDepth nullable.NullInt32) ([]*entities.Organization, error)
+	FindNearby(ctx context.Context, params entities.GeoSearchParams) ([]*entities.Organization, error)
+	CountUsersByOrganizationUUIDs(ctx context.Context, uuids []string) (map[string]int64, error)
+	FindHeadcounts(ctx context.Context, uuid string, maxDepth int32) ([]entities.OrganizationHeadcount, error)
+	CountMembersByStatus(ctx context.Context, uuid string) ([]entities.OrganizationStatsBucket, error)
+	CountMembersByDirectRole(ctx context.Context, uuid string) ([]entities.OrganizationStatsBucket, error)
+	CountJoinersByMonth(ctx context.Context, uuid string, since time.Time) ([]entities.OrganizationStatsBucket, error)
+	LockTree(ctx context.Context) error
+	UpdateParent(ctx context.Context, organization entities.Organization) error
+	MoveSubtree(ctx context.Context, organization entities.Organization, oldPath string) error
+	FindOrganizationHierarchy(ctx context.Context) ([]*entities.Organization, error)
+	FindOrganizationClosure(ctx context.Context) ([]entities.OrganizationClosure, error)
+	RepairOrganizationPath(ctx context.Context, organization entities.Organization) error
+	ReplaceOrganizationClosure(ctx context.Context, organizationUUID string, rows []entities.OrganizationClosure) error
+
+	InsertOrganizationVersions(ctx context.Context, versions entities.OrganizationVersions) error
+	UpdateOrganizationVersion(ctx context.Context, version entities.OrganizationVersion) error
+	DeleteOrganizationVersion(ctx context.Context, uuid string) error
+	FindOrganizationVersions(ctx context.Context, organizationUUID string) (entities.OrganizationVersions, error)
+	FindOrganizationVersionByUUID(ctx context.Context, uuid string) (*entities.OrganizationVersion, error)
+	FindDueOrganizationVersions(ctx context.Context, day time.Time) (entities.OrganizationVersions, error)
+	LockDueOrganizationVersion(ctx context.Context, uuid string) (*entities.OrganizationVersion, error)
+	MarkOrganizationVersionApplied(ctx context.Context, uuid string, appliedAt time.Time) error
+	MarkOrganizationVersionFailed(ctx context.Context, uuid string, failedAt time.Time, reason string) error
+	UpdateAttributes(ctx context.Context, organization entities.Organization) error
+	FindOrganizationsAsOf(ctx context.Context, asOf time.Time) ([]*entities.Organization, error)
+
+	FindOrganizationsByCodes(ctx context.Context, codes []string) ([]*entities.Organization, error)
+	FindOrganizations(ctx context.Context) ([]*entities.Organization, error)
+
+	FindMemberUUIDs(ctx context.Context, uuid string) ([]string, error)
+	ReassignUsers(ctx context.Context, fromUUID string, toUUID string, userUUIDs []string, username string, at time.Time) error
+	FindGroupsByOrganizationFilter(ctx context.Context, uuid string) ([]*entities.Group, error)
+	UpdateGroupFilters(ctx context.Context, group entities.Group) error
+	InsertAuditLog(ctx context.Context, auditLog entities.AuditLog) error
+
+	FindOrganizationSettingDefinitions(ctx context.Context) (entities.OrganizationSettingDefinitions, error)
+	FindOrganizationSettingDefinitionByUUID(ctx context.Context, uuid string) (*entities.OrganizationSettingDefinition, error)
+	FindOrganizationSettingDefinitionByKey(ctx context.Context, key string) (*entities.OrganizationSettingDefinition, error)
+	InsertOrganizationSettingDefinition(ctx context.Context, definition entities.OrganizationSettingDefinition) (string, error)
+	UpdateOrganizationSettingDefinition(ctx context.Context, definition entities.OrganizationSettingDefinition) error
+	DeleteOrganizationSettingDefinition(ctx context.Context, uuid string) error
+	IsOrganizationSettingOutsideEnum(ctx context.Context, key string, enumValues []string) (bool, error)
+	FindInheritedSettings(ctx context.Context, organizationUUID string) (entities.OrganizationSettings, error)
+	UpsertOrganizationSetting(ctx context.Context, setting entities.OrganizationSetting) error
+	DeleteOrganizationSetting(ctx context.Context, organizationUUID string, key string) error
+
+	FindOrganizationTypes(ctx context.Context) ([]*entities.OrganizationType, error)
+	FindOrganizationTypeByUUID(ctx context.Context, uuid string) (*entities.OrganizationType, error)
+	FindOrganizationTypeByCode(ctx context.Context, code string) (*entities.OrganizationType, error)
+	InsertOrganizationType(ctx context.Context, organizationType entities.OrganizationType) (string, error)
+	UpdateOrganizationType(ctx context.Context, organizationType entities.OrganizationType) error
+	DeleteOrganizationType(ctx context.Context, organizationType entities.OrganizationType) error
+	IsOrganizationTypeInUse(ctx context.Context, code string) (bool, error)
 }
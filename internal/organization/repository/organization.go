@@ -14,6 +14,8 @@ import (
 	"github.com/laksanagusta/identity/internal/entities"
 	"github.com/laksanagusta/identity/internal/organization"
 	"github.com/laksanagusta/identity/pkg/database"
+	"github.com/laksanagusta/identity/pkg/nullable"
+	"github.com/lib/pq"
 )
 
 func NewOrganizationRepo(db database.Queryer) organization.Repository {
@@ -41,6 +43,7 @@ func (r *organizationRepo) Insert(ctx context.Context, organization entities.Org
 		organization.Longitude,
 		organization.Type,
 		organization.Path,
+		organization.Level,
 		organization.ParentUUID,
 		time.Now(),
 		organization.CreatedBy,
@@ -54,6 +57,11 @@ func (r *organizationRepo) Insert(ctx context.Context, organization entities.Org
 		return "", err
 	}
 
+	_, err = r.db.ExecContext(ctx, insertOrganizationClosure, organization.UUID, organization.ParentUUID)
+	if err != nil {
+		return "", err
+	}
+
 	return returnedUUID, nil
 }
 
@@ -145,7 +153,7 @@ func (r *organizationRepo) FindOrganizationByUUID(ctx context.Context, rootUUID
 
 		nodes[node.UUID] = node
 
-		// Set root
+		// Set root, rows come ordered by depth so parents are seen before their children
 		if node.UUID == rootUUID {
 			root = node
 		}
@@ -215,9 +223,202 @@ func (r *organizationRepo) findSingleOrganizationByUUID(ctx context.Context, uui
 	return &org, nil
 }
 
-func (r *organizationRepo) IndexOrganization(ctx context.Context, params entities.ListOrganizationParams) ([]entities.Organization, *entities.Metadata, error) {
+// FindOrganizationNodeByUUID returns a single organization without its subtree
+func (r *organizationRepo) FindOrganizationNodeByUUID(ctx context.Context, uuid string) (*entities.Organization, error) {
+	organization, err := r.findSingleOrganizationByUUID(ctx, uuid)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return organization, nil
+}
+
+// FindSubtree returns the organization and its descendants as a flat list, parents before children
+func (r *organizationRepo) FindSubtree(ctx context.Context, uuid string) ([]*entities.Organization, error) {
+	rows, err := r.db.QueryxContext(ctx, findOrganizationSubtree, uuid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	organizations := []*entities.Organization{}
+	for rows.Next() {
+		var organization entities.Organization
+		err := rows.Scan(
+			&organization.UUID,
+			&organization.Name,
+			&organization.Type,
+			&organization.Path,
+			&organization.Level,
+		)
+		if err != nil {
+			return nil, err
+		}
+		organizations = append(organizations, &organization)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return organizations, nil
+}
+
+// FindAncestors returns the organizations above uuid, root first
+func (r *organizationRepo) FindAncestors(ctx context.Context, uuid string) ([]*entities.Organization, error) {
+	return r.findOrganizationNodes(ctx, findOrganizationAncestors, uuid)
+}
+
+// FindDescendants returns the organizations below uuid down to maxDepth levels as a flat list,
+// parents before children
+func (r *organizationRepo) FindDescendants(ctx context.Context, uuid string, maxDepth nullable.NullInt32) ([]*entities.Organization, error) {
+	return r.findOrganizationNodes(ctx, findOrganizationDescendants, uuid, maxDepth)
+}
+
+func (r *organizationRepo) findOrganizationNodes(ctx context.Context, query string, args ...interface{}) ([]*entities.Organization, error) {
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	organizations := []*entities.Organization{}
+	for rows.Next() {
+		var organization entities.Organization
+		err := rows.StructScan(&organization)
+		if err != nil {
+			return nil, err
+		}
+		organizations = append(organizations, &organization)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return organizations, nil
+}
+
+// FindNearby returns the active organizations with coordinates closest to params.Origin, nearest first
+func (r *organizationRepo) FindNearby(ctx context.Context, params entities.GeoSearchParams) ([]*entities.Organization, error) {
+	args := []interface{}{params.Origin.Latitude, params.Origin.Longitude, params.RadiusMeters, params.Limit}
+
+	boxCondition := ""
+	if params.Box != nil {
+		args = append(args, params.Box.MinLatitude, params.Box.MaxLatitude, params.Box.MinLongitude, params.Box.MaxLongitude)
+
+		// A box crossing the antimeridian covers both ends of the longitude range
+		longitudeCondition := "o.longitude BETWEEN $7 AND $8"
+		if params.Box.CrossesAntimeridian() {
+			longitudeCondition = "(o.longitude >= $7 OR o.longitude <= $8)"
+		}
+		boxCondition = "AND o.latitude BETWEEN $5 AND $6 AND " + longitudeCondition
+	}
+
+	return r.findOrganizationNodes(ctx, fmt.Sprintf(findNearbyOrganizations, boxCondition), args...)
+}
+
+// CountUsersByOrganizationUUIDs returns the number of active users directly in each organization
+func (r *organizationRepo) CountUsersByOrganizationUUIDs(ctx context.Context, uuids []string) (map[string]int64, error) {
+	counts := make(map[string]int64, len(uuids))
+	if len(uuids) == 0 {
+		return counts, nil
+	}
+
+	rows, err := r.db.QueryxContext(ctx, countUsersByOrganizationUUIDs, pq.Array(uuids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var organizationUUID string
+		var count int64
+		err := rows.Scan(&organizationUUID, &count)
+		if err != nil {
+			return nil, err
+		}
+		counts[organizationUUID] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+func (r *organizationRepo) LockTree(ctx context.Context) error {
+	_, err := r.db.ExecContext(ctx, lockOrganizationTree)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *organizationRepo) UpdateParent(ctx context.Context, organization entities.Organization) error {
+	_, err := r.db.ExecContext(ctx,
+		updateOrganizationParent,
+		organization.ParentUUID,
+		organization.UpdatedAt,
+		organization.UpdatedBy,
+		organization.UUID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MoveSubtree relinks the subtree of organization below its new parent_uuid and rewrites the path
+// and level of every organization in it from oldPath to the new organization.Path
+func (r *organizationRepo) MoveSubtree(ctx context.Context, organization entities.Organization, oldPath string) error {
+	_, err := r.db.ExecContext(ctx, detachOrganizationSubtree, organization.UUID)
+	if err != nil {
+		return err
+	}
+
+	if organization.ParentUUID.IsNotEmpty() {
+		_, err = r.db.ExecContext(ctx, attachOrganizationSubtree, organization.UUID, organization.ParentUUID)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = r.db.ExecContext(ctx,
+		moveOrganizationSubtree,
+		organization.Path,
+		oldPath,
+		organization.UpdatedAt,
+		organization.UpdatedBy,
+		organization.UUID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// listOrganizationFilters returns the organizations to list from and the conditions of params, shared
+// by IndexOrganization and StreamOrganizations
+func listOrganizationFilters(params entities.ListOrganizationParams) (string, string, []interface{}) {
 	whereClause := []string{}
 	finalArgs := []interface{}{}
+
+	// Lists the organizations as they were or will be on AsOf instead of today
+	source := "organizations"
+	if params.AsOf.IsExists {
+		finalArgs = append(finalArgs, entities.Day(params.AsOf.GetOrDefault()))
+		source = fmt.Sprintf(organizationsAsOf, len(finalArgs))
+	}
+
 	if params.Search.IsExists {
 		whereClause = append(whereClause, fmt.Sprintf("lower(s.name) LIKE $%d", len(finalArgs)+1))
 		finalArgs = append(finalArgs, "%"+strings.ToLower(params.Search.GetOrDefault())+"%")
@@ -240,7 +441,13 @@ func (r *organizationRepo) IndexOrganization(ctx context.Context, params entitie
 		whereStr = fmt.Sprintf("WHERE %s", strings.Join(whereClause, " AND "))
 	}
 
-	countOrganizationsQuery := fmt.Sprintf(countOrganizations, whereStr)
+	return source, whereStr, finalArgs
+}
+
+func (r *organizationRepo) IndexOrganization(ctx context.Context, params entities.ListOrganizationParams) ([]entities.Organization, *entities.Metadata, error) {
+	source, whereStr, finalArgs := listOrganizationFilters(params)
+
+	countOrganizationsQuery := fmt.Sprintf(countOrganizations, source, whereStr)
 	var totalCount float64
 	row := r.db.QueryRowxContext(ctx, countOrganizationsQuery, finalArgs...)
 	err := row.Scan(
@@ -262,7 +469,7 @@ func (r *organizationRepo) IndexOrganization(ctx context.Context, params entitie
 	finalArgs = append(finalArgs, params.Limit)
 	finalArgs = append(finalArgs, params.Offset)
 
-	query := fmt.Sprintf(listOrganization, whereStr, sortStr, pagination)
+	query := fmt.Sprintf(listOrganization, source, whereStr, sortStr, pagination)
 
 	var organizations []entities.Organization
 	organizationRow, err := r.db.QueryxContext(ctx, query, finalArgs...)
@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/laksanagusta/identity/pkg/nullable"
+	"github.com/lib/pq"
+)
+
+// Hierarchy benchmarks need a migrated database, e.g.
+//
+//	BENCH_POSTGRES_DSN="host=localhost user=postgres dbname=identity sslmode=disable" go test -run '^$' -bench Hierarchy ./internal/organization/repository/
+//
+// The generated tree lives in a transaction that is rolled back afterwards.
+const (
+	benchTreeSize   = 50000
+	benchTreeFanout = 6
+)
+
+// Queries on the materialized path as used before the closure table
+var (
+	legacyFindOrganizationSubtree = `
+		WITH target_org AS (
+			SELECT uuid, path FROM organizations WHERE uuid = $1 AND deleted_at IS NULL
+		)
+		SELECT o.uuid FROM organizations o, target_org t
+		WHERE o.deleted_at IS NULL AND (o.uuid = t.uuid OR o.path LIKE t.path || '.%')
+		ORDER BY o.path
+	`
+
+	legacyFindOrganizationAncestors = `
+		SELECT uuid FROM organizations
+		WHERE uuid::text = ANY(string_to_array((SELECT path FROM organizations WHERE uuid = $1), '.')) AND uuid <> $1 AND deleted_at IS NULL
+		ORDER BY level
+	`
+
+	legacyFindOrganizationDescendants = `
+		SELECT o.uuid FROM organizations o, organizations t
+		WHERE t.uuid = $1 AND o.deleted_at IS NULL AND o.path LIKE t.path || '.%' AND o.level <= t.level + $2
+		ORDER BY o.path
+	`
+
+	closureFindOrganizationSubtree = `
+		SELECT o.uuid FROM organization_closure c
+		JOIN organizations o ON o.uuid = c.descendant_uuid
+		WHERE c.ancestor_uuid = $1 AND o.deleted_at IS NULL
+		ORDER BY c.depth
+	`
+)
+
+type benchTree struct {
+	tx     *sqlx.Tx
+	branch string // a child of the root, its subtree is about a sixth of the tree
+	leaf   string
+}
+
+func newBenchTree(b *testing.B) benchTree {
+	dsn := os.Getenv("BENCH_POSTGRES_DSN")
+	if dsn == "" {
+		b.Skip("BENCH_POSTGRES_DSN is not set")
+	}
+
+	db, err := sqlx.Open("postgres", dsn)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { db.Close() })
+
+	ctx := context.Background()
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { tx.Rollback() })
+
+	uuids := make([]string, 0, benchTreeSize)
+	parents := make([]string, 0, benchTreeSize)
+	paths := make([]string, 0, benchTreeSize)
+	levels := make([]int32, 0, benchTreeSize)
+	ancestors, descendants, depths := []string{}, []string{}, []int32{}
+
+	// Breadth first so every parent is generated before its children
+	for i := 0; i < benchTreeSize; i++ {
+		node := uuid.New().String()
+		path, parent := node, ""
+		if i > 0 {
+			parentIndex := (i - 1) / benchTreeFanout
+			parent = uuids[parentIndex]
+			path = paths[parentIndex] + "." + node
+		}
+
+		labels := strings.Split(path, ".")
+		for position, label := range labels {
+			ancestors = append(ancestors, label)
+			descendants = append(descendants, node)
+			depths = append(depths, int32(len(labels)-1-position))
+		}
+
+		uuids = append(uuids, node)
+		parents = append(parents, parent)
+		paths = append(paths, path)
+		levels = append(levels, int32(len(labels)-1))
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO organization_types (code, label, is_root_allowed, allowed_parent_types, created_by, updated_by)
+		VALUES ('bench', 'bench', true, '{bench}', 'bench', 'bench')
+	`)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO organizations (uuid, name, code, type, parent_uuid, path, level, created_by, updated_by)
+		SELECT t.uuid, 'bench ' || t.n, 'bench-' || t.uuid, 'bench', NULLIF(t.parent, '')::uuid, t.path, t.level, 'bench', 'bench'
+		FROM unnest($1::uuid[], $2::text[], $3::text[], $4::int[]) WITH ORDINALITY AS t(uuid, parent, path, level, n)
+	`, pq.Array(uuids), pq.Array(parents), pq.Array(paths), pq.Array(levels))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO organization_closure (ancestor_uuid, descendant_uuid, depth)
+		SELECT * FROM unnest($1::uuid[], $2::uuid[], $3::int[])
+	`, pq.Array(ancestors), pq.Array(descendants), pq.Array(depths))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	_, err = tx.ExecContext(ctx, `ANALYZE organizations; ANALYZE organization_closure`)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	return benchTree{tx: tx, branch: uuids[1], leaf: uuids[len(uuids)-1]}
+}
+
+func benchmarkQuery(b *testing.B, tx *sqlx.Tx, query string, args ...interface{}) {
+	ctx := context.Background()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rows, err := tx.QueryxContext(ctx, query, args...)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		count := 0
+		for rows.Next() {
+			count++
+		}
+		rows.Close()
+
+		if err := rows.Err(); err != nil {
+			b.Fatal(err)
+		}
+		if count == 0 {
+			b.Fatal("query returned no organizations")
+		}
+	}
+}
+
+func BenchmarkHierarchySubtree(b *testing.B) {
+	tree := newBenchTree(b)
+
+	b.Run("path", func(b *testing.B) {
+		benchmarkQuery(b, tree.tx, legacyFindOrganizationSubtree, tree.branch)
+	})
+	b.Run("closure", func(b *testing.B) {
+		benchmarkQuery(b, tree.tx, closureFindOrganizationSubtree, tree.branch)
+	})
+}
+
+func BenchmarkHierarchyAncestors(b *testing.B) {
+	tree := newBenchTree(b)
+
+	b.Run("path", func(b *testing.B) {
+		benchmarkQuery(b, tree.tx, legacyFindOrganizationAncestors, tree.leaf)
+	})
+	b.Run("closure", func(b *testing.B) {
+		benchmarkQuery(b, tree.tx, findOrganizationAncestors, tree.leaf)
+	})
+}
+
+func BenchmarkHierarchyDescendantsWithDepth(b *testing.B) {
+	tree := newBenchTree(b)
+
+	b.Run("path", func(b *testing.B) {
+		benchmarkQuery(b, tree.tx, legacyFindOrganizationDescendants, tree.branch, 2)
+	})
+	b.Run("closure", func(b *testing.B) {
+		benchmarkQuery(b, tree.tx, findOrganizationDescendants, tree.branch, nullable.NewInt32(2))
+	})
+}
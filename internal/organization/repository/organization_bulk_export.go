@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+// StreamOrganizations runs the query of IndexOrganization without pagination and yields the organizations
+// as they are read, with the code of their parent. The rows are released once the loop ends.
+func (r *organizationRepo) StreamOrganizations(ctx context.Context, params entities.ListOrganizationParams) (iter.Seq2[*entities.Organization, error], error) {
+	source, whereStr, finalArgs := listOrganizationFilters(params)
+
+	sortStr := "ORDER BY s.name ASC, s.uuid ASC"
+	if params.Sort != nil {
+		sortStr = fmt.Sprintf("ORDER BY %s %s, s.uuid ASC", params.Sort.FieldName, params.Sort.SortType)
+	}
+
+	rows, err := r.db.QueryxContext(ctx, fmt.Sprintf(streamOrganizations, source, whereStr, sortStr), finalArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(yield func(*entities.Organization, error) bool) {
+		defer rows.Close()
+
+		for rows.Next() {
+			var organization entities.Organization
+			var parentCode nullable.NullString
+			err := rows.Scan(
+				&organization.UUID,
+				&organization.Name,
+				&organization.Code,
+				&organization.Address,
+				&organization.Latitude,
+				&organization.Longitude,
+				&organization.Type,
+				&organization.ParentUUID,
+				&parentCode,
+				&organization.IsActive,
+				&organization.CreatedAt,
+				&organization.CreatedBy,
+			)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if organization.ParentUUID.IsExists {
+				organization.Parent = &entities.Organization{Code: parentCode}
+				organization.Parent.UUID = organization.ParentUUID.GetOrDefault()
+			}
+
+			if !yield(&organization, nil) {
+				return
+			}
+		}
+
+		if err := rows.Err(); err != nil {
+			yield(nil, err)
+		}
+	}, nil
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/lib/pq"
+)
+
+// FindOrganizationHierarchy returns every organization, soft deleted ones included, with the columns
+// the hierarchy check needs
+func (r *organizationRepo) FindOrganizationHierarchy(ctx context.Context) ([]*entities.Organization, error) {
+	return r.findOrganizationNodes(ctx, findOrganizationHierarchy)
+}
+
+func (r *organizationRepo) FindOrganizationClosure(ctx context.Context) ([]entities.OrganizationClosure, error) {
+	closure := []entities.OrganizationClosure{}
+	err := r.db.SelectContext(ctx, &closure, findOrganizationClosure)
+	if err != nil {
+		return nil, err
+	}
+
+	return closure, nil
+}
+
+// RepairOrganizationPath stores the path and level of organization without touching its subtree
+func (r *organizationRepo) RepairOrganizationPath(ctx context.Context, organization entities.Organization) error {
+	_, err := r.db.ExecContext(ctx,
+		repairOrganizationPath,
+		organization.Path,
+		organization.Level,
+		organization.UpdatedAt,
+		organization.UpdatedBy,
+		organization.UUID,
+	)
+
+	return err
+}
+
+// ReplaceOrganizationClosure replaces the closure rows linking organizationUUID to its ancestors
+func (r *organizationRepo) ReplaceOrganizationClosure(ctx context.Context, organizationUUID string, rows []entities.OrganizationClosure) error {
+	_, err := r.db.ExecContext(ctx, deleteOrganizationClosure, organizationUUID)
+	if err != nil {
+		return err
+	}
+
+	ancestorUUIDs := make([]string, 0, len(rows))
+	depths := make([]int32, 0, len(rows))
+	for _, row := range rows {
+		ancestorUUIDs = append(ancestorUUIDs, row.AncestorUUID)
+		depths = append(depths, row.Depth)
+	}
+
+	_, err = r.db.ExecContext(ctx, insertOrganizationClosureRows, pq.Array(ancestorUUIDs), organizationUUID, pq.Array(depths))
+
+	return err
+}
@@ -0,0 +1,21 @@
+package repository
+
+var (
+	// Soft deleted organizations are included, their rows are kept consistent in case they are restored
+	findOrganizationHierarchy = `
+		SELECT uuid, name, parent_uuid, path, level, is_active, deleted_at
+		FROM organizations
+		ORDER BY uuid
+	`
+
+	findOrganizationClosure = `SELECT ancestor_uuid, descendant_uuid, depth FROM organization_closure`
+
+	repairOrganizationPath = `UPDATE organizations SET path = $1, level = $2, updated_at = $3, updated_by = $4 WHERE uuid = $5`
+
+	deleteOrganizationClosure = `DELETE FROM organization_closure WHERE descendant_uuid = $1`
+
+	insertOrganizationClosureRows = `
+		INSERT INTO organization_closure (ancestor_uuid, descendant_uuid, depth)
+		SELECT unnest($1::uuid[]), $2::uuid, unnest($3::int[])
+	`
+)
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/laksanagusta/identity/internal/entities"
+)
+
+// The hierarchy test needs a migrated database, e.g.
+//
+//	TEST_POSTGRES_DSN="host=localhost user=postgres dbname=identity sslmode=disable" go test -run Hierarchy ./internal/organization/repository/
+//
+// Everything it writes is rolled back afterwards.
+func newTestTx(t *testing.T) *sqlx.Tx {
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN is not set")
+	}
+
+	db, err := sqlx.Open("postgres", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	tx, err := db.BeginTxx(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { tx.Rollback() })
+
+	return tx
+}
+
+func TestCheckOrganizationHierarchy_DeletedParent(t *testing.T) {
+	tx := newTestTx(t)
+	ctx := context.Background()
+
+	parent, child := uuid.New().String(), uuid.New().String()
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO organization_types (code, label, is_root_allowed, allowed_parent_types, created_by, updated_by)
+		VALUES ('hierarchy-test', 'hierarchy test', true, '{hierarchy-test}', 'test', 'test')
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO organizations (uuid, name, code, type, parent_uuid, path, level, created_by, updated_by) VALUES
+			($1::uuid, 'parent', 'hierarchy-test-' || $1::text, 'hierarchy-test', NULL, $1::text, 0, 'test', 'test'),
+			($2::uuid, 'child', 'hierarchy-test-' || $2::text, 'hierarchy-test', $1::uuid, $1::text || '.' || $2::text, 1, 'test', 'test')
+	`, parent, child)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO organization_closure (ancestor_uuid, descendant_uuid, depth) VALUES
+			($1, $1, 0), ($2, $2, 0), ($1, $2, 1)
+	`, parent, child)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := NewOrganizationRepo(tx)
+	if err := repo.Delete(ctx, parent, "test"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	organizations, err := repo.FindOrganizationHierarchy(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	closure, err := repo.FindOrganizationClosure(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var issues []entities.OrganizationHierarchyIssue
+	for _, issue := range entities.CheckOrganizationHierarchy(organizations, closure).Issues {
+		if issue.OrganizationUUID == parent || issue.OrganizationUUID == child {
+			issues = append(issues, issue)
+		}
+	}
+	if len(issues) != 1 || issues[0].Type != entities.HierarchyIssueOrphanedParent || issues[0].OrganizationUUID != child {
+		t.Fatalf("expected the child to be orphaned, got %+v", issues)
+	}
+}
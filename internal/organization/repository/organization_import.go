@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/lib/pq"
+)
+
+// FindOrganizationsByCodes returns the organizations with codes, soft deleted ones included
+func (r *organizationRepo) FindOrganizationsByCodes(ctx context.Context, codes []string) ([]*entities.Organization, error) {
+	return r.findOrganizationNodes(ctx, findOrganizationsByCodes, pq.Array(codes))
+}
+
+// FindOrganizations returns every organization with its address and coordinates, parents first
+func (r *organizationRepo) FindOrganizations(ctx context.Context) ([]*entities.Organization, error) {
+	return r.findOrganizationNodes(ctx, findOrganizations)
+}
@@ -0,0 +1,18 @@
+package repository
+
+var (
+	// Soft deleted organizations are included, their codes are still taken
+	findOrganizationsByCodes = `
+		SELECT ` + organizationNodeColumns + `, o.address, o.latitude, o.longitude, o.deleted_at
+		FROM organizations o
+		WHERE o.code = ANY($1)
+	`
+
+	// Parents come before their children
+	findOrganizations = `
+		SELECT ` + organizationNodeColumns + `, o.address, o.latitude, o.longitude
+		FROM organizations o
+		WHERE o.deleted_at IS NULL
+		ORDER BY o.level, o.name
+	`
+)
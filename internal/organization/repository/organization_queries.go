@@ -10,12 +10,13 @@ var (
 		longitude,
 		type,
 		path,
+		level,
 		parent_uuid,
 		created_at,
 		created_by,
 		updated_at,
 		updated_by
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 		RETURNING UUID`
 
 	updateOrganization = `
@@ -31,9 +32,6 @@ var (
 	`
 
 	findOrganizationById = `
-		WITH target_org AS (
-			SELECT uuid, path FROM organizations WHERE uuid = $1 AND deleted_at IS NULL
-		)
 		SELECT
 			o.uuid,
 			o.name,
@@ -50,10 +48,10 @@ var (
 			o.created_by,
 			o.updated_at,
 			o.updated_by
-		FROM organizations o, target_org t
-		WHERE o.deleted_at IS NULL 
-			AND (o.uuid = t.uuid OR o.path LIKE t.path || '.%')
-		ORDER BY o.path
+		FROM organization_closure c
+		JOIN organizations o ON o.uuid = c.descendant_uuid
+		WHERE c.ancestor_uuid = $1 AND o.deleted_at IS NULL
+		ORDER BY c.depth
 	`
 
 	listOrganization = `
@@ -66,7 +64,7 @@ var (
 			s.created_at,
 			s.type,
 			s.created_by
-		FROM organizations s
+		FROM %s s
 		%s
 		%s
 		%s
@@ -75,12 +73,12 @@ var (
 	countOrganizations = `
 		SELECT 
 			count(s.uuid) as total_count 
-		FROM organizations s
+		FROM %s s
 		%s
 	`
 
 	deleteOrganization = `
-		UPDATE organizations SET is_deleted = true, updated_at = $1, updated_by = $2 WHERE uuid = $3
+		UPDATE organizations SET deleted_at = $1, deleted_by = $2 WHERE uuid = $3 AND deleted_at IS NULL
 	`
 
 	findOrganizationUUIDs = `
@@ -101,4 +99,114 @@ var (
 		WHERE 
 			o.uuid IN (?)
 	`
+
+	// Serializes writes to the hierarchy, a move could otherwise race an insert under the moved subtree
+	lockOrganizationTree = `SELECT pg_advisory_xact_lock(hashtext('organizations.path'))`
+
+	// Links a new organization below every ancestor of its parent, a NULL parent makes it a root
+	insertOrganizationClosure = `
+		INSERT INTO organization_closure (ancestor_uuid, descendant_uuid, depth)
+		SELECT ancestor_uuid, $1::uuid, depth + 1 FROM organization_closure WHERE descendant_uuid = $2::uuid
+		UNION ALL
+		SELECT $1::uuid, $1::uuid, 0
+	`
+
+	findOrganizationSubtree = `
+		SELECT o.uuid, o.name, o.type, o.path, o.level
+		FROM organization_closure c
+		JOIN organizations o ON o.uuid = c.descendant_uuid
+		WHERE c.ancestor_uuid = $1 AND o.deleted_at IS NULL
+		ORDER BY c.depth, o.path
+	`
+
+	updateOrganizationParent = `UPDATE organizations SET parent_uuid = $1, updated_at = $2, updated_by = $3 WHERE uuid = $4`
+
+	// Cuts the links between the moved subtree and its former ancestors
+	detachOrganizationSubtree = `
+		DELETE FROM organization_closure
+		WHERE descendant_uuid IN (SELECT descendant_uuid FROM organization_closure WHERE ancestor_uuid = $1)
+			AND ancestor_uuid NOT IN (SELECT descendant_uuid FROM organization_closure WHERE ancestor_uuid = $1)
+	`
+
+	// Links every node of the subtree rooted at $1 below every ancestor of the new parent $2
+	attachOrganizationSubtree = `
+		INSERT INTO organization_closure (ancestor_uuid, descendant_uuid, depth)
+		SELECT p.ancestor_uuid, s.descendant_uuid, p.depth + s.depth + 1
+		FROM organization_closure p
+		CROSS JOIN organization_closure s
+		WHERE p.descendant_uuid = $2 AND s.ancestor_uuid = $1
+	`
+
+	// Rebases every path of the subtree, deleted nodes included so they stay consistent if restored
+	moveOrganizationSubtree = `
+		UPDATE organizations o SET
+			path = $1 || substr(o.path, length($2) + 1),
+			level = array_length(string_to_array($1 || substr(o.path, length($2) + 1), '.'), 1) - 1,
+			updated_at = $3,
+			updated_by = $4
+		FROM organization_closure c
+		WHERE c.ancestor_uuid = $5 AND o.uuid = c.descendant_uuid
+	`
+
+	organizationNodeColumns = `o.uuid, o.name, o.code, o.type, o.parent_uuid, o.path, o.level, o.is_active, o.created_at, o.created_by, o.updated_at, o.updated_by`
+
+	findOrganizationAncestors = `
+		SELECT ` + organizationNodeColumns + `
+		FROM organization_closure c
+		JOIN organizations o ON o.uuid = c.ancestor_uuid
+		WHERE c.descendant_uuid = $1 AND c.depth > 0 AND o.deleted_at IS NULL
+		ORDER BY c.depth DESC
+	`
+
+	// Parents come before their children, a NULL max depth returns the whole subtree
+	findOrganizationDescendants = `
+		SELECT ` + organizationNodeColumns + `
+		FROM organization_closure c
+		JOIN organizations o ON o.uuid = c.descendant_uuid
+		WHERE c.ancestor_uuid = $1 AND c.depth > 0 AND ($2::int IS NULL OR c.depth <= $2) AND o.deleted_at IS NULL
+		ORDER BY c.depth, o.name
+	`
+
+	// findNearbyOrganizations expects $1 latitude and $2 longitude of the origin, the box filter is
+	// appended by FindNearby. Distances use the haversine formula with entities.EarthRadiusMeters.
+	findNearbyOrganizations = `
+		SELECT * FROM (
+			SELECT ` + organizationNodeColumns + `, o.address, o.latitude, o.longitude,
+				2 * 6371008.8 * asin(LEAST(1, sqrt(
+					power(sin(radians(o.latitude - $1) / 2), 2) +
+					cos(radians($1)) * cos(radians(o.latitude)) * power(sin(radians(o.longitude - $2) / 2), 2)
+				))) AS distance_meters
+			FROM organizations o
+			WHERE o.deleted_at IS NULL AND o.is_active AND o.latitude IS NOT NULL AND o.longitude IS NOT NULL %s
+		) nearby
+		WHERE ($3::double precision IS NULL OR distance_meters <= $3)
+		ORDER BY distance_meters, name
+		LIMIT $4
+	`
+
+	countUsersByOrganizationUUIDs = `
+		SELECT organization_uuid, count(uuid)
+		FROM users
+		WHERE organization_uuid = ANY($1) AND deleted_at IS NULL AND erased_at IS NULL
+		GROUP BY organization_uuid
+	`
+
+	streamOrganizations = `
+		SELECT
+			s.uuid,
+			s.name,
+			s.code,
+			s.address,
+			s.latitude,
+			s.longitude,
+			s.type,
+			s.parent_uuid,
+			(SELECT p.code FROM organizations p WHERE p.uuid = s.parent_uuid) AS parent_code,
+			s.is_active,
+			s.created_at,
+			s.created_by
+		FROM %s s
+		%s
+		%s
+	`
 )
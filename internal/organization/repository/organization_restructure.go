@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/laksanagusta/identity/internal/entities"
+
+	"github.com/lib/pq"
+)
+
+// FindMemberUUIDs returns the users whose primary organization is uuid or who hold a position in it
+func (r *organizationRepo) FindMemberUUIDs(ctx context.Context, uuid string) ([]string, error) {
+	userUUIDs := []string{}
+	err := r.db.SelectContext(ctx, &userUUIDs, findOrganizationMemberUUIDs, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	return userUUIDs, nil
+}
+
+// ReassignUsers moves the primary organization and the positions of userUUIDs held in fromUUID to toUUID
+func (r *organizationRepo) ReassignUsers(ctx context.Context, fromUUID string, toUUID string, userUUIDs []string, username string, at time.Time) error {
+	for _, query := range []string{reassignUsers, reassignUserPositions} {
+		_, err := r.db.ExecContext(ctx, query, toUUID, at, username, fromUUID, pq.Array(userUUIDs))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FindGroupsByOrganizationFilter returns the dynamic groups filtering on organization uuid
+func (r *organizationRepo) FindGroupsByOrganizationFilter(ctx context.Context, uuid string) ([]*entities.Group, error) {
+	rows, err := r.db.QueryxContext(ctx, findGroupsByOrganizationFilter, uuid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	groups := []*entities.Group{}
+	for rows.Next() {
+		var group entities.Group
+		err := rows.StructScan(&group)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, &group)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+func (r *organizationRepo) UpdateGroupFilters(ctx context.Context, group entities.Group) error {
+	_, err := r.db.ExecContext(ctx,
+		updateGroupFilters,
+		group.Filters,
+		group.UpdatedAt,
+		group.UpdatedBy,
+		group.UUID,
+	)
+
+	return err
+}
+
+func (r *organizationRepo) InsertAuditLog(ctx context.Context, auditLog entities.AuditLog) error {
+	_, err := r.db.ExecContext(ctx,
+		insertOrganizationAuditLog,
+		auditLog.UUID,
+		auditLog.Actor,
+		auditLog.Action,
+		auditLog.EntityType,
+		auditLog.EntityUUID,
+		auditLog.Metadata,
+		auditLog.CreatedAt,
+	)
+
+	return err
+}
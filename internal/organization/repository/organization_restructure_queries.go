@@ -0,0 +1,54 @@
+package repository
+
+var (
+	// Users belong to an organization through their primary organization or any of their positions
+	findOrganizationMemberUUIDs = `
+		SELECT uuid FROM users WHERE organization_uuid = $1 AND deleted_at IS NULL
+		UNION
+		SELECT p.user_uuid FROM user_positions p
+		JOIN users u ON u.uuid = p.user_uuid
+		WHERE p.organization_uuid = $1 AND u.deleted_at IS NULL
+		ORDER BY 1
+	`
+
+	reassignUsers = `
+		UPDATE users SET
+			organization_uuid = $1,
+			updated_at = $2,
+			updated_by = $3
+		WHERE organization_uuid = $4 AND uuid = ANY($5)
+	`
+
+	reassignUserPositions = `
+		UPDATE user_positions SET
+			organization_uuid = $1,
+			updated_at = $2,
+			updated_by = $3
+		WHERE organization_uuid = $4 AND user_uuid = ANY($5)
+	`
+
+	// Dynamic groups with an organization_id or organization_subtree filter on $1, whose roles are
+	// scoped to that organization
+	findGroupsByOrganizationFilter = `
+		SELECT uuid, name, type, filters
+		FROM user_groups g
+		WHERE g.type = 'dynamic' AND EXISTS (
+			SELECT 1 FROM jsonb_array_elements(g.filters) f
+			WHERE f->>'field' IN ('organization_id', 'organization_subtree')
+				AND (f->>'value' = $1 OR f->'value' ? $1)
+		)
+		ORDER BY name
+	`
+
+	updateGroupFilters = `UPDATE user_groups SET filters = $1, updated_at = $2, updated_by = $3 WHERE uuid = $4`
+
+	insertOrganizationAuditLog = `INSERT INTO audit_logs (
+		uuid,
+		actor,
+		action,
+		entity_type,
+		entity_uuid,
+		metadata,
+		created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+)
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/laksanagusta/identity/internal/entities"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+func scanOrganizationSettingDefinition(row interface{ Scan(dest ...any) error }) (*entities.OrganizationSettingDefinition, error) {
+	var definition entities.OrganizationSettingDefinition
+	err := row.Scan(
+		&definition.UUID,
+		&definition.Key,
+		&definition.Label,
+		&definition.Description,
+		&definition.Type,
+		pq.Array(&definition.EnumValues),
+		&definition.DefaultValue,
+		&definition.CreatedAt,
+		&definition.CreatedBy,
+		&definition.UpdatedAt,
+		&definition.UpdatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &definition, nil
+}
+
+func (r *organizationRepo) FindOrganizationSettingDefinitions(ctx context.Context) (entities.OrganizationSettingDefinitions, error) {
+	rows, err := r.db.QueryxContext(ctx, findOrganizationSettingDefinitions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	definitions := entities.OrganizationSettingDefinitions{}
+	for rows.Next() {
+		definition, err := scanOrganizationSettingDefinition(rows)
+		if err != nil {
+			return nil, err
+		}
+		definitions = append(definitions, definition)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return definitions, nil
+}
+
+func (r *organizationRepo) FindOrganizationSettingDefinitionByUUID(ctx context.Context, uuid string) (*entities.OrganizationSettingDefinition, error) {
+	return r.findOrganizationSettingDefinition(r.db.QueryRowxContext(ctx, findOrganizationSettingDefinitionByUUID, uuid))
+}
+
+func (r *organizationRepo) FindOrganizationSettingDefinitionByKey(ctx context.Context, key string) (*entities.OrganizationSettingDefinition, error) {
+	return r.findOrganizationSettingDefinition(r.db.QueryRowxContext(ctx, findOrganizationSettingDefinitionByKey, key))
+}
+
+func (r *organizationRepo) findOrganizationSettingDefinition(row *sqlx.Row) (*entities.OrganizationSettingDefinition, error) {
+	definition, err := scanOrganizationSettingDefinition(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return definition, nil
+}
+
+func (r *organizationRepo) InsertOrganizationSettingDefinition(ctx context.Context, definition entities.OrganizationSettingDefinition) (string, error) {
+	var returnedUUID string
+	err := r.db.GetContext(ctx,
+		&returnedUUID,
+		insertOrganizationSettingDefinition,
+		definition.UUID,
+		definition.Key,
+		definition.Label,
+		definition.Description,
+		definition.Type,
+		pq.Array(definition.EnumValues),
+		definition.DefaultValue,
+		definition.CreatedAt,
+		definition.CreatedBy,
+		definition.UpdatedAt,
+		definition.UpdatedBy,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return returnedUUID, nil
+}
+
+func (r *organizationRepo) UpdateOrganizationSettingDefinition(ctx context.Context, definition entities.OrganizationSettingDefinition) error {
+	_, err := r.db.ExecContext(ctx,
+		updateOrganizationSettingDefinition,
+		definition.Label,
+		definition.Description,
+		pq.Array(definition.EnumValues),
+		definition.DefaultValue,
+		definition.UpdatedAt,
+		definition.UpdatedBy,
+		definition.UUID,
+	)
+
+	return err
+}
+
+// IsOrganizationSettingOutsideEnum reports whether an organization has set key to a value missing from enumValues
+func (r *organizationRepo) IsOrganizationSettingOutsideEnum(ctx context.Context, key string, enumValues []string) (bool, error) {
+	var outside bool
+	err := r.db.GetContext(ctx, &outside, isOrganizationSettingOutsideEnum, key, pq.Array(enumValues))
+	if err != nil {
+		return false, err
+	}
+
+	return outside, nil
+}
+
+func (r *organizationRepo) DeleteOrganizationSettingDefinition(ctx context.Context, uuid string) error {
+	_, err := r.db.ExecContext(ctx, deleteOrganizationSettingDefinition, uuid)
+	return err
+}
+
+// FindInheritedSettings returns the values set on an organization and its ancestors, nearest first
+func (r *organizationRepo) FindInheritedSettings(ctx context.Context, organizationUUID string) (entities.OrganizationSettings, error) {
+	rows, err := r.db.QueryxContext(ctx, findInheritedOrganizationSettings, organizationUUID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	settings := entities.OrganizationSettings{}
+	for rows.Next() {
+		var setting entities.OrganizationSetting
+		err := rows.StructScan(&setting)
+		if err != nil {
+			return nil, err
+		}
+		settings = append(settings, &setting)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// UpsertOrganizationSetting sets a value on an organization, replacing the one it had
+func (r *organizationRepo) UpsertOrganizationSetting(ctx context.Context, setting entities.OrganizationSetting) error {
+	_, err := r.db.ExecContext(ctx,
+		upsertOrganizationSetting,
+		setting.UUID,
+		setting.OrganizationUUID,
+		setting.Key,
+		setting.Value,
+		setting.CreatedAt,
+		setting.CreatedBy,
+		setting.UpdatedAt,
+		setting.UpdatedBy,
+	)
+
+	return err
+}
+
+// DeleteOrganizationSetting removes the value of key from an organization, it inherits it again
+func (r *organizationRepo) DeleteOrganizationSetting(ctx context.Context, organizationUUID string, key string) error {
+	_, err := r.db.ExecContext(ctx, deleteOrganizationSetting, organizationUUID, key)
+	return err
+}
@@ -0,0 +1,93 @@
+package repository
+
+var (
+	selectOrganizationSettingDefinition = `
+		SELECT
+			uuid,
+			key,
+			label,
+			description,
+			type,
+			enum_values,
+			default_value,
+			created_at,
+			created_by,
+			updated_at,
+			updated_by
+		FROM organization_setting_definitions
+	`
+
+	findOrganizationSettingDefinitions = selectOrganizationSettingDefinition + ` ORDER BY key ASC`
+
+	findOrganizationSettingDefinitionByUUID = selectOrganizationSettingDefinition + ` WHERE uuid = $1 LIMIT 1`
+
+	findOrganizationSettingDefinitionByKey = selectOrganizationSettingDefinition + ` WHERE key = $1 LIMIT 1`
+
+	insertOrganizationSettingDefinition = `INSERT INTO organization_setting_definitions (
+		uuid,
+		key,
+		label,
+		description,
+		type,
+		enum_values,
+		default_value,
+		created_at,
+		created_by,
+		updated_at,
+		updated_by
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING uuid`
+
+	updateOrganizationSettingDefinition = `
+		UPDATE organization_setting_definitions SET
+			label = $1,
+			description = $2,
+			enum_values = $3,
+			default_value = $4,
+			updated_at = $5,
+			updated_by = $6
+		WHERE uuid = $7
+	`
+
+	// Values of the definition are removed by the foreign key
+	deleteOrganizationSettingDefinition = `DELETE FROM organization_setting_definitions WHERE uuid = $1`
+
+	// Values set on $1 and its ancestors, depth counts the levels up from $1
+	findInheritedOrganizationSettings = `
+		SELECT
+			s.uuid,
+			s.organization_uuid,
+			s.key,
+			s.value,
+			c.depth,
+			s.created_at,
+			s.created_by,
+			s.updated_at,
+			s.updated_by
+		FROM organization_closure c
+		JOIN organization_settings s ON s.organization_uuid = c.ancestor_uuid
+		WHERE c.descendant_uuid = $1
+		ORDER BY c.depth, s.key
+	`
+
+	upsertOrganizationSetting = `INSERT INTO organization_settings (
+		uuid,
+		organization_uuid,
+		key,
+		value,
+		created_at,
+		created_by,
+		updated_at,
+		updated_by
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (organization_uuid, key) DO UPDATE SET
+			value = EXCLUDED.value,
+			updated_at = EXCLUDED.updated_at,
+			updated_by = EXCLUDED.updated_by`
+
+	// Enum values are stored as JSON strings
+	isOrganizationSettingOutsideEnum = `
+		SELECT EXISTS (SELECT 1 FROM organization_settings WHERE key = $1 AND NOT (value #>> '{}' = ANY($2)))
+	`
+
+	deleteOrganizationSetting = `DELETE FROM organization_settings WHERE organization_uuid = $1 AND key = $2`
+)
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/laksanagusta/identity/internal/entities"
+)
+
+// FindHeadcounts returns the headcount of uuid followed by those of its sub organizations down to
+// maxDepth levels, parents first
+func (r *organizationRepo) FindHeadcounts(ctx context.Context, uuid string, maxDepth int32) ([]entities.OrganizationHeadcount, error) {
+	headcounts := []entities.OrganizationHeadcount{}
+	err := r.db.SelectContext(ctx, &headcounts, findOrganizationHeadcounts, uuid, maxDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	return headcounts, nil
+}
+
+func (r *organizationRepo) CountMembersByStatus(ctx context.Context, uuid string) ([]entities.OrganizationStatsBucket, error) {
+	return r.countMembers(ctx, countOrganizationMembersByStatus, uuid)
+}
+
+func (r *organizationRepo) CountMembersByDirectRole(ctx context.Context, uuid string) ([]entities.OrganizationStatsBucket, error) {
+	return r.countMembers(ctx, countOrganizationMembersByDirectRole, uuid)
+}
+
+// CountJoinersByMonth counts the users of the subtree of uuid who joined from since on, by month
+func (r *organizationRepo) CountJoinersByMonth(ctx context.Context, uuid string, since time.Time) ([]entities.OrganizationStatsBucket, error) {
+	return r.countMembers(ctx, countOrganizationJoinersByMonth, uuid, since)
+}
+
+func (r *organizationRepo) countMembers(ctx context.Context, query string, args ...interface{}) ([]entities.OrganizationStatsBucket, error) {
+	buckets := []entities.OrganizationStatsBucket{}
+	err := r.db.SelectContext(ctx, &buckets, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return buckets, nil
+}
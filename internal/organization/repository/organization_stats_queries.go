@@ -0,0 +1,66 @@
+package repository
+
+var (
+	// organizationMembers are the users of the subtree of $1 with the depth of their primary
+	// organization below it, depth 0 being $1 itself
+	organizationMembers = `
+		WITH members AS (
+			SELECT u.uuid, u.is_active, u.is_approved, u.created_at, c.depth
+			FROM organization_closure c
+			JOIN organizations o ON o.uuid = c.descendant_uuid
+			JOIN users u ON u.organization_uuid = c.descendant_uuid
+			WHERE c.ancestor_uuid = $1 AND o.deleted_at IS NULL AND u.deleted_at IS NULL AND u.erased_at IS NULL
+		)
+	`
+
+	// Headcounts of $1 and of its sub organizations down to $2 levels, parents first
+	findOrganizationHeadcounts = `
+		SELECT
+			o.uuid AS organization_uuid,
+			o.name,
+			c.depth,
+			(
+				SELECT count(*) FROM users u
+				WHERE u.organization_uuid = o.uuid AND u.deleted_at IS NULL AND u.erased_at IS NULL
+			) AS direct,
+			(
+				SELECT count(*) FROM organization_closure sc
+				JOIN organizations so ON so.uuid = sc.descendant_uuid
+				JOIN users u ON u.organization_uuid = sc.descendant_uuid
+				WHERE sc.ancestor_uuid = o.uuid AND so.deleted_at IS NULL AND u.deleted_at IS NULL AND u.erased_at IS NULL
+			) AS total
+		FROM organization_closure c
+		JOIN organizations o ON o.uuid = c.descendant_uuid
+		WHERE c.ancestor_uuid = $1 AND c.depth <= $2 AND o.deleted_at IS NULL
+		ORDER BY c.depth, o.name
+	`
+
+	countOrganizationMembersByStatus = organizationMembers + `
+		SELECT s.key, count(*) FILTER (WHERE m.depth = 0) AS direct, count(*) AS total
+		FROM members m
+		CROSS JOIN LATERAL (VALUES
+			(CASE WHEN m.is_active THEN 'active' ELSE 'inactive' END),
+			(CASE WHEN m.is_approved THEN 'approved' ELSE 'pending_approval' END)
+		) s(key)
+		GROUP BY s.key
+	`
+
+	countOrganizationMembersByDirectRole = organizationMembers + `
+		SELECT r.uuid::text AS key, r.name AS label, count(*) FILTER (WHERE m.depth = 0) AS direct, count(*) AS total
+		FROM members m
+		JOIN user_roles ur ON ur.user_uuid = m.uuid
+		JOIN roles r ON r.uuid = ur.role_uuid AND r.deleted_at IS NULL
+		GROUP BY r.uuid, r.name
+		ORDER BY total DESC, r.name
+	`
+
+	// Users who joined from $2 on, by month of their creation in UTC
+	countOrganizationJoinersByMonth = organizationMembers + `
+		SELECT to_char(date_trunc('month', m.created_at AT TIME ZONE 'UTC'), 'YYYY-MM') AS key,
+			count(*) FILTER (WHERE m.depth = 0) AS direct, count(*) AS total
+		FROM members m
+		WHERE m.created_at >= $2
+		GROUP BY 1
+		ORDER BY 1
+	`
+)
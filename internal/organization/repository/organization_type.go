@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/laksanagusta/identity/internal/entities"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+func scanOrganizationType(row interface{ Scan(dest ...any) error }) (*entities.OrganizationType, error) {
+	var organizationType entities.OrganizationType
+	err := row.Scan(
+		&organizationType.UUID,
+		&organizationType.Code,
+		&organizationType.Label,
+		&organizationType.IsRootAllowed,
+		pq.Array(&organizationType.AllowedParentTypes),
+		&organizationType.MaxDepth,
+		pq.Array(&organizationType.RequiredFields),
+		&organizationType.CreatedAt,
+		&organizationType.CreatedBy,
+		&organizationType.UpdatedAt,
+		&organizationType.UpdatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &organizationType, nil
+}
+
+func (r *organizationRepo) FindOrganizationTypes(ctx context.Context) ([]*entities.OrganizationType, error) {
+	rows, err := r.db.QueryxContext(ctx, findOrganizationTypes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	organizationTypes := []*entities.OrganizationType{}
+	for rows.Next() {
+		organizationType, err := scanOrganizationType(rows)
+		if err != nil {
+			return nil, err
+		}
+		organizationTypes = append(organizationTypes, organizationType)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return organizationTypes, nil
+}
+
+func (r *organizationRepo) FindOrganizationTypeByUUID(ctx context.Context, uuid string) (*entities.OrganizationType, error) {
+	return r.findOrganizationType(r.db.QueryRowxContext(ctx, findOrganizationTypeByUUID, uuid))
+}
+
+func (r *organizationRepo) FindOrganizationTypeByCode(ctx context.Context, code string) (*entities.OrganizationType, error) {
+	return r.findOrganizationType(r.db.QueryRowxContext(ctx, findOrganizationTypeByCode, code))
+}
+
+func (r *organizationRepo) findOrganizationType(row *sqlx.Row) (*entities.OrganizationType, error) {
+	organizationType, err := scanOrganizationType(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return organizationType, nil
+}
+
+func (r *organizationRepo) InsertOrganizationType(ctx context.Context, organizationType entities.OrganizationType) (string, error) {
+	var returnedUUID string
+	err := r.db.GetContext(ctx,
+		&returnedUUID,
+		insertOrganizationType,
+		organizationType.UUID,
+		organizationType.Code,
+		organizationType.Label,
+		organizationType.IsRootAllowed,
+		pq.Array(organizationType.AllowedParentTypes),
+		organizationType.MaxDepth,
+		pq.Array(organizationType.RequiredFields),
+		organizationType.CreatedAt,
+		organizationType.CreatedBy,
+		organizationType.UpdatedAt,
+		organizationType.UpdatedBy,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return returnedUUID, nil
+}
+
+func (r *organizationRepo) UpdateOrganizationType(ctx context.Context, organizationType entities.OrganizationType) error {
+	_, err := r.db.ExecContext(ctx,
+		updateOrganizationType,
+		organizationType.Label,
+		organizationType.IsRootAllowed,
+		pq.Array(organizationType.AllowedParentTypes),
+		organizationType.MaxDepth,
+		pq.Array(organizationType.RequiredFields),
+		organizationType.UpdatedAt,
+		organizationType.UpdatedBy,
+		organizationType.UUID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteOrganizationType removes the type and drops its code from the allowed parents of other types
+func (r *organizationRepo) DeleteOrganizationType(ctx context.Context, organizationType entities.OrganizationType) error {
+	_, err := r.db.ExecContext(ctx, removeAllowedParentType, organizationType.Code)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, deleteOrganizationType, organizationType.UUID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *organizationRepo) IsOrganizationTypeInUse(ctx context.Context, code string) (bool, error) {
+	var inUse bool
+	err := r.db.GetContext(ctx, &inUse, isOrganizationTypeInUse, code)
+	if err != nil {
+		return false, err
+	}
+
+	return inUse, nil
+}
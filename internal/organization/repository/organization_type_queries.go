@@ -0,0 +1,64 @@
+package repository
+
+var (
+	selectOrganizationType = `
+		SELECT
+			uuid,
+			code,
+			label,
+			is_root_allowed,
+			allowed_parent_types,
+			max_depth,
+			required_fields,
+			created_at,
+			created_by,
+			updated_at,
+			updated_by
+		FROM organization_types
+	`
+
+	findOrganizationTypes = selectOrganizationType + ` ORDER BY label ASC`
+
+	findOrganizationTypeByUUID = selectOrganizationType + ` WHERE uuid = $1 LIMIT 1`
+
+	findOrganizationTypeByCode = selectOrganizationType + ` WHERE code = $1 LIMIT 1`
+
+	insertOrganizationType = `INSERT INTO organization_types (
+		uuid,
+		code,
+		label,
+		is_root_allowed,
+		allowed_parent_types,
+		max_depth,
+		required_fields,
+		created_at,
+		created_by,
+		updated_at,
+		updated_by
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING uuid`
+
+	updateOrganizationType = `
+		UPDATE organization_types SET
+			label = $1,
+			is_root_allowed = $2,
+			allowed_parent_types = $3,
+			max_depth = $4,
+			required_fields = $5,
+			updated_at = $6,
+			updated_by = $7
+		WHERE uuid = $8
+	`
+
+	deleteOrganizationType = `DELETE FROM organization_types WHERE uuid = $1`
+
+	// Soft deleted organizations still reference their type, so do scheduled versions
+	isOrganizationTypeInUse = `
+		SELECT EXISTS (SELECT 1 FROM organizations WHERE type = $1)
+			OR EXISTS (SELECT 1 FROM organization_versions WHERE attribute = 'type' AND value = $1 AND applied_at IS NULL)
+	`
+
+	removeAllowedParentType = `
+		UPDATE organization_types SET allowed_parent_types = array_remove(allowed_parent_types, $1)
+		WHERE $1 = ANY(allowed_parent_types)
+	`
+)
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/laksanagusta/identity/internal/entities"
+)
+
+func (r *organizationRepo) InsertOrganizationVersions(ctx context.Context, versions entities.OrganizationVersions) error {
+	for _, version := range versions {
+		_, err := r.db.ExecContext(ctx,
+			insertOrganizationVersion,
+			version.UUID,
+			version.OrganizationUUID,
+			version.Attribute,
+			version.Value,
+			version.ValidFrom,
+			version.ValidTo,
+			version.AppliedAt,
+			version.CreatedAt,
+			version.CreatedBy,
+			version.UpdatedAt,
+			version.UpdatedBy,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *organizationRepo) UpdateOrganizationVersion(ctx context.Context, version entities.OrganizationVersion) error {
+	_, err := r.db.ExecContext(ctx,
+		updateOrganizationVersion,
+		version.Value,
+		version.ValidTo,
+		version.AppliedAt,
+		version.FailedAt,
+		version.Error,
+		version.UpdatedAt,
+		version.UpdatedBy,
+		version.UUID,
+	)
+
+	return err
+}
+
+func (r *organizationRepo) DeleteOrganizationVersion(ctx context.Context, uuid string) error {
+	_, err := r.db.ExecContext(ctx, deleteOrganizationVersion, uuid)
+	return err
+}
+
+// FindOrganizationVersions returns the history and scheduled changes of an organization
+func (r *organizationRepo) FindOrganizationVersions(ctx context.Context, organizationUUID string) (entities.OrganizationVersions, error) {
+	return r.findOrganizationVersions(ctx, findOrganizationVersions, organizationUUID)
+}
+
+func (r *organizationRepo) FindOrganizationVersionByUUID(ctx context.Context, uuid string) (*entities.OrganizationVersion, error) {
+	var version entities.OrganizationVersion
+	err := r.db.QueryRowxContext(ctx, findOrganizationVersionByUUID, uuid).StructScan(&version)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+// FindDueOrganizationVersions returns the scheduled versions starting on or before day
+func (r *organizationRepo) FindDueOrganizationVersions(ctx context.Context, day time.Time) (entities.OrganizationVersions, error) {
+	return r.findOrganizationVersions(ctx, findDueOrganizationVersions, day)
+}
+
+// LockDueOrganizationVersion locks a version that is still waiting to be applied, it returns nil when
+// the version was applied, failed or is being applied by another transaction meanwhile
+func (r *organizationRepo) LockDueOrganizationVersion(ctx context.Context, uuid string) (*entities.OrganizationVersion, error) {
+	var version entities.OrganizationVersion
+	err := r.db.QueryRowxContext(ctx, lockDueOrganizationVersion, uuid).StructScan(&version)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+func (r *organizationRepo) findOrganizationVersions(ctx context.Context, query string, args ...interface{}) (entities.OrganizationVersions, error) {
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := entities.OrganizationVersions{}
+	for rows.Next() {
+		var version entities.OrganizationVersion
+		err := rows.StructScan(&version)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, &version)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+func (r *organizationRepo) MarkOrganizationVersionApplied(ctx context.Context, uuid string, appliedAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, markOrganizationVersionApplied, appliedAt, uuid)
+	return err
+}
+
+// MarkOrganizationVersionFailed keeps a version that could not be applied from being retried
+func (r *organizationRepo) MarkOrganizationVersionFailed(ctx context.Context, uuid string, failedAt time.Time, reason string) error {
+	_, err := r.db.ExecContext(ctx, markOrganizationVersionFailed, failedAt, reason, uuid)
+	return err
+}
+
+// UpdateAttributes writes the versioned attributes apart from the parent, which moves the subtree
+func (r *organizationRepo) UpdateAttributes(ctx context.Context, organization entities.Organization) error {
+	_, err := r.db.ExecContext(ctx,
+		updateOrganizationAttributes,
+		organization.Name,
+		organization.Type,
+		organization.IsActive,
+		organization.UpdatedAt,
+		organization.UpdatedBy,
+		organization.UUID,
+	)
+
+	return err
+}
+
+// FindOrganizationsAsOf returns every organization with its attributes, path and level as they are on
+// the day of asOf, parents before children
+func (r *organizationRepo) FindOrganizationsAsOf(ctx context.Context, asOf time.Time) ([]*entities.Organization, error) {
+	return r.findOrganizationNodes(ctx, fmt.Sprintf(findOrganizationsAsOf, 1), entities.Day(asOf))
+}
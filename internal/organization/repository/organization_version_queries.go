@@ -0,0 +1,125 @@
+package repository
+
+var (
+	organizationVersionColumns = `uuid, organization_uuid, attribute, value, valid_from, valid_to, applied_at, failed_at, error, created_at, created_by, updated_at, updated_by`
+
+	insertOrganizationVersion = `INSERT INTO organization_versions (
+		uuid,
+		organization_uuid,
+		attribute,
+		value,
+		valid_from,
+		valid_to,
+		applied_at,
+		created_at,
+		created_by,
+		updated_at,
+		updated_by
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+	updateOrganizationVersion = `
+		UPDATE organization_versions SET
+			value = $1,
+			valid_to = $2,
+			applied_at = $3,
+			failed_at = $4,
+			error = NULLIF($5, ''),
+			updated_at = $6,
+			updated_by = $7
+		WHERE uuid = $8
+	`
+
+	deleteOrganizationVersion = `DELETE FROM organization_versions WHERE uuid = $1`
+
+	findOrganizationVersions = `
+		SELECT ` + organizationVersionColumns + `
+		FROM organization_versions
+		WHERE organization_uuid = $1
+		ORDER BY attribute, valid_from
+	`
+
+	findOrganizationVersionByUUID = `
+		SELECT ` + organizationVersionColumns + `
+		FROM organization_versions
+		WHERE uuid = $1
+	`
+
+	// Scheduled versions whose day has come, oldest first so later changes win
+	findDueOrganizationVersions = `
+		SELECT ` + organizationVersionColumns + `
+		FROM organization_versions
+		WHERE applied_at IS NULL AND failed_at IS NULL AND valid_from <= $1
+		ORDER BY valid_from, created_at
+	`
+
+	// Another instance applying the version holds the lock, it is skipped here
+	lockDueOrganizationVersion = `
+		SELECT ` + organizationVersionColumns + `
+		FROM organization_versions
+		WHERE uuid = $1 AND applied_at IS NULL AND failed_at IS NULL
+		FOR UPDATE SKIP LOCKED
+	`
+
+	markOrganizationVersionApplied = `UPDATE organization_versions SET applied_at = $1 WHERE uuid = $2`
+
+	markOrganizationVersionFailed = `
+		UPDATE organization_versions SET
+			failed_at = $1,
+			error = $2
+		WHERE uuid = $3 AND applied_at IS NULL
+	`
+
+	updateOrganizationAttributes = `
+		UPDATE organizations SET
+			name = $1,
+			type = $2,
+			is_active = $3,
+			updated_at = $4,
+			updated_by = $5
+		WHERE uuid = $6
+	`
+
+	// organizationsAsOf replaces the versioned attributes of every organization by their value on the
+	// date in the %d-th parameter, organizations without a name version did not exist yet
+	organizationsAsOf = `(
+		SELECT
+			o.uuid,
+			max(v.value) FILTER (WHERE v.attribute = 'name') AS name,
+			o.code,
+			o.address,
+			o.latitude,
+			o.longitude,
+			max(v.value) FILTER (WHERE v.attribute = 'type') AS type,
+			max(v.value) FILTER (WHERE v.attribute = 'parent_uuid')::uuid AS parent_uuid,
+			COALESCE(bool_or(v.value = 'true') FILTER (WHERE v.attribute = 'is_active'), true) AS is_active,
+			o.created_at,
+			o.created_by,
+			o.updated_at,
+			o.updated_by,
+			o.deleted_at
+		FROM organizations o
+		JOIN organization_versions v ON v.organization_uuid = o.uuid
+			AND v.valid_from <= $%[1]d AND (v.valid_to IS NULL OR v.valid_to > $%[1]d)
+		WHERE o.deleted_at IS NULL
+		GROUP BY o.uuid
+		HAVING count(*) FILTER (WHERE v.attribute = 'name') > 0
+	)`
+
+	// Rebuilds path and level of the hierarchy as of $1 from the roots down
+	findOrganizationsAsOf = `
+		WITH RECURSIVE versioned AS ` + organizationsAsOf + `,
+		tree AS (
+			SELECT v.*, v.uuid::text AS path, 0 AS level
+			FROM versioned v
+			WHERE v.parent_uuid IS NULL
+			UNION ALL
+			SELECT v.*, t.path || '.' || v.uuid::text, t.level + 1
+			FROM versioned v
+			JOIN tree t ON v.parent_uuid = t.uuid
+		)
+		SELECT uuid, name, code, address, latitude, longitude, type, parent_uuid, path, level, is_active,
+			created_at, created_by, updated_at, updated_by
+		FROM tree
+		ORDER BY level, name
+	`
+)
@@ -2,6 +2,8 @@ package organization
 
 import (
 	"context"
+	"iter"
+	"time"
 
 	"github.com/laksanagusta/identity/internal/entities"
 	"github.com/laksanagusta/identity/internal/organization/dtos"
@@ -10,7 +12,37 @@ import (
 type UseCase interface {
 	Create(ctx context.Context, auth entities.AuthenticatedUser, req dtos.CreateNewOrganizationReq) (string, error)
 	Update(ctx context.Context, cred entities.AuthenticatedUser, req dtos.UpdateOrganizationReq) error
-	Show(ctx context.Context, cred entities.AuthenticatedUser, uuid string) (*entities.Organization, error)
+	Show(ctx context.Context, cred entities.AuthenticatedUser, req dtos.ShowOrganizationReq) (*entities.Organization, error)
 	ListOrganization(ctx context.Context, cred entities.AuthenticatedUser, req dtos.ListOrganizationReq) ([]entities.Organization, *entities.Metadata, error)
 	Delete(ctx context.Context, cred entities.AuthenticatedUser, uuid string) error
+	OrgChart(ctx context.Context, cred entities.AuthenticatedUser, uuid string) (*entities.Organization, error)
+	Ancestors(ctx context.Context, cred entities.AuthenticatedUser, req dtos.OrganizationTreeReq) ([]*entities.Organization, error)
+	Children(ctx context.Context, cred entities.AuthenticatedUser, req dtos.OrganizationTreeReq) ([]*entities.Organization, error)
+	Descendants(ctx context.Context, cred entities.AuthenticatedUser, req dtos.OrganizationTreeReq) (*entities.Organization, error)
+	SearchNearby(ctx context.Context, cred entities.AuthenticatedUser, params entities.GeoSearchParams) ([]*entities.Organization, error)
+	Stats(ctx context.Context, cred entities.AuthenticatedUser, req dtos.OrganizationStatsReq) (*entities.OrganizationStats, error)
+	Move(ctx context.Context, cred entities.AuthenticatedUser, req dtos.MoveOrganizationReq) ([]entities.OrganizationPathChange, error)
+	Merge(ctx context.Context, cred entities.AuthenticatedUser, req dtos.MergeOrganizationReq) (*entities.OrganizationRestructure, error)
+	Split(ctx context.Context, cred entities.AuthenticatedUser, req dtos.SplitOrganizationReq) (*entities.OrganizationRestructure, error)
+	CheckHierarchy(ctx context.Context, cred entities.AuthenticatedUser, req dtos.CheckOrganizationHierarchyReq) (*entities.OrganizationHierarchyReport, error)
+	Import(ctx context.Context, cred entities.AuthenticatedUser, req dtos.ImportOrganizationReq) ([]entities.OrganizationImportChange, error)
+	Export(ctx context.Context, cred entities.AuthenticatedUser, req dtos.ExportOrganizationReq) ([]*entities.Organization, error)
+	BulkExport(ctx context.Context, cred entities.AuthenticatedUser, req dtos.BulkExportOrganizationReq) (iter.Seq2[*entities.Organization, error], error)
+
+	IndexOrganizationVersion(ctx context.Context, cred entities.AuthenticatedUser, organizationUUID string) (entities.OrganizationVersions, error)
+	ScheduleOrganizationVersion(ctx context.Context, cred entities.AuthenticatedUser, req dtos.ScheduleOrganizationVersionReq) (entities.OrganizationVersions, error)
+	CancelOrganizationVersion(ctx context.Context, cred entities.AuthenticatedUser, req dtos.OrganizationVersionReq) error
+	ApplyDueOrganizationVersions(ctx context.Context, now time.Time) (int, error)
+
+	IndexOrganizationSetting(ctx context.Context) (entities.OrganizationSettingDefinitions, error)
+	CreateOrganizationSetting(ctx context.Context, definition entities.OrganizationSettingDefinition) (string, error)
+	UpdateOrganizationSetting(ctx context.Context, cred entities.AuthenticatedUser, req dtos.UpdateOrganizationSettingReq) error
+	DeleteOrganizationSetting(ctx context.Context, uuid string) error
+	EffectiveOrganizationSettings(ctx context.Context, organizationUUID string) ([]entities.EffectiveOrganizationSetting, error)
+	UpdateOrganizationSettings(ctx context.Context, cred entities.AuthenticatedUser, req dtos.UpdateOrganizationSettingsReq) error
+
+	IndexOrganizationType(ctx context.Context, req dtos.ListOrganizationTypeReq) ([]*entities.OrganizationType, error)
+	CreateOrganizationType(ctx context.Context, organizationType entities.OrganizationType) (string, error)
+	UpdateOrganizationType(ctx context.Context, cred entities.AuthenticatedUser, req dtos.UpdateOrganizationTypeReq) error
+	DeleteOrganizationType(ctx context.Context, uuid string) error
 }
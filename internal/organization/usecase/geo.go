@@ -0,0 +1,13 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/laksanagusta/identity/internal/entities"
+)
+
+// SearchNearby returns the active organizations with coordinates around params.Origin, nearest first,
+// each with its distance from the origin
+func (uc *OrganizationUseCase) SearchNearby(ctx context.Context, cred entities.AuthenticatedUser, params entities.GeoSearchParams) ([]*entities.Organization, error) {
+	return uc.organizationRepo.FindNearby(ctx, params)
+}
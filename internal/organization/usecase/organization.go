@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"maps"
 
 	"github.com/laksanagusta/identity/constants"
 	"github.com/laksanagusta/identity/internal/entities"
@@ -10,6 +11,7 @@ import (
 	"github.com/laksanagusta/identity/internal/user"
 	"github.com/laksanagusta/identity/pkg/database"
 	"github.com/laksanagusta/identity/pkg/errorhelper"
+	"github.com/laksanagusta/identity/pkg/nullable"
 )
 
 type UseCaseParameter struct {
@@ -39,24 +41,13 @@ func (uc *OrganizationUseCase) Create(ctx context.Context, cred entities.Authent
 	err := uc.txManager.Atomic(ctx, func(ctx context.Context, tx database.DBTx) error {
 		organizationRepoTrx := uc.organizationRepo.WithTransaction(tx)
 
-		var parentPath string
-		if organization.ParentUUID.IsExists {
-			parent, err := organizationRepoTrx.FindOrganizationByUUID(ctx, *organization.ParentUUID.Val)
-			if err != nil {
-				return err
-			}
-
-			parentPath = parent.Path.GetOrDefault()
-		}
-
-		organization.BuildPath(parentPath)
-
-		newUUID, err := organizationRepoTrx.Insert(ctx, organization)
+		err := organizationRepoTrx.LockTree(ctx)
 		if err != nil {
 			return err
 		}
-		newOrganizationUUID = newUUID
-		return nil
+
+		newOrganizationUUID, err = uc.insertOrganization(ctx, organizationRepoTrx, organization, cred.Username)
+		return err
 	})
 	if err != nil {
 		return "", err
@@ -65,25 +56,104 @@ func (uc *OrganizationUseCase) Create(ctx context.Context, cred entities.Authent
 	return newOrganizationUUID, nil
 }
 
-func (uc *OrganizationUseCase) Update(ctx context.Context, cred entities.AuthenticatedUser, req dtos.UpdateOrganizationReq) error {
-	organization := req.NewUpdateOrganization(cred)
-
-	existingOrganization, err := uc.organizationRepo.FindOrganizationByUUID(ctx, req.OrganizationUUID)
+// insertOrganization stores a new organization below its parent_uuid, the tree has to be locked
+func (uc *OrganizationUseCase) insertOrganization(ctx context.Context, repo organization.Repository, organization entities.Organization, username string) (string, error) {
+	organizationTypes, err := organizationTypesByCode(ctx, repo)
 	if err != nil {
-		return err
+		return "", err
 	}
-	if existingOrganization == nil {
-		return errorhelper.BadRequestMap(map[string][]string{
-			"organization_id": {constants.ErrMsgNotFound},
+
+	organizationType, ok := organizationTypes[organization.Type.GetOrDefault()]
+	if !ok {
+		return "", errorhelper.BadRequestMap(map[string][]string{
+			"type": {constants.ErrMsgNotFound},
 		})
 	}
 
-	err = uc.organizationRepo.Update(ctx, organization)
+	var parentPath, parentType string
+	if organization.ParentUUID.IsNotEmpty() {
+		parent, err := repo.FindOrganizationNodeByUUID(ctx, *organization.ParentUUID.Val)
+		if err != nil {
+			return "", err
+		}
+		if parent == nil {
+			return "", errorhelper.BadRequestMap(map[string][]string{
+				"parent_id": {constants.ErrMsgNotFound},
+			})
+		}
+
+		parentPath = parent.Path.GetOrDefault()
+		parentType = parent.Type.GetOrDefault()
+	}
+
+	organization.BuildPath(parentPath)
+
+	errs := organizationType.ValidatePlacement(parentType, organization.Level.GetOrDefault())
+	maps.Copy(errs, organizationType.ValidateFields(organization))
+	if len(errs) > 0 {
+		return "", errorhelper.BadRequestMap(errs)
+	}
+
+	newUUID, err := repo.Insert(ctx, organization)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	return nil
+	err = repo.InsertOrganizationVersions(ctx, appliedVersions(organization, username))
+	if err != nil {
+		return "", err
+	}
+
+	return newUUID, nil
+}
+
+// Update changes organization details, the result has to satisfy the rules of its organization type.
+// Name and type changes are recorded in the organization history from today on.
+func (uc *OrganizationUseCase) Update(ctx context.Context, cred entities.AuthenticatedUser, req dtos.UpdateOrganizationReq) error {
+	organization := req.NewUpdateOrganization(cred)
+
+	return uc.txManager.Atomic(ctx, func(ctx context.Context, tx database.DBTx) error {
+		organizationRepoTrx := uc.organizationRepo.WithTransaction(tx)
+
+		err := organizationRepoTrx.LockTree(ctx)
+		if err != nil {
+			return err
+		}
+
+		existingOrganization, err := organizationRepoTrx.FindOrganizationNodeByUUID(ctx, req.OrganizationUUID)
+		if err != nil {
+			return err
+		}
+		if existingOrganization == nil {
+			return errorhelper.BadRequestMap(map[string][]string{
+				"organization_id": {constants.ErrMsgNotFound},
+			})
+		}
+
+		err = uc.validateOrganizationUpdate(ctx, organizationRepoTrx, *existingOrganization, organization)
+		if err != nil {
+			return err
+		}
+
+		err = organizationRepoTrx.Update(ctx, organization)
+		if err != nil {
+			return err
+		}
+
+		var attributes []string
+		if organization.Name.IsExists {
+			attributes = append(attributes, entities.OrganizationAttributeName)
+		}
+		if organization.Type.IsNotEmpty() {
+			attributes = append(attributes, entities.OrganizationAttributeType)
+		}
+		if len(attributes) == 0 {
+			return nil
+		}
+
+		_, err = uc.saveVersions(ctx, organizationRepoTrx, appliedVersions(organization, cred.Username, attributes...))
+		return err
+	})
 }
 
 func (uc *OrganizationUseCase) ListOrganization(ctx context.Context, cred entities.AuthenticatedUser, req dtos.ListOrganizationReq) ([]entities.Organization, *entities.Metadata, error) {
@@ -100,8 +170,12 @@ func (uc *OrganizationUseCase) ListOrganization(ctx context.Context, cred entiti
 	return organizations, metadata, nil
 }
 
-func (uc *OrganizationUseCase) Show(ctx context.Context, cred entities.AuthenticatedUser, uuid string) (*entities.Organization, error) {
-	existingOrganization, err := uc.organizationRepo.FindOrganizationByUUID(ctx, uuid)
+func (uc *OrganizationUseCase) Show(ctx context.Context, cred entities.AuthenticatedUser, req dtos.ShowOrganizationReq) (*entities.Organization, error) {
+	if req.AsOfDate().IsExists {
+		return uc.showAsOf(ctx, req.OrganizationUUID, req.AsOfDate().GetOrDefault())
+	}
+
+	existingOrganization, err := uc.organizationRepo.FindOrganizationByUUID(ctx, req.OrganizationUUID)
 	if err != nil {
 		return nil, err
 	}
@@ -116,7 +190,7 @@ func (uc *OrganizationUseCase) Show(ctx context.Context, cred entities.Authentic
 }
 
 func (uc *OrganizationUseCase) Delete(ctx context.Context, cred entities.AuthenticatedUser, uuid string) error {
-	organization, err := uc.organizationRepo.FindOrganizationByUUID(ctx, uuid)
+	organization, err := uc.organizationRepo.FindOrganizationNodeByUUID(ctx, uuid)
 	if err != nil {
 		return err
 	}
@@ -133,3 +207,160 @@ func (uc *OrganizationUseCase) Delete(ctx context.Context, cred entities.Authent
 
 	return nil
 }
+
+// Move reparents an organization and rebases the path and level of its whole subtree. With
+// req.DryRun the affected organizations are computed but nothing is written.
+func (uc *OrganizationUseCase) Move(ctx context.Context, cred entities.AuthenticatedUser, req dtos.MoveOrganizationReq) ([]entities.OrganizationPathChange, error) {
+	var changes []entities.OrganizationPathChange
+	err := uc.txManager.Atomic(ctx, func(ctx context.Context, tx database.DBTx) error {
+		organizationRepoTrx := uc.organizationRepo.WithTransaction(tx)
+
+		err := organizationRepoTrx.LockTree(ctx)
+		if err != nil {
+			return err
+		}
+
+		organization, err := organizationRepoTrx.FindOrganizationNodeByUUID(ctx, req.OrganizationUUID)
+		if err != nil {
+			return err
+		}
+		if organization == nil {
+			return errorhelper.BadRequestMap(map[string][]string{
+				"organization_id": {constants.ErrMsgNotFound},
+			})
+		}
+
+		changes, err = uc.moveOrganization(ctx, organizationRepoTrx, organization, req.ParentUUID, cred.Username, req.DryRun)
+		if err != nil || req.DryRun {
+			return err
+		}
+
+		_, err = uc.saveVersions(ctx, organizationRepoTrx, appliedVersions(*organization, cred.Username, entities.OrganizationAttributeParent))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// moveOrganization reparents organization below parentUUID, a root when empty, and rebases its
+// subtree. With dryRun the changes are only computed.
+func (uc *OrganizationUseCase) moveOrganization(ctx context.Context, repo organization.Repository, organization *entities.Organization, parentUUID nullable.NullString, username string, dryRun bool) ([]entities.OrganizationPathChange, error) {
+	oldPath := organization.Path.GetOrDefault()
+
+	var parentPath, parentType string
+	if parentUUID.IsNotEmpty() {
+		parent, err := repo.FindOrganizationNodeByUUID(ctx, parentUUID.GetOrDefault())
+		if err != nil {
+			return nil, err
+		}
+		if parent == nil {
+			return nil, errorhelper.BadRequestMap(map[string][]string{
+				"parent_id": {constants.ErrMsgNotFound},
+			})
+		}
+		if parent.IsInSubtreeOf(oldPath) {
+			return nil, errorhelper.BadRequestMap(map[string][]string{
+				"parent_id": {constants.ErrMsgOrganizationCycle},
+			})
+		}
+
+		parentPath = parent.Path.GetOrDefault()
+		parentType = parent.Type.GetOrDefault()
+	}
+
+	organization.BuildPath(parentPath)
+	newPath := organization.Path.GetOrDefault()
+
+	subtree, err := repo.FindSubtree(ctx, organization.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]entities.OrganizationPathChange, 0, len(subtree))
+	for _, node := range subtree {
+		movedPath := entities.MovedPath(node.Path.GetOrDefault(), oldPath, newPath)
+		changes = append(changes, entities.OrganizationPathChange{
+			UUID:     node.UUID,
+			Name:     node.Name,
+			OldPath:  node.Path.GetOrDefault(),
+			NewPath:  movedPath,
+			OldLevel: node.Level.GetOrDefault(),
+			NewLevel: entities.PathLevel(movedPath),
+		})
+	}
+
+	organizationTypes, err := organizationTypesByCode(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	errs := validateSubtreePlacement(organizationTypes, subtree, changes, parentType)
+	if len(errs) > 0 {
+		return nil, errorhelper.BadRequestMap(errs)
+	}
+
+	if dryRun {
+		return changes, nil
+	}
+
+	organization.ParentUUID = parentUUID
+	organization.UpdateModel(username)
+
+	err = repo.UpdateParent(ctx, *organization)
+	if err != nil {
+		return nil, err
+	}
+
+	err = repo.MoveSubtree(ctx, *organization, oldPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// OrgChart returns the organization subtree rooted at uuid with the people of every organization attached
+func (uc *OrganizationUseCase) OrgChart(ctx context.Context, cred entities.AuthenticatedUser, uuid string) (*entities.Organization, error) {
+	root, err := uc.organizationRepo.FindOrganizationByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	if root == nil {
+		return nil, errorhelper.BadRequestMap(map[string][]string{
+			"organization_id": {constants.ErrMsgNotFound},
+		})
+	}
+
+	nodes := make(map[string]*entities.Organization)
+	collectOrganizations(root, nodes)
+
+	organizationUUIDs := make([]string, 0, len(nodes))
+	for organizationUUID := range nodes {
+		organizationUUIDs = append(organizationUUIDs, organizationUUID)
+	}
+
+	users, err := uc.userUC.ListByOrganizations(ctx, organizationUUIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range users {
+		if node, ok := nodes[user.OrganizationUUID.GetOrDefault()]; ok {
+			node.Users = append(node.Users, user)
+		}
+	}
+
+	return root, nil
+}
+
+func collectOrganizations(organization *entities.Organization, nodes map[string]*entities.Organization) {
+	nodes[organization.UUID] = organization
+	for _, child := range organization.Children {
+		if child != nil {
+			collectOrganizations(child, nodes)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package usecase
+
+import (
+	"context"
+	"iter"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/organization/dtos"
+)
+
+// BulkExport returns every organization matching the filters of req with the code of its parent. The
+// query already runs when it returns, so invalid filters are reported before anything is written.
+func (uc *OrganizationUseCase) BulkExport(ctx context.Context, cred entities.AuthenticatedUser, req dtos.BulkExportOrganizationReq) (iter.Seq2[*entities.Organization, error], error) {
+	listOrganizationParams, err := req.NewListOrganizationParams()
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.organizationRepo.StreamOrganizations(ctx, listOrganizationParams)
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/organization/dtos"
+	"github.com/laksanagusta/identity/pkg/database"
+)
+
+// CheckHierarchy compares the path, level and closure rows of every organization with its parent_uuid.
+// With req.Repair the repairable issues are fixed in the same transaction, the others are only reported.
+func (uc *OrganizationUseCase) CheckHierarchy(ctx context.Context, cred entities.AuthenticatedUser, req dtos.CheckOrganizationHierarchyReq) (*entities.OrganizationHierarchyReport, error) {
+	var report entities.OrganizationHierarchyReport
+	err := uc.txManager.Atomic(ctx, func(ctx context.Context, tx database.DBTx) error {
+		organizationRepoTrx := uc.organizationRepo.WithTransaction(tx)
+
+		err := organizationRepoTrx.LockTree(ctx)
+		if err != nil {
+			return err
+		}
+
+		organizations, err := organizationRepoTrx.FindOrganizationHierarchy(ctx)
+		if err != nil {
+			return err
+		}
+
+		closure, err := organizationRepoTrx.FindOrganizationClosure(ctx)
+		if err != nil {
+			return err
+		}
+
+		report = entities.CheckOrganizationHierarchy(organizations, closure)
+		if !req.Repair {
+			return nil
+		}
+
+		for _, organization := range report.PathRepairs {
+			organization.UpdateModel(cred.Username)
+			err = organizationRepoTrx.RepairOrganizationPath(ctx, *organization)
+			if err != nil {
+				return err
+			}
+		}
+
+		for organizationUUID, rows := range report.ClosureRepairs {
+			err = organizationRepoTrx.ReplaceOrganizationClosure(ctx, organizationUUID, rows)
+			if err != nil {
+				return err
+			}
+		}
+
+		report.Repaired = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/laksanagusta/identity/constants"
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/organization"
+	"github.com/laksanagusta/identity/internal/organization/dtos"
+	"github.com/laksanagusta/identity/pkg/database"
+	"github.com/laksanagusta/identity/pkg/errorhelper"
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+// Import creates the organizations of req.Rows whose code is new and updates the others, all in one
+// transaction. Errors of a row are keyed by its number. With req.DryRun everything is carried out
+// and rolled back, so the diff is checked against the same rules.
+func (uc *OrganizationUseCase) Import(ctx context.Context, cred entities.AuthenticatedUser, req dtos.ImportOrganizationReq) ([]entities.OrganizationImportChange, error) {
+	var changes []entities.OrganizationImportChange
+	err := uc.txManager.Atomic(ctx, func(ctx context.Context, tx database.DBTx) error {
+		organizationRepoTrx := uc.organizationRepo.WithTransaction(tx)
+
+		err := organizationRepoTrx.LockTree(ctx)
+		if err != nil {
+			return err
+		}
+
+		organizations, err := organizationRepoTrx.FindOrganizationsByCodes(ctx, req.Rows.Codes())
+		if err != nil {
+			return err
+		}
+
+		existing := make(map[string]*entities.Organization, len(organizations))
+		uuids := make(map[string]string, len(organizations))
+		for _, organization := range organizations {
+			existing[organization.Code.GetOrDefault()] = organization
+			uuids[organization.Code.GetOrDefault()] = organization.UUID
+		}
+
+		errs := req.Rows.Validate(existing)
+		if len(errs) > 0 {
+			return errorhelper.BadRequestMap(errs)
+		}
+
+		changes = make([]entities.OrganizationImportChange, 0, len(req.Rows))
+		for _, row := range req.Rows.Ordered() {
+			var parentUUID nullable.NullString
+			if row.ParentCode != "" {
+				parentUUID = nullable.NewString(uuids[row.ParentCode])
+			}
+
+			change, err := uc.importOrganization(ctx, organizationRepoTrx, row, existing[row.Code], parentUUID, cred.Username)
+			if err != nil {
+				return errorhelper.PrefixMap(err, req.Rows.ErrorKey(row.Code))
+			}
+
+			uuids[row.Code] = change.OrganizationUUID
+			changes = append(changes, change)
+		}
+
+		if req.DryRun {
+			return errDryRun
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+
+	if req.DryRun {
+		for i := range changes {
+			if changes[i].Action == entities.OrganizationImportCreate {
+				changes[i].OrganizationUUID = ""
+			}
+		}
+	}
+
+	return changes, nil
+}
+
+// importOrganization creates row below parentUUID when there is no existing organization with its
+// code, otherwise it moves and updates the existing one where the row differs
+func (uc *OrganizationUseCase) importOrganization(ctx context.Context, repo organization.Repository, row entities.OrganizationImportRow, existing *entities.Organization, parentUUID nullable.NullString, username string) (entities.OrganizationImportChange, error) {
+	change := entities.OrganizationImportChange{Code: row.Code}
+
+	if existing == nil {
+		organizationUUID, err := uc.insertOrganization(ctx, repo, row.NewOrganization(username, parentUUID), username)
+		if err != nil {
+			return change, err
+		}
+
+		change.Action = entities.OrganizationImportCreate
+		change.OrganizationUUID = organizationUUID
+		return change, nil
+	}
+
+	change.OrganizationUUID = existing.UUID
+	update, fields := row.Update(*existing, parentUUID, username)
+	if len(fields) == 0 {
+		change.Action = entities.OrganizationImportUnchanged
+		return change, nil
+	}
+
+	change.Action = entities.OrganizationImportUpdate
+	change.Fields = fields
+
+	// Paths of earlier moves in the same import may have changed, the node is read again
+	node, err := repo.FindOrganizationNodeByUUID(ctx, existing.UUID)
+	if err != nil {
+		return change, err
+	}
+	if node == nil {
+		return change, errorhelper.BadRequestMap(map[string][]string{
+			"code": {constants.ErrMsgNotFound},
+		})
+	}
+
+	if slices.Contains(fields, entities.OrganizationAttributeParent) {
+		_, err = uc.moveOrganization(ctx, repo, node, parentUUID, username, false)
+		if err != nil {
+			return change, err
+		}
+
+		_, err = uc.saveVersions(ctx, repo, appliedVersions(*node, username, entities.OrganizationAttributeParent))
+		if err != nil {
+			return change, err
+		}
+	}
+
+	var attributes []string
+	if update.Name.IsExists {
+		attributes = append(attributes, entities.OrganizationAttributeName)
+	}
+	if update.Type.IsNotEmpty() {
+		attributes = append(attributes, entities.OrganizationAttributeType)
+	}
+	if len(attributes) == 0 && !update.Address.IsExists && !update.Latitude.IsExists && !update.Longitude.IsExists {
+		return change, nil
+	}
+
+	node.Address, node.Latitude, node.Longitude = existing.Address, existing.Latitude, existing.Longitude
+	err = uc.validateOrganizationUpdate(ctx, repo, *node, update)
+	if err != nil {
+		return change, err
+	}
+
+	err = repo.Update(ctx, update)
+	if err != nil {
+		return change, err
+	}
+
+	if len(attributes) == 0 {
+		return change, nil
+	}
+
+	_, err = uc.saveVersions(ctx, repo, appliedVersions(update, username, attributes...))
+	return change, err
+}
+
+// Export returns the subtree of req.RootUUID, or every organization when it is empty, parents first.
+// The root keeps its parent so the file can be imported below it again.
+func (uc *OrganizationUseCase) Export(ctx context.Context, cred entities.AuthenticatedUser, req dtos.ExportOrganizationReq) ([]*entities.Organization, error) {
+	var organizations []*entities.Organization
+	var err error
+	if req.AsOfDate().IsExists {
+		organizations, err = uc.organizationRepo.FindOrganizationsAsOf(ctx, req.AsOfDate().GetOrDefault())
+	} else {
+		organizations, err = uc.organizationRepo.FindOrganizations(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if req.RootUUID == "" {
+		return organizations, nil
+	}
+
+	tree := entities.NewOrganizationTree(organizations)
+	root := tree.Node(req.RootUUID)
+	if root == nil {
+		errMsg := constants.ErrMsgNotFound
+		if req.AsOfDate().IsExists {
+			errMsg = constants.ErrMsgOrganizationNotExistsAsOf
+		}
+		return nil, errorhelper.BadRequestMap(map[string][]string{
+			"root_id": {errMsg},
+		})
+	}
+
+	if root.ParentUUID.IsNotEmpty() {
+		root.Parent = tree.Node(root.ParentUUID.GetOrDefault())
+	}
+
+	return append([]*entities.Organization{root}, tree.Descendants(root.UUID, 0)...), nil
+}
@@ -0,0 +1,213 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+
+	"github.com/laksanagusta/identity/constants"
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/organization/dtos"
+	"github.com/laksanagusta/identity/pkg/database"
+	"github.com/laksanagusta/identity/pkg/errorhelper"
+)
+
+func (uc *OrganizationUseCase) IndexOrganizationSetting(ctx context.Context) (entities.OrganizationSettingDefinitions, error) {
+	return uc.organizationRepo.FindOrganizationSettingDefinitions(ctx)
+}
+
+func (uc *OrganizationUseCase) CreateOrganizationSetting(ctx context.Context, definition entities.OrganizationSettingDefinition) (string, error) {
+	existing, err := uc.organizationRepo.FindOrganizationSettingDefinitionByKey(ctx, definition.Key.GetOrDefault())
+	if err != nil {
+		return "", err
+	}
+	if existing != nil {
+		return "", errorhelper.BadRequestMap(map[string][]string{
+			"key": {constants.ErrMsgAlreadyExist},
+		})
+	}
+
+	err = validateDefaultValue(&definition)
+	if err != nil {
+		return "", err
+	}
+
+	return uc.organizationRepo.InsertOrganizationSettingDefinition(ctx, definition)
+}
+
+func (uc *OrganizationUseCase) UpdateOrganizationSetting(ctx context.Context, cred entities.AuthenticatedUser, req dtos.UpdateOrganizationSettingReq) error {
+	definition, err := uc.organizationRepo.FindOrganizationSettingDefinitionByUUID(ctx, req.UUID)
+	if err != nil {
+		return err
+	}
+	if definition == nil {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"setting_id": {constants.ErrMsgNotFound},
+		})
+	}
+
+	err = req.Apply(definition, cred)
+	if err != nil {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"default_value": {constants.ErrMsgAttributeInvalidType},
+		})
+	}
+
+	if definition.Type.GetOrDefault() == entities.AttributeTypeEnum && len(definition.EnumValues) == 0 {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"enum_values": {constants.ErrMsgAttributeRequired},
+		})
+	}
+
+	err = validateDefaultValue(definition)
+	if err != nil {
+		return err
+	}
+
+	// Values organizations set stay valid, they have to be changed before their enum value is removed
+	if definition.Type.GetOrDefault() == entities.AttributeTypeEnum && req.EnumValues != nil {
+		outside, err := uc.organizationRepo.IsOrganizationSettingOutsideEnum(ctx, definition.Key.GetOrDefault(), definition.EnumValues)
+		if err != nil {
+			return err
+		}
+		if outside {
+			return errorhelper.BadRequestMap(map[string][]string{
+				"enum_values": {constants.ErrMsgOrganizationSettingEnumInUse},
+			})
+		}
+	}
+
+	return uc.organizationRepo.UpdateOrganizationSettingDefinition(ctx, *definition)
+}
+
+// DeleteOrganizationSetting removes a setting together with the values organizations set for it
+func (uc *OrganizationUseCase) DeleteOrganizationSetting(ctx context.Context, uuid string) error {
+	definition, err := uc.organizationRepo.FindOrganizationSettingDefinitionByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+	if definition == nil {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"setting_id": {constants.ErrMsgNotFound},
+		})
+	}
+
+	return uc.organizationRepo.DeleteOrganizationSettingDefinition(ctx, uuid)
+}
+
+// EffectiveOrganizationSettings resolves every setting for an organization, a value set on the
+// organization overrides the ones of its ancestors, which override the default
+func (uc *OrganizationUseCase) EffectiveOrganizationSettings(ctx context.Context, organizationUUID string) ([]entities.EffectiveOrganizationSetting, error) {
+	organization, err := uc.organizationRepo.FindOrganizationNodeByUUID(ctx, organizationUUID)
+	if err != nil {
+		return nil, err
+	}
+	if organization == nil {
+		return nil, errorhelper.BadRequestMap(map[string][]string{
+			"organization_id": {constants.ErrMsgNotFound},
+		})
+	}
+
+	definitions, err := uc.organizationRepo.FindOrganizationSettingDefinitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := uc.organizationRepo.FindInheritedSettings(ctx, organizationUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return definitions.Resolve(settings), nil
+}
+
+// UpdateOrganizationSettings sets or, for null values, removes values on an organization. Every value
+// is checked against its definition before anything is written.
+func (uc *OrganizationUseCase) UpdateOrganizationSettings(ctx context.Context, cred entities.AuthenticatedUser, req dtos.UpdateOrganizationSettingsReq) error {
+	organization, err := uc.organizationRepo.FindOrganizationNodeByUUID(ctx, req.OrganizationUUID)
+	if err != nil {
+		return err
+	}
+	if organization == nil {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"organization_id": {constants.ErrMsgNotFound},
+		})
+	}
+
+	definitionList, err := uc.organizationRepo.FindOrganizationSettingDefinitions(ctx)
+	if err != nil {
+		return err
+	}
+	definitions := definitionList.ByKey()
+
+	keys := make([]string, 0, len(req.Settings))
+	for key := range req.Settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	errs := map[string][]string{}
+	settings := entities.OrganizationSettings{}
+	for _, key := range keys {
+		definition, ok := definitions[key]
+		if !ok {
+			errs["settings."+key] = []string{constants.ErrMsgAttributeUnknown}
+			continue
+		}
+
+		value := req.Settings[key]
+		if value != nil {
+			normalized, errMsg := definition.ValidateValue(value)
+			if errMsg != "" {
+				errs["settings."+key] = []string{errMsg}
+				continue
+			}
+			value = normalized
+		}
+
+		settings = append(settings, &entities.OrganizationSetting{
+			BaseModel:        entities.NewBaseModel(cred.Username),
+			OrganizationUUID: organization.UUID,
+			Key:              key,
+			Value:            entities.SettingValue{Val: value},
+		})
+	}
+
+	if len(errs) > 0 {
+		return errorhelper.BadRequestMap(errs)
+	}
+
+	return uc.txManager.Atomic(ctx, func(ctx context.Context, tx database.DBTx) error {
+		organizationRepoTrx := uc.organizationRepo.WithTransaction(tx)
+
+		for _, setting := range settings {
+			var err error
+			if setting.Value.Val == nil {
+				err = organizationRepoTrx.DeleteOrganizationSetting(ctx, setting.OrganizationUUID, setting.Key)
+			} else {
+				err = organizationRepoTrx.UpsertOrganizationSetting(ctx, *setting)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// validateDefaultValue checks and normalizes the default of a definition, a missing default is valid
+func validateDefaultValue(definition *entities.OrganizationSettingDefinition) error {
+	if definition.DefaultValue.Val == nil {
+		return nil
+	}
+
+	normalized, errMsg := definition.ValidateValue(definition.DefaultValue.Val)
+	if errMsg != "" {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"default_value": {errMsg},
+		})
+	}
+
+	definition.DefaultValue.Val = normalized
+	return nil
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/laksanagusta/identity/constants"
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/organization/dtos"
+	"github.com/laksanagusta/identity/pkg/errorhelper"
+)
+
+// Stats counts the users of an organization by their primary organization, directly and rolled up
+// over its subtree, and those of its sub organizations. With req.Breakdowns the counts by status, by
+// role and of new joiners per month are added.
+func (uc *OrganizationUseCase) Stats(ctx context.Context, cred entities.AuthenticatedUser, req dtos.OrganizationStatsReq) (*entities.OrganizationStats, error) {
+	headcounts, err := uc.organizationRepo.FindHeadcounts(ctx, req.OrganizationUUID, req.Depth())
+	if err != nil {
+		return nil, err
+	}
+	if len(headcounts) == 0 {
+		return nil, errorhelper.BadRequestMap(map[string][]string{
+			"organization_id": {constants.ErrMsgNotFound},
+		})
+	}
+
+	stats := &entities.OrganizationStats{
+		Headcount:        headcounts[0],
+		SubOrganizations: headcounts[1:],
+		ByStatus:         []entities.OrganizationStatsBucket{},
+		ByDirectRole:     []entities.OrganizationStatsBucket{},
+		JoinersByMonth:   []entities.OrganizationStatsBucket{},
+	}
+	if !req.Breakdowns {
+		return stats, nil
+	}
+
+	byStatus, err := uc.organizationRepo.CountMembersByStatus(ctx, req.OrganizationUUID)
+	if err != nil {
+		return nil, err
+	}
+	stats.ByStatus = entities.StatusBuckets(byStatus)
+
+	stats.ByDirectRole, err = uc.organizationRepo.CountMembersByDirectRole(ctx, req.OrganizationUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	since := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 1-req.MonthCount(), 0)
+	joiners, err := uc.organizationRepo.CountJoinersByMonth(ctx, req.OrganizationUUID, since)
+	if err != nil {
+		return nil, err
+	}
+	stats.JoinersByMonth = entities.MonthBuckets(joiners, since, now)
+
+	return stats, nil
+}
@@ -0,0 +1,235 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/laksanagusta/identity/constants"
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/organization"
+	"github.com/laksanagusta/identity/internal/organization/dtos"
+	"github.com/laksanagusta/identity/pkg/database"
+	"github.com/laksanagusta/identity/pkg/errorhelper"
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+// IndexOrganizationType lists the registry. With req.ParentUUID only the types that may be created
+// directly below that organization are returned.
+func (uc *OrganizationUseCase) IndexOrganizationType(ctx context.Context, req dtos.ListOrganizationTypeReq) ([]*entities.OrganizationType, error) {
+	organizationTypes, err := uc.organizationRepo.FindOrganizationTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.ParentUUID == "" {
+		return organizationTypes, nil
+	}
+
+	parent, err := uc.organizationRepo.FindOrganizationNodeByUUID(ctx, req.ParentUUID)
+	if err != nil {
+		return nil, err
+	}
+	if parent == nil {
+		return nil, errorhelper.BadRequestMap(map[string][]string{
+			"parent_id": {constants.ErrMsgNotFound},
+		})
+	}
+
+	allowed := []*entities.OrganizationType{}
+	for _, organizationType := range organizationTypes {
+		if len(organizationType.ValidatePlacement(parent.Type.GetOrDefault(), parent.Level.GetOrDefault()+1)) == 0 {
+			allowed = append(allowed, organizationType)
+		}
+	}
+
+	return allowed, nil
+}
+
+func (uc *OrganizationUseCase) CreateOrganizationType(ctx context.Context, organizationType entities.OrganizationType) (string, error) {
+	existing, err := uc.organizationRepo.FindOrganizationTypeByCode(ctx, organizationType.Code.GetOrDefault())
+	if err != nil {
+		return "", err
+	}
+	if existing != nil {
+		return "", errorhelper.BadRequestMap(map[string][]string{
+			"code": {constants.ErrMsgAlreadyExist},
+		})
+	}
+
+	err = uc.validateAllowedParentTypes(ctx, organizationType)
+	if err != nil {
+		return "", err
+	}
+
+	return uc.organizationRepo.InsertOrganizationType(ctx, organizationType)
+}
+
+// UpdateOrganizationType changes the rules of a type, they apply to organizations created, updated
+// or moved afterwards
+func (uc *OrganizationUseCase) UpdateOrganizationType(ctx context.Context, cred entities.AuthenticatedUser, req dtos.UpdateOrganizationTypeReq) error {
+	organizationType, err := uc.organizationRepo.FindOrganizationTypeByUUID(ctx, req.UUID)
+	if err != nil {
+		return err
+	}
+	if organizationType == nil {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"organization_type_id": {constants.ErrMsgNotFound},
+		})
+	}
+
+	req.Apply(organizationType, cred)
+
+	err = uc.validateAllowedParentTypes(ctx, *organizationType)
+	if err != nil {
+		return err
+	}
+
+	return uc.organizationRepo.UpdateOrganizationType(ctx, *organizationType)
+}
+
+func (uc *OrganizationUseCase) DeleteOrganizationType(ctx context.Context, uuid string) error {
+	organizationType, err := uc.organizationRepo.FindOrganizationTypeByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+	if organizationType == nil {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"organization_type_id": {constants.ErrMsgNotFound},
+		})
+	}
+
+	inUse, err := uc.organizationRepo.IsOrganizationTypeInUse(ctx, organizationType.Code.GetOrDefault())
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"organization_type_id": {constants.ErrMsgOrganizationTypeInUse},
+		})
+	}
+
+	return uc.txManager.Atomic(ctx, func(ctx context.Context, tx database.DBTx) error {
+		return uc.organizationRepo.WithTransaction(tx).DeleteOrganizationType(ctx, *organizationType)
+	})
+}
+
+// validateAllowedParentTypes requires every allowed parent to be registered, a type may allow itself
+func (uc *OrganizationUseCase) validateAllowedParentTypes(ctx context.Context, organizationType entities.OrganizationType) error {
+	organizationTypes, err := organizationTypesByCode(ctx, uc.organizationRepo)
+	if err != nil {
+		return err
+	}
+
+	errs := map[string][]string{}
+	for i, code := range organizationType.AllowedParentTypes {
+		if _, ok := organizationTypes[code]; !ok && code != organizationType.Code.GetOrDefault() {
+			errs[fmt.Sprintf("allowed_parent_types.%d", i)] = []string{constants.ErrMsgNotFound}
+		}
+	}
+	if len(errs) > 0 {
+		return errorhelper.BadRequestMap(errs)
+	}
+
+	return nil
+}
+
+// validateOrganizationUpdate checks existing with the changes of update applied against its type.
+// A new type must fit below the parent, at the current level and above the current sub organizations.
+func (uc *OrganizationUseCase) validateOrganizationUpdate(ctx context.Context, repo organization.Repository, existing entities.Organization, update entities.Organization) error {
+	organizationTypes, err := organizationTypesByCode(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	merged := existing
+	if update.Address.IsExists {
+		merged.Address = update.Address
+	}
+	if update.Latitude.IsExists {
+		merged.Latitude = update.Latitude
+	}
+	if update.Longitude.IsExists {
+		merged.Longitude = update.Longitude
+	}
+	if update.Type.IsNotEmpty() {
+		merged.Type = update.Type
+	}
+
+	organizationType, ok := organizationTypes[merged.Type.GetOrDefault()]
+	if !ok {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"type": {constants.ErrMsgNotFound},
+		})
+	}
+
+	errs := organizationType.ValidateFields(merged)
+
+	if merged.Type.GetOrDefault() != existing.Type.GetOrDefault() {
+		var parentType string
+		if existing.ParentUUID.IsNotEmpty() {
+			parent, err := repo.FindOrganizationNodeByUUID(ctx, existing.ParentUUID.GetOrDefault())
+			if err != nil {
+				return err
+			}
+			if parent != nil {
+				parentType = parent.Type.GetOrDefault()
+			}
+		}
+
+		for _, placementErrs := range organizationType.ValidatePlacement(parentType, existing.Level.GetOrDefault()) {
+			errs["type"] = append(errs["type"], placementErrs...)
+		}
+
+		children, err := repo.FindDescendants(ctx, existing.UUID, nullable.NewInt32(1))
+		if err != nil {
+			return err
+		}
+
+		for _, child := range children {
+			childType, ok := organizationTypes[child.Type.GetOrDefault()]
+			if ok && !childType.AllowsParent(merged.Type.GetOrDefault()) {
+				errs["type"] = append(errs["type"], constants.ErrMsgOrganizationTypeChildren)
+				break
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errorhelper.BadRequestMap(errs)
+	}
+
+	return nil
+}
+
+// validateSubtreePlacement checks a moved subtree against the organization types, subtree starts
+// with the moved organization and changes holds the new levels in the same order
+func validateSubtreePlacement(organizationTypes map[string]*entities.OrganizationType, subtree []*entities.Organization, changes []entities.OrganizationPathChange, parentType string) map[string][]string {
+	errs := map[string][]string{}
+	for i, node := range subtree {
+		organizationType, ok := organizationTypes[node.Type.GetOrDefault()]
+		if !ok {
+			continue
+		}
+
+		if i == 0 {
+			maps.Copy(errs, organizationType.ValidatePlacement(parentType, changes[i].NewLevel))
+			continue
+		}
+
+		if !organizationType.AllowsLevel(changes[i].NewLevel) && !slices.Contains(errs["parent_id"], constants.ErrMsgOrganizationTypeDepth) {
+			errs["parent_id"] = append(errs["parent_id"], constants.ErrMsgOrganizationTypeDepth)
+		}
+	}
+
+	return errs
+}
+
+func organizationTypesByCode(ctx context.Context, repo organization.Repository) (map[string]*entities.OrganizationType, error) {
+	organizationTypes, err := repo.FindOrganizationTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.OrganizationTypes(organizationTypes).ByCode(), nil
+}
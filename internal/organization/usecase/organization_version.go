@@ -0,0 +1,347 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/laksanagusta/identity/constants"
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/organization"
+	"github.com/laksanagusta/identity/internal/organization/dtos"
+	"github.com/laksanagusta/identity/pkg/database"
+	"github.com/laksanagusta/identity/pkg/errorhelper"
+)
+
+// IndexOrganizationVersion returns the history and the scheduled changes of an organization
+func (uc *OrganizationUseCase) IndexOrganizationVersion(ctx context.Context, cred entities.AuthenticatedUser, organizationUUID string) (entities.OrganizationVersions, error) {
+	organization, err := uc.organizationRepo.FindOrganizationNodeByUUID(ctx, organizationUUID)
+	if err != nil {
+		return nil, err
+	}
+	if organization == nil {
+		return nil, errorhelper.BadRequestMap(map[string][]string{
+			"organization_id": {constants.ErrMsgNotFound},
+		})
+	}
+
+	return uc.organizationRepo.FindOrganizationVersions(ctx, organizationUUID)
+}
+
+// ScheduleOrganizationVersion changes attributes of an organization from req.ValidFrom on. The change is
+// checked against the hierarchy as it will be on that day, a change for today is applied right away.
+func (uc *OrganizationUseCase) ScheduleOrganizationVersion(ctx context.Context, cred entities.AuthenticatedUser, req dtos.ScheduleOrganizationVersionReq) (entities.OrganizationVersions, error) {
+	now := time.Now()
+	validFrom := entities.Day(req.ValidFromDate())
+	if validFrom.Before(entities.Day(now)) {
+		return nil, errorhelper.BadRequestMap(map[string][]string{
+			"valid_from": {constants.ErrMsgOrganizationVersionPast},
+		})
+	}
+
+	var saved entities.OrganizationVersions
+	err := uc.txManager.Atomic(ctx, func(ctx context.Context, tx database.DBTx) error {
+		organizationRepoTrx := uc.organizationRepo.WithTransaction(tx)
+
+		err := organizationRepoTrx.LockTree(ctx)
+		if err != nil {
+			return err
+		}
+
+		existing, err := organizationRepoTrx.FindOrganizationNodeByUUID(ctx, req.OrganizationUUID)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return errorhelper.BadRequestMap(map[string][]string{
+				"organization_id": {constants.ErrMsgNotFound},
+			})
+		}
+
+		tree, err := organizationTreeAsOf(ctx, organizationRepoTrx, validFrom)
+		if err != nil {
+			return err
+		}
+
+		organization := tree.Node(req.OrganizationUUID)
+		if organization == nil {
+			return errorhelper.BadRequestMap(map[string][]string{
+				"organization_id": {constants.ErrMsgOrganizationNotExistsAsOf},
+			})
+		}
+
+		versions := req.NewOrganizationVersions(cred)
+		err = validateDatedChange(ctx, organizationRepoTrx, tree, *organization, versions)
+		if err != nil {
+			return err
+		}
+
+		saved, err = uc.saveVersions(ctx, organizationRepoTrx, versions)
+		if err != nil {
+			return err
+		}
+
+		if validFrom.After(entities.Day(now)) {
+			return nil
+		}
+
+		for _, version := range saved {
+			err = uc.applyOrganizationVersion(ctx, organizationRepoTrx, version, now)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+// CancelOrganizationVersion removes a change that has not taken effect yet, the version before it
+// stays in effect instead
+func (uc *OrganizationUseCase) CancelOrganizationVersion(ctx context.Context, cred entities.AuthenticatedUser, req dtos.OrganizationVersionReq) error {
+	return uc.txManager.Atomic(ctx, func(ctx context.Context, tx database.DBTx) error {
+		organizationRepoTrx := uc.organizationRepo.WithTransaction(tx)
+
+		err := organizationRepoTrx.LockTree(ctx)
+		if err != nil {
+			return err
+		}
+
+		version, err := organizationRepoTrx.FindOrganizationVersionByUUID(ctx, req.VersionUUID)
+		if err != nil {
+			return err
+		}
+		if version == nil || version.OrganizationUUID != req.OrganizationUUID {
+			return errorhelper.BadRequestMap(map[string][]string{
+				"version_id": {constants.ErrMsgNotFound},
+			})
+		}
+		if version.AppliedAt != nil {
+			return errorhelper.BadRequestMap(map[string][]string{
+				"version_id": {constants.ErrMsgOrganizationVersionApplied},
+			})
+		}
+
+		versions, err := organizationRepoTrx.FindOrganizationVersions(ctx, version.OrganizationUUID)
+		if err != nil {
+			return err
+		}
+
+		extended := versions.Timeline(version.Attribute).Cancel(version, cred.Username)
+		if extended != nil {
+			err = organizationRepoTrx.UpdateOrganizationVersion(ctx, *extended)
+			if err != nil {
+				return err
+			}
+		}
+
+		return organizationRepoTrx.DeleteOrganizationVersion(ctx, version.UUID)
+	})
+}
+
+// ApplyDueOrganizationVersions writes the scheduled versions whose day has come to the organizations and
+// returns how many were applied. Every version is applied in its own transaction and skipped when another
+// instance got to it first. A version that no longer fits the hierarchy is marked failed so it is not
+// retried, other errors leave it for the next run. All errors are returned together.
+func (uc *OrganizationUseCase) ApplyDueOrganizationVersions(ctx context.Context, now time.Time) (int, error) {
+	versions, err := uc.organizationRepo.FindDueOrganizationVersions(ctx, entities.Day(now))
+	if err != nil {
+		return 0, err
+	}
+
+	applied := 0
+	var errs []error
+	for _, due := range versions {
+		locked := false
+		err := uc.txManager.Atomic(ctx, func(ctx context.Context, tx database.DBTx) error {
+			organizationRepoTrx := uc.organizationRepo.WithTransaction(tx)
+
+			err := organizationRepoTrx.LockTree(ctx)
+			if err != nil {
+				return err
+			}
+
+			version, err := organizationRepoTrx.LockDueOrganizationVersion(ctx, due.UUID)
+			if err != nil || version == nil {
+				return err
+			}
+
+			locked = true
+			return uc.applyOrganizationVersion(ctx, organizationRepoTrx, version, now)
+		})
+		if err != nil {
+			var appErr *errorhelper.AppError
+			if errors.As(err, &appErr) {
+				if markErr := uc.organizationRepo.MarkOrganizationVersionFailed(ctx, due.UUID, now, err.Error()); markErr != nil {
+					err = errors.Join(err, markErr)
+				}
+			}
+			errs = append(errs, fmt.Errorf("organization version %s: %w", due.UUID, err))
+			continue
+		}
+
+		if locked {
+			applied++
+		}
+	}
+
+	return applied, errors.Join(errs...)
+}
+
+// applyOrganizationVersion writes version to its organization, a parent change moves the subtree
+func (uc *OrganizationUseCase) applyOrganizationVersion(ctx context.Context, repo organization.Repository, version *entities.OrganizationVersion, now time.Time) error {
+	existing, err := repo.FindOrganizationNodeByUUID(ctx, version.OrganizationUUID)
+	if err != nil {
+		return err
+	}
+
+	// Deleted organizations have nothing left to change
+	if existing != nil {
+		switch version.Attribute {
+		case entities.OrganizationAttributeParent:
+			_, err = uc.moveOrganization(ctx, repo, existing, version.Value, version.UpdatedBy, false)
+			if err != nil {
+				return err
+			}
+		default:
+			updated := *existing
+			version.ApplyTo(&updated)
+
+			if version.Attribute == entities.OrganizationAttributeType {
+				err = uc.validateOrganizationUpdate(ctx, repo, *existing, entities.Organization{Type: updated.Type})
+				if err != nil {
+					return err
+				}
+			}
+
+			updated.UpdateModel(version.UpdatedBy)
+			err = repo.UpdateAttributes(ctx, updated)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	version.AppliedAt = &now
+	return repo.MarkOrganizationVersionApplied(ctx, version.UUID, now)
+}
+
+// saveVersions places versions in the timelines of their organization and returns the stored versions
+// holding the new values, all versions belong to the same organization
+func (uc *OrganizationUseCase) saveVersions(ctx context.Context, repo organization.Repository, versions entities.OrganizationVersions) (entities.OrganizationVersions, error) {
+	if len(versions) == 0 {
+		return versions, nil
+	}
+
+	existing, err := repo.FindOrganizationVersions(ctx, versions[0].OrganizationUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	saved := make(entities.OrganizationVersions, 0, len(versions))
+	for _, version := range versions {
+		updated, inserted := existing.Timeline(version.Attribute).Schedule(*version)
+		for _, u := range updated {
+			err = repo.UpdateOrganizationVersion(ctx, *u)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if inserted == nil {
+			saved = append(saved, updated[0])
+			continue
+		}
+
+		err = repo.InsertOrganizationVersions(ctx, entities.OrganizationVersions{inserted})
+		if err != nil {
+			return nil, err
+		}
+		saved = append(saved, inserted)
+	}
+
+	return saved, nil
+}
+
+// appliedVersions records attributes of organization, all of them when none are given, as changed today
+func appliedVersions(organization entities.Organization, username string, attributes ...string) entities.OrganizationVersions {
+	now := time.Now()
+	versions := entities.NewOrganizationVersions(organization, now, username, attributes...)
+	for _, version := range versions {
+		version.AppliedAt = &now
+	}
+
+	return versions
+}
+
+// validateDatedChange checks versions of organization against the hierarchy tree as it will be when
+// they take effect. Later scheduled changes are checked again once they are applied.
+func validateDatedChange(ctx context.Context, repo organization.Repository, tree entities.OrganizationTree, organization entities.Organization, versions entities.OrganizationVersions) error {
+	changed := organization
+	for _, version := range versions {
+		version.ApplyTo(&changed)
+	}
+
+	organizationTypes, err := organizationTypesByCode(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	organizationType, ok := organizationTypes[changed.Type.GetOrDefault()]
+	if !ok {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"type": {constants.ErrMsgNotFound},
+		})
+	}
+
+	var parentType string
+	var level int32
+	if changed.ParentUUID.IsNotEmpty() {
+		parent := tree.Node(changed.ParentUUID.GetOrDefault())
+		if parent == nil {
+			return errorhelper.BadRequestMap(map[string][]string{
+				"parent_id": {constants.ErrMsgOrganizationNotExistsAsOf},
+			})
+		}
+		if parent.IsInSubtreeOf(organization.Path.GetOrDefault()) {
+			return errorhelper.BadRequestMap(map[string][]string{
+				"parent_id": {constants.ErrMsgOrganizationCycle},
+			})
+		}
+
+		parentType = parent.Type.GetOrDefault()
+		level = parent.Level.GetOrDefault() + 1
+	}
+
+	errs := organizationType.ValidatePlacement(parentType, level)
+
+	// The subtree moves along, its organizations have to accept the new type and depth
+	levelShift := level - organization.Level.GetOrDefault()
+	for _, descendant := range tree.Descendants(organization.UUID, 0) {
+		descendantType, ok := organizationTypes[descendant.Type.GetOrDefault()]
+		if !ok {
+			continue
+		}
+
+		isChild := descendant.ParentUUID.GetOrDefault() == organization.UUID
+		if isChild && !descendantType.AllowsParent(changed.Type.GetOrDefault()) && !slices.Contains(errs["type"], constants.ErrMsgOrganizationTypeChildren) {
+			errs["type"] = append(errs["type"], constants.ErrMsgOrganizationTypeChildren)
+		}
+		if !descendantType.AllowsLevel(descendant.Level.GetOrDefault()+levelShift) && !slices.Contains(errs["parent_id"], constants.ErrMsgOrganizationTypeDepth) {
+			errs["parent_id"] = append(errs["parent_id"], constants.ErrMsgOrganizationTypeDepth)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errorhelper.BadRequestMap(errs)
+	}
+
+	return nil
+}
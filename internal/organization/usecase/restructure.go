@@ -0,0 +1,269 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/laksanagusta/identity/constants"
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/organization"
+	"github.com/laksanagusta/identity/internal/organization/dtos"
+	"github.com/laksanagusta/identity/pkg/database"
+	"github.com/laksanagusta/identity/pkg/errorhelper"
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+// errDryRun rolls back a restructure that was only run to preview its result
+var errDryRun = errors.New("dry run")
+
+// Merge moves the users, sub organizations and organization scoped groups of an organization to the
+// target and archives it. With req.DryRun everything is carried out and rolled back, so the preview
+// is checked against the same rules.
+func (uc *OrganizationUseCase) Merge(ctx context.Context, cred entities.AuthenticatedUser, req dtos.MergeOrganizationReq) (*entities.OrganizationRestructure, error) {
+	var restructure *entities.OrganizationRestructure
+	err := uc.txManager.Atomic(ctx, func(ctx context.Context, tx database.DBTx) error {
+		organizationRepoTrx := uc.organizationRepo.WithTransaction(tx)
+
+		err := organizationRepoTrx.LockTree(ctx)
+		if err != nil {
+			return err
+		}
+
+		source, err := organizationRepoTrx.FindOrganizationNodeByUUID(ctx, req.OrganizationUUID)
+		if err != nil {
+			return err
+		}
+		if source == nil {
+			return errorhelper.BadRequestMap(map[string][]string{
+				"organization_id": {constants.ErrMsgNotFound},
+			})
+		}
+
+		target, err := organizationRepoTrx.FindOrganizationNodeByUUID(ctx, req.TargetUUID)
+		if err != nil {
+			return err
+		}
+		if target == nil {
+			return errorhelper.BadRequestMap(map[string][]string{
+				"target_id": {constants.ErrMsgNotFound},
+			})
+		}
+		if target.IsInSubtreeOf(source.Path.GetOrDefault()) {
+			return errorhelper.BadRequestMap(map[string][]string{
+				"target_id": {constants.ErrMsgOrganizationMergeSubtree},
+			})
+		}
+		if !target.IsActive {
+			return errorhelper.BadRequestMap(map[string][]string{
+				"target_id": {constants.ErrMsgOrganizationInactive},
+			})
+		}
+
+		// A scheduled change would bring the archived organization back into the hierarchy
+		versions, err := organizationRepoTrx.FindOrganizationVersions(ctx, source.UUID)
+		if err != nil {
+			return err
+		}
+		if slices.ContainsFunc(versions, func(version *entities.OrganizationVersion) bool { return version.AppliedAt == nil }) {
+			return errorhelper.BadRequestMap(map[string][]string{
+				"organization_id": {constants.ErrMsgOrganizationVersionPending},
+			})
+		}
+
+		userUUIDs, err := organizationRepoTrx.FindMemberUUIDs(ctx, source.UUID)
+		if err != nil {
+			return err
+		}
+
+		children, err := organizationRepoTrx.FindDescendants(ctx, source.UUID, nullable.NewInt32(1))
+		if err != nil {
+			return err
+		}
+
+		restructure, err = uc.reassign(ctx, organizationRepoTrx, source.UUID, target.UUID, userUUIDs, children, cred.Username)
+		if err != nil {
+			return err
+		}
+
+		groups, err := organizationRepoTrx.FindGroupsByOrganizationFilter(ctx, source.UUID)
+		if err != nil {
+			return err
+		}
+
+		for _, group := range groups {
+			if !group.Filters.ReplaceOrganization(source.UUID, target.UUID) {
+				continue
+			}
+
+			group.UpdateModel(cred.Username)
+			err = organizationRepoTrx.UpdateGroupFilters(ctx, *group)
+			if err != nil {
+				return err
+			}
+			restructure.GroupUUIDs = append(restructure.GroupUUIDs, group.UUID)
+		}
+
+		source.IsActive = false
+		source.UpdateModel(cred.Username)
+		err = organizationRepoTrx.UpdateAttributes(ctx, *source)
+		if err != nil {
+			return err
+		}
+
+		_, err = uc.saveVersions(ctx, organizationRepoTrx, appliedVersions(*source, cred.Username, entities.OrganizationAttributeIsActive))
+		if err != nil {
+			return err
+		}
+
+		err = organizationRepoTrx.InsertAuditLog(ctx, entities.NewAuditLog(
+			cred.Username,
+			entities.AuditActionOrganizationMerged,
+			entities.AuditEntityOrganization,
+			source.UUID,
+			restructure.AuditMetadata(),
+		))
+		if err != nil {
+			return err
+		}
+
+		if req.DryRun {
+			return errDryRun
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+
+	return restructure, nil
+}
+
+// Split moves the given users and sub organizations of an organization into a new sibling. With
+// req.DryRun everything is carried out and rolled back, the returned sibling is not kept.
+func (uc *OrganizationUseCase) Split(ctx context.Context, cred entities.AuthenticatedUser, req dtos.SplitOrganizationReq) (*entities.OrganizationRestructure, error) {
+	var restructure *entities.OrganizationRestructure
+	err := uc.txManager.Atomic(ctx, func(ctx context.Context, tx database.DBTx) error {
+		organizationRepoTrx := uc.organizationRepo.WithTransaction(tx)
+
+		err := organizationRepoTrx.LockTree(ctx)
+		if err != nil {
+			return err
+		}
+
+		source, err := organizationRepoTrx.FindOrganizationNodeByUUID(ctx, req.OrganizationUUID)
+		if err != nil {
+			return err
+		}
+		if source == nil {
+			return errorhelper.BadRequestMap(map[string][]string{
+				"organization_id": {constants.ErrMsgNotFound},
+			})
+		}
+
+		memberUUIDs, err := organizationRepoTrx.FindMemberUUIDs(ctx, source.UUID)
+		if err != nil {
+			return err
+		}
+
+		sourceChildren, err := organizationRepoTrx.FindDescendants(ctx, source.UUID, nullable.NewInt32(1))
+		if err != nil {
+			return err
+		}
+
+		errs := map[string][]string{}
+		for _, userUUID := range req.UserUUIDs {
+			if !slices.Contains(memberUUIDs, userUUID) {
+				errs["user_ids"] = []string{constants.ErrMsgOrganizationNotMember}
+				break
+			}
+		}
+
+		children := make([]*entities.Organization, 0, len(req.ChildUUIDs))
+		for _, childUUID := range req.ChildUUIDs {
+			index := slices.IndexFunc(sourceChildren, func(child *entities.Organization) bool { return child.UUID == childUUID })
+			if index < 0 {
+				errs["child_ids"] = []string{constants.ErrMsgOrganizationNotChild}
+				break
+			}
+			children = append(children, sourceChildren[index])
+		}
+
+		if len(errs) > 0 {
+			return errorhelper.BadRequestMap(errs)
+		}
+
+		sibling := req.NewOrganization(cred, source.ParentUUID)
+		if !sibling.Type.IsNotEmpty() {
+			sibling.Type = source.Type
+		}
+
+		siblingUUID, err := uc.insertOrganization(ctx, organizationRepoTrx, sibling, cred.Username)
+		if err != nil {
+			return err
+		}
+
+		restructure, err = uc.reassign(ctx, organizationRepoTrx, source.UUID, siblingUUID, slices.Compact(slices.Sorted(slices.Values(req.UserUUIDs))), children, cred.Username)
+		if err != nil {
+			return err
+		}
+
+		err = organizationRepoTrx.InsertAuditLog(ctx, entities.NewAuditLog(
+			cred.Username,
+			entities.AuditActionOrganizationSplit,
+			entities.AuditEntityOrganization,
+			source.UUID,
+			restructure.AuditMetadata(),
+		))
+		if err != nil {
+			return err
+		}
+
+		if req.DryRun {
+			return errDryRun
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+
+	return restructure, nil
+}
+
+// reassign moves userUUIDs and the sub organizations children of sourceUUID to targetUUID and records
+// the new parents in the organization history
+func (uc *OrganizationUseCase) reassign(ctx context.Context, repo organization.Repository, sourceUUID string, targetUUID string, userUUIDs []string, children []*entities.Organization, username string) (*entities.OrganizationRestructure, error) {
+	restructure := &entities.OrganizationRestructure{
+		SourceUUID: sourceUUID,
+		TargetUUID: targetUUID,
+		UserUUIDs:  userUUIDs,
+		Children:   []entities.OrganizationPathChange{},
+		GroupUUIDs: []string{},
+	}
+
+	if len(userUUIDs) > 0 {
+		err := repo.ReassignUsers(ctx, sourceUUID, targetUUID, userUUIDs, username, time.Now())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, child := range children {
+		changes, err := uc.moveOrganization(ctx, repo, child, nullable.NewString(targetUUID), username, false)
+		if err != nil {
+			return nil, err
+		}
+		restructure.Children = append(restructure.Children, changes...)
+
+		_, err = uc.saveVersions(ctx, repo, appliedVersions(*child, username, entities.OrganizationAttributeParent))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return restructure, nil
+}
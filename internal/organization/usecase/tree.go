@@ -0,0 +1,219 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/laksanagusta/identity/constants"
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/organization"
+	"github.com/laksanagusta/identity/internal/organization/dtos"
+	"github.com/laksanagusta/identity/pkg/errorhelper"
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+// Ancestors returns the organizations above req.OrganizationUUID, root first. With req.IncludeSelf
+// the organization itself closes the list, which is the breadcrumb of the organization.
+func (uc *OrganizationUseCase) Ancestors(ctx context.Context, cred entities.AuthenticatedUser, req dtos.OrganizationTreeReq) ([]*entities.Organization, error) {
+	hierarchy, err := uc.hierarchy(ctx, req.AsOfDate())
+	if err != nil {
+		return nil, err
+	}
+
+	organization, err := hierarchy.node(ctx, req.OrganizationUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	ancestors, err := hierarchy.ancestors(ctx, organization.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.IncludeSelf {
+		ancestors = append(ancestors, organization)
+	}
+
+	if req.IncludeUserCount {
+		err = uc.setUserCounts(ctx, ancestors)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ancestors, nil
+}
+
+// Children returns the direct sub organizations of req.OrganizationUUID
+func (uc *OrganizationUseCase) Children(ctx context.Context, cred entities.AuthenticatedUser, req dtos.OrganizationTreeReq) ([]*entities.Organization, error) {
+	hierarchy, err := uc.hierarchy(ctx, req.AsOfDate())
+	if err != nil {
+		return nil, err
+	}
+
+	organization, err := hierarchy.node(ctx, req.OrganizationUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	children, err := hierarchy.descendants(ctx, organization.UUID, nullable.NewInt32(1))
+	if err != nil {
+		return nil, err
+	}
+
+	if req.IncludeUserCount {
+		err = uc.setUserCounts(ctx, children)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return children, nil
+}
+
+// Descendants returns req.OrganizationUUID with its sub organizations nested at most req.MaxDepth
+// levels deep, 0 loads the whole subtree
+func (uc *OrganizationUseCase) Descendants(ctx context.Context, cred entities.AuthenticatedUser, req dtos.OrganizationTreeReq) (*entities.Organization, error) {
+	hierarchy, err := uc.hierarchy(ctx, req.AsOfDate())
+	if err != nil {
+		return nil, err
+	}
+
+	organization, err := hierarchy.node(ctx, req.OrganizationUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	var maxDepth nullable.NullInt32
+	if req.MaxDepth > 0 {
+		maxDepth = nullable.NewInt32(int32(req.MaxDepth))
+	}
+
+	descendants, err := hierarchy.descendants(ctx, organization.UUID, maxDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.IncludeUserCount {
+		err = uc.setUserCounts(ctx, append([]*entities.Organization{organization}, descendants...))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	organization.AttachDescendants(descendants)
+
+	return organization, nil
+}
+
+// organizationHierarchy navigates the organizations either as they are today or as of another date
+type organizationHierarchy interface {
+	node(ctx context.Context, uuid string) (*entities.Organization, error)
+	ancestors(ctx context.Context, uuid string) ([]*entities.Organization, error)
+	descendants(ctx context.Context, uuid string, maxDepth nullable.NullInt32) ([]*entities.Organization, error)
+}
+
+// hierarchy returns the organizations of today, or of asOf when it is set
+func (uc *OrganizationUseCase) hierarchy(ctx context.Context, asOf nullable.NullTime) (organizationHierarchy, error) {
+	if !asOf.IsExists {
+		return currentHierarchy{repo: uc.organizationRepo}, nil
+	}
+
+	tree, err := organizationTreeAsOf(ctx, uc.organizationRepo, asOf.GetOrDefault())
+	if err != nil {
+		return nil, err
+	}
+
+	return datedHierarchy{tree: tree}, nil
+}
+
+func organizationTreeAsOf(ctx context.Context, repo organization.Repository, asOf time.Time) (entities.OrganizationTree, error) {
+	organizations, err := repo.FindOrganizationsAsOf(ctx, asOf)
+	if err != nil {
+		return entities.OrganizationTree{}, err
+	}
+
+	return entities.NewOrganizationTree(organizations), nil
+}
+
+type currentHierarchy struct {
+	repo organization.Repository
+}
+
+func (h currentHierarchy) node(ctx context.Context, uuid string) (*entities.Organization, error) {
+	organization, err := h.repo.FindOrganizationNodeByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	if organization == nil {
+		return nil, errorhelper.BadRequestMap(map[string][]string{
+			"organization_id": {constants.ErrMsgNotFound},
+		})
+	}
+
+	return organization, nil
+}
+
+func (h currentHierarchy) ancestors(ctx context.Context, uuid string) ([]*entities.Organization, error) {
+	return h.repo.FindAncestors(ctx, uuid)
+}
+
+func (h currentHierarchy) descendants(ctx context.Context, uuid string, maxDepth nullable.NullInt32) ([]*entities.Organization, error) {
+	return h.repo.FindDescendants(ctx, uuid, maxDepth)
+}
+
+type datedHierarchy struct {
+	tree entities.OrganizationTree
+}
+
+func (h datedHierarchy) node(ctx context.Context, uuid string) (*entities.Organization, error) {
+	organization := h.tree.Node(uuid)
+	if organization == nil {
+		return nil, errorhelper.BadRequestMap(map[string][]string{
+			"organization_id": {constants.ErrMsgOrganizationNotExistsAsOf},
+		})
+	}
+
+	return organization, nil
+}
+
+func (h datedHierarchy) ancestors(ctx context.Context, uuid string) ([]*entities.Organization, error) {
+	return h.tree.Ancestors(uuid), nil
+}
+
+func (h datedHierarchy) descendants(ctx context.Context, uuid string, maxDepth nullable.NullInt32) ([]*entities.Organization, error) {
+	return h.tree.Descendants(uuid, maxDepth.GetOrDefault()), nil
+}
+
+// showAsOf loads an organization with its parent and its sub organizations as of asOf
+func (uc *OrganizationUseCase) showAsOf(ctx context.Context, uuid string, asOf time.Time) (*entities.Organization, error) {
+	tree, err := organizationTreeAsOf(ctx, uc.organizationRepo, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	organization, err := datedHierarchy{tree: tree}.node(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	organization.AttachDescendants(tree.Descendants(uuid, 0))
+	if organization.ParentUUID.IsNotEmpty() {
+		organization.Parent = tree.Node(organization.ParentUUID.GetOrDefault())
+	}
+
+	return organization, nil
+}
+
+func (uc *OrganizationUseCase) setUserCounts(ctx context.Context, organizations []*entities.Organization) error {
+	counts, err := uc.organizationRepo.CountUsersByOrganizationUUIDs(ctx, entities.Organizations(organizations).Uuids())
+	if err != nil {
+		return err
+	}
+
+	for _, organization := range organizations {
+		organization.UserCount = nullable.NewInt64(counts[organization.UUID])
+	}
+
+	return nil
+}
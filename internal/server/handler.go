@@ -1,6 +1,9 @@
 package server
 
 import (
+	"context"
+	"time"
+
 	"github.com/laksanagusta/identity/internal/middleware"
 	organizationhandler "github.com/laksanagusta/identity/internal/organization/delivery/http/api/v1"
 	organizationrepository "github.com/laksanagusta/identity/internal/organization/repository"
@@ -10,12 +13,13 @@ import (
 	userusecase "github.com/laksanagusta/identity/internal/user/usecase"
 
 	"github.com/laksanagusta/identity/pkg/authservice/jwt"
+	"github.com/laksanagusta/identity/pkg/blobstorage"
 	"github.com/laksanagusta/identity/pkg/database"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func (s *Server) MapHandlers() error {
+func (s *Server) MapHandlers(ctx context.Context) error {
 	check := s.Fiber.Group("/check")
 	check.Get("/health", func(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -35,6 +39,16 @@ func (s *Server) MapHandlers() error {
 
 	txManager := database.NewManager(s.DB)
 
+	storage, err := blobstorage.New(s.Config.Storage)
+	if err != nil {
+		return err
+	}
+
+	// local blobs (avatars) are served straight from disk
+	if localStorage, ok := storage.(*blobstorage.LocalStorage); ok {
+		s.Fiber.Static(blobstorage.LocalMountPath, localStorage.Root(), fiber.Static{MaxAge: 31536000})
+	}
+
 	apiExternalV1.Use(middleware.APIKeyMiddleware(s.Config))
 
 	apiV1.Use(middleware.AuthMiddleware(s.Config, userRepo))
@@ -43,6 +57,9 @@ func (s *Server) MapHandlers() error {
 		UserRepo:         userRepo,
 		JwtAuth:          authService,
 		OrganizationRepo: organizationRepo,
+		TxManager:        txManager,
+		Storage:          storage,
+		AuthConfig:       s.Config.Auth,
 	})
 
 	organizationUseCase := organizationusecase.NewOrganizationUseCase(organizationusecase.UseCaseParameter{
@@ -50,6 +67,46 @@ func (s *Server) MapHandlers() error {
 		TxManager:        txManager,
 		UserUC:           userUseCase,
 	})
+
+	// Scheduled restructures take effect once their valid_from day has come
+	go s.runPeriodically(ctx, "apply organization versions", s.Config.Jobs.OrganizationVersionInterval, func(ctx context.Context) error {
+		applied, err := organizationUseCase.ApplyDueOrganizationVersions(ctx, time.Now())
+		if applied > 0 {
+			s.Logger.Infof("Applied %d scheduled organization versions", applied)
+		}
+		return err
+	})
+
+	go s.runPeriodically(ctx, "run user imports", s.Config.Jobs.UserImportInterval, func(ctx context.Context) error {
+		finished, err := userUseCase.RunUserImportJobs(ctx)
+		if finished > 0 {
+			s.Logger.Infof("Finished %d user imports", finished)
+		}
+		return err
+	})
+
+	// Every instance is notified of the change events written by any of them, and wakes its streams
+	go func() {
+		if err := database.Listen(ctx, s.Config, "change_events", userUseCase.NotifyChangeEvent); err != nil {
+			s.Logger.Errorw("Listening for change events failed", "error", err)
+		}
+	}()
+
+	// An event committed behind a longer running transaction is readable only after the notification
+	go s.runPeriodically(ctx, "wake change streams", s.Config.Jobs.ChangeStreamInterval, func(ctx context.Context) error {
+		userUseCase.NotifyChangeEvent()
+		return nil
+	})
+
+	// Consumers that fall further behind than the retention get a resync required error
+	go s.runPeriodically(ctx, "purge change events", s.Config.Jobs.ChangeEventRetentionInterval, func(ctx context.Context) error {
+		purged, err := userUseCase.PurgeChangeEvents(ctx, time.Now().Add(-s.Config.Jobs.ChangeEventRetention))
+		if purged > 0 {
+			s.Logger.Infof("Purged %d change events", purged)
+		}
+		return err
+	})
+
 	userHandler := userhandler.NewUserHandler(s.Config, userUseCase)
 	userhandler.MapUser(apiV1, apiPublicV1, userHandler)
 
@@ -0,0 +1,25 @@
+package server
+
+import (
+	"context"
+	"time"
+)
+
+// runPeriodically calls job every interval until ctx is done, a failing run is logged and retried on
+// the next tick
+func (s *Server) runPeriodically(ctx context.Context, name string, interval time.Duration, job func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := job(ctx); err != nil {
+			s.Logger.Errorw("Background job failed", "job", name, "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
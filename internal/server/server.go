@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
@@ -74,8 +75,12 @@ func (s *Server) Run() error {
 	// Swagger Handler
 	// s.Fiber.Get("/swagger/*", swagger.HandlerDefault)
 
+	// Background jobs stop with the server
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	// Map App Handlers
-	err := s.MapHandlers()
+	err := s.MapHandlers(ctx)
 	if err != nil {
 		return err
 	}
@@ -86,6 +91,7 @@ func (s *Server) Run() error {
 	signal.Notify(quit, syscall.SIGINT)
 	go func() {
 		<-quit
+		cancel()
 		s.Fiber.Shutdown()
 	}()
 
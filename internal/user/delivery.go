@@ -10,12 +10,34 @@ type Handlers interface {
 	Update(c *fiber.Ctx) error
 	Show(c *fiber.Ctx) error
 	Login(c *fiber.Ctx) error
+	DiscoverLogin(c *fiber.Ctx) error
 	Whoami(c *fiber.Ctx) error
 	Index(c *fiber.Ctx) error
+	Suggest(c *fiber.Ctx) error
 	Delete(c *fiber.Ctx) error
 	ChangePassword(c *fiber.Ctx) error
 	ApproveUser(c *fiber.Ctx) error
 	RejectUser(c *fiber.Ctx) error
+	UploadAvatar(c *fiber.Ctx) error
+	DeleteAvatar(c *fiber.Ctx) error
+	AvatarSVG(c *fiber.Ctx) error
+	ExportUser(c *fiber.Ctx) error
+	BulkExport(c *fiber.Ctx) error
+	StreamChanges(c *fiber.Ctx) error
+	EraseUser(c *fiber.Ctx) error
+	UpdateManager(c *fiber.Ctx) error
+	DirectReports(c *fiber.Ctx) error
+	ManagementChain(c *fiber.Ctx) error
+	SwitchOrganization(c *fiber.Ctx) error
+	ImportUsers(c *fiber.Ctx) error
+	IndexUserImportJob(c *fiber.Ctx) error
+	ShowUserImportJob(c *fiber.Ctx) error
+
+	// position
+	IndexUserPosition(c *fiber.Ctx) error
+	CreateUserPosition(c *fiber.Ctx) error
+	UpdateUserPosition(c *fiber.Ctx) error
+	DeleteUserPosition(c *fiber.Ctx) error
 
 	// role
 	Role(c *fiber.Ctx) error
@@ -35,6 +57,27 @@ type Handlers interface {
 	CreatePermission(c *fiber.Ctx) error
 	IndexPermission(c *fiber.Ctx) error
 
+	// user-attribute
+	IndexUserAttribute(c *fiber.Ctx) error
+	CreateUserAttribute(c *fiber.Ctx) error
+	UpdateUserAttribute(c *fiber.Ctx) error
+	DeleteUserAttribute(c *fiber.Ctx) error
+
+	// group
+	IndexGroup(c *fiber.Ctx) error
+	ShowGroup(c *fiber.Ctx) error
+	CreateGroup(c *fiber.Ctx) error
+	UpdateGroup(c *fiber.Ctx) error
+	DeleteGroup(c *fiber.Ctx) error
+	PreviewGroup(c *fiber.Ctx) error
+	GroupMembers(c *fiber.Ctx) error
+	IsGroupMember(c *fiber.Ctx) error
+	IndexGroupMember(c *fiber.Ctx) error
+	CreateGroupMember(c *fiber.Ctx) error
+	DeleteGroupMember(c *fiber.Ctx) error
+	CreateGroupRole(c *fiber.Ctx) error
+	DeleteGroupRole(c *fiber.Ctx) error
+
 	// role-permissions
 	CreateRolePermission(c *fiber.Ctx) error
 	DeleteRolePermission(c *fiber.Ctx) error
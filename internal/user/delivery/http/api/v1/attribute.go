@@ -0,0 +1,105 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/middleware"
+	"github.com/laksanagusta/identity/internal/user/dtos"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func (h *userHandler) IndexUserAttribute(c *fiber.Ctx) error {
+	definitions, err := h.userUc.IndexUserAttribute(c.Context())
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: dtos.NewListUserAttributeRes(definitions)})
+}
+
+func (h *userHandler) CreateUserAttribute(c *fiber.Ctx) error {
+	var createUserAttributeReq dtos.CreateUserAttributeReq
+	err := c.BodyParser(&createUserAttributeReq)
+	if err != nil {
+		return err
+	}
+
+	err = createUserAttributeReq.Validate()
+	if err != nil {
+		return err
+	}
+
+	// Safely get authenticated user
+	cred, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	uuid, err := h.userUc.CreateUserAttribute(
+		c.Context(),
+		createUserAttributeReq.NewUserAttributeDefinition(*cred),
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: map[string]string{"id": uuid}})
+}
+
+func (h *userHandler) UpdateUserAttribute(c *fiber.Ctx) error {
+	var updateUserAttributeReq dtos.UpdateUserAttributeReq
+	err := c.ParamsParser(&updateUserAttributeReq)
+	if err != nil {
+		return err
+	}
+
+	err = c.BodyParser(&updateUserAttributeReq)
+	if err != nil {
+		return err
+	}
+
+	err = updateUserAttributeReq.Validate()
+	if err != nil {
+		return err
+	}
+
+	// Safely get authenticated user
+	cred, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	err = h.userUc.UpdateUserAttribute(
+		c.Context(),
+		*cred,
+		updateUserAttributeReq,
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(http.StatusOK)
+}
+
+func (h *userHandler) DeleteUserAttribute(c *fiber.Ctx) error {
+	var params struct {
+		AttributeUUID string `params:"attributeUUID"`
+	}
+
+	err := c.ParamsParser(&params)
+	if err != nil {
+		return err
+	}
+
+	err = h.userUc.DeleteUserAttribute(
+		c.Context(),
+		params.AttributeUUID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(http.StatusOK)
+}
@@ -0,0 +1,163 @@
+package v1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/laksanagusta/identity/constants"
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/middleware"
+	"github.com/laksanagusta/identity/internal/user/dtos"
+	"github.com/laksanagusta/identity/pkg/errorhelper"
+	"github.com/laksanagusta/identity/pkg/helper"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// UploadAvatar accepts a multipart form with the image in the "avatar" field
+func (h *userHandler) UploadAvatar(c *fiber.Ctx) error {
+	var req dtos.UploadAvatarReq
+	err := c.ParamsParser(&req)
+	if err != nil {
+		return err
+	}
+
+	fileHeader, err := c.FormFile("avatar")
+	if err != nil {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"avatar": {constants.ErrMsgUnsupportedImage},
+		})
+	}
+	if fileHeader.Size > dtos.MaxAvatarSize {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"avatar": {constants.ErrMsgImageTooLarge},
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	req.Data, err = io.ReadAll(io.LimitReader(file, dtos.MaxAvatarSize+1))
+	if err != nil {
+		return err
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return err
+	}
+
+	// Safely get authenticated user
+	authUser, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	avatarURLs, err := h.userUc.UploadAvatar(
+		c.Context(),
+		*authUser,
+		req,
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{
+		Data: map[string]entities.AvatarURLs{"avatar_urls": avatarURLs},
+	})
+}
+
+func (h *userHandler) DeleteAvatar(c *fiber.Ctx) error {
+	var params struct {
+		UserUUID string `params:"userUUID"`
+	}
+
+	err := c.ParamsParser(&params)
+	if err != nil {
+		return err
+	}
+
+	// Safely get authenticated user
+	authUser, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	err = h.userUc.DeleteAvatar(
+		c.Context(),
+		*authUser,
+		params.UserUUID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(http.StatusOK)
+}
+
+// AvatarSVG renders the user's initials on their stored gradient, it is public so other
+// services can embed it with a plain <img src>. Unknown users get a placeholder with a 404.
+func (h *userHandler) AvatarSVG(c *fiber.Ctx) error {
+	var req dtos.AvatarSVGReq
+	err := c.ParamsParser(&req)
+	if err != nil {
+		return err
+	}
+
+	err = c.QueryParser(&req)
+	if err != nil {
+		return err
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return err
+	}
+
+	status := http.StatusNotFound
+	opts := helper.AvatarSVGOptions{
+		Size:  req.Size,
+		Shape: req.Shape,
+		Seed:  req.UserUUID,
+	}
+
+	if req.HasValidUserUUID() {
+		user, err := h.userUc.ShowAvatar(c.Context(), req.UserUUID)
+		if err != nil {
+			return err
+		}
+
+		if user != nil {
+			status = http.StatusOK
+			opts.Initials = helper.Initials(user.FirstName.GetOrDefault(), user.LastName.GetOrDefault())
+			opts.GradientStart = user.AvatarGradientStart.GetOrDefault()
+			opts.GradientEnd = user.AvatarGradientEnd.GetOrDefault()
+		}
+	}
+
+	svg := helper.RenderAvatarSVG(opts)
+	sum := sha256.Sum256([]byte(svg))
+	etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+
+	c.Set(fiber.HeaderContentType, "image/svg+xml; charset=utf-8")
+	c.Set(fiber.HeaderETag, etag)
+
+	if status != http.StatusOK {
+		c.Set(fiber.HeaderCacheControl, "public, max-age=60")
+		return c.Status(status).SendString(svg)
+	}
+
+	c.Set(fiber.HeaderCacheControl, "public, max-age=3600, stale-while-revalidate=86400")
+
+	if match := c.Get(fiber.HeaderIfNoneMatch); match != "" && (match == "*" || strings.Contains(match, etag)) {
+		return c.SendStatus(http.StatusNotModified)
+	}
+
+	return c.Status(status).SendString(svg)
+}
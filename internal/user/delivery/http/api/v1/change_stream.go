@@ -0,0 +1,129 @@
+package v1
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/middleware"
+	"github.com/laksanagusta/identity/internal/user/dtos"
+	"github.com/laksanagusta/identity/internal/user/dtos/external"
+	"github.com/laksanagusta/identity/pkg/sse"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// changeStreamHeartbeat keeps idle streams from being closed by proxies
+	changeStreamHeartbeat = 15 * time.Second
+	// changeStreamMaxAge ends streams so clients reconnect and are authenticated again, they resume with
+	// Last-Event-ID and miss nothing
+	changeStreamMaxAge = 30 * time.Minute
+	changeStreamRetry  = 3 * time.Second
+)
+
+// StreamChanges streams the change feed of the organization subtree of the caller as server-sent events.
+// Every event carries its cursor as ID and is named by its type like user.updated, the data is an item
+// of the external change feed.
+func (h *userHandler) StreamChanges(c *fiber.Ctx) error {
+	authUser, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	var req dtos.StreamChangeReq
+	err = c.QueryParser(&req)
+	if err != nil {
+		return err
+	}
+	if lastEventID := c.Get("Last-Event-ID"); lastEventID != "" {
+		req.LastEventID = lastEventID
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return err
+	}
+
+	params, err := h.userUc.NewChangeStream(c.Context(), *authUser, req)
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, sse.ContentType)
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set("X-Accel-Buffering", "no")
+
+	// The request context is done once the server shuts down, it outlives c while the body is written
+	requestCtx := c.Context()
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		ctx, cancel := context.WithTimeout(requestCtx, changeStreamMaxAge)
+		defer cancel()
+
+		h.streamChanges(ctx, w, params)
+	})
+
+	return nil
+}
+
+// streamChanges writes the events after params.After until ctx is done or the client is gone, and
+// waits for other instances or this one to write more in between
+func (h *userHandler) streamChanges(ctx context.Context, w *bufio.Writer, params entities.ListChangeEventParams) {
+	heartbeat := time.NewTicker(changeStreamHeartbeat)
+	defer heartbeat.Stop()
+
+	// Clients that reconnect before the first event resume from where the stream started
+	err := sse.Write(w, sse.Event{ID: params.After.String(), Retry: changeStreamRetry})
+	if err != nil {
+		return
+	}
+
+	for {
+		changed := h.userUc.WatchChangeEvent()
+
+		for {
+			events, err := h.userUc.StreamChangeEvent(ctx, params)
+			if err != nil {
+				// The client reconnects and resumes after the last event it received
+				log.Printf("change stream failed: %v", err)
+				return
+			}
+
+			for _, event := range events {
+				data, err := json.Marshal(external.NewChangeRes(event))
+				if err != nil {
+					log.Printf("change stream failed: %v", err)
+					return
+				}
+
+				err = sse.Write(w, sse.Event{ID: event.Cursor().String(), Name: event.Name(), Data: string(data)})
+				if err != nil {
+					return
+				}
+				params.After = event.Cursor()
+			}
+
+			if len(events) < params.Limit {
+				break
+			}
+		}
+
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-changed:
+		case <-heartbeat.C:
+			if err := sse.WriteComment(w, "ping"); err != nil {
+				return
+			}
+		}
+	}
+}
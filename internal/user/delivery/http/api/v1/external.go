@@ -1,11 +1,13 @@
 package v1
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/laksanagusta/identity/config"
 	"github.com/laksanagusta/identity/internal/entities"
 	"github.com/laksanagusta/identity/internal/user"
+	"github.com/laksanagusta/identity/internal/user/dtos"
 	"github.com/laksanagusta/identity/internal/user/dtos/external"
 	"github.com/laksanagusta/identity/pkg/pagination"
 
@@ -69,6 +71,12 @@ func (h *ExternalUserHandler) GetUsers(c *fiber.Ctx) error {
 		})
 	}
 
+	// Attributes that are not public can neither be filtered nor sorted on
+	err = h.userUc.CheckUserQuery(c.Context(), params, entities.AttributeVisibilityPublic)
+	if err != nil {
+		return err
+	}
+
 	// Get users from use case
 	users, paginationResp, err := h.userUc.Index(c.Context(), params)
 	if err != nil {
@@ -77,6 +85,14 @@ func (h *ExternalUserHandler) GetUsers(c *fiber.Ctx) error {
 		})
 	}
 
+	// Only public attributes are exposed to external consumers
+	err = h.userUc.FilterUserAttributes(c.Context(), users, entities.AttributeVisibilityPublic)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to fetch users: " + err.Error(),
+		})
+	}
+
 	// Convert to external response
 	userData := external.NewExternalListUserResp(users)
 
@@ -110,8 +126,147 @@ func (h *ExternalUserHandler) GetUser(c *fiber.Ctx) error {
 		})
 	}
 
+	// Only public attributes are exposed to external consumers
+	err = h.userUc.FilterUserAttributes(c.Context(), []*entities.User{user}, entities.AttributeVisibilityPublic)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to fetch user: " + err.Error(),
+		})
+	}
+
 	// Convert to external response and use standard response format
 	userData := external.NewExternalUserRes(*user)
 
 	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: userData})
 }
+
+// GetApprover handles GET /api/v1/external/users/{id}/approver
+// Resolves the active manager that approves requests of the user, ?level=2 returns the skip-level manager
+func (h *ExternalUserHandler) GetApprover(c *fiber.Ctx) error {
+	var req dtos.ApproverReq
+	err := c.ParamsParser(&req)
+	if err != nil {
+		return err
+	}
+
+	err = c.QueryParser(&req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid query parameters: " + err.Error(),
+		})
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Validation error: " + err.Error(),
+		})
+	}
+
+	approver, err := h.userUc.Approver(c.Context(), req)
+	if err != nil {
+		return err
+	}
+
+	if approver == nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Approver not found",
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: dtos.NewReportingLineUserRes(*approver)})
+}
+
+// GetGroupMembers handles GET /api/v1/external/groups/{groupUUID}/members
+// Lists the current members of a dynamic group
+func (h *ExternalUserHandler) GetGroupMembers(c *fiber.Ctx) error {
+	var req dtos.ListGroupMemberReq
+	err := c.ParamsParser(&req)
+	if err != nil {
+		return err
+	}
+
+	err = c.QueryParser(&req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid query parameters: " + err.Error(),
+		})
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Validation error: " + err.Error(),
+		})
+	}
+
+	users, paginationResp, err := h.userUc.GroupMembers(c.Context(), req)
+	if err != nil {
+		return err
+	}
+
+	paginationResp.Data = dtos.NewListReportingLineUserRes(users)
+	return c.JSON(paginationResp)
+}
+
+// CheckGroupMember handles GET /api/v1/external/groups/{groupUUID}/members/{userUUID}
+// Answers whether a user currently belongs to a group
+func (h *ExternalUserHandler) CheckGroupMember(c *fiber.Ctx) error {
+	var req dtos.GroupMemberReq
+	err := c.ParamsParser(&req)
+	if err != nil {
+		return err
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Validation error: " + err.Error(),
+		})
+	}
+
+	isMember, err := h.userUc.IsGroupMember(c.Context(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: dtos.GroupMembershipRes{
+		GroupUUID: req.GroupUUID,
+		UserUUID:  req.UserUUID,
+		IsMember:  isMember,
+	}})
+}
+
+// GetChanges handles GET /api/v1/external/changes
+// Returns the users, organizations, roles and role assignments created, updated or deleted after ?cursor=,
+// consumers store next_cursor and poll again for incremental synchronization
+func (h *ExternalUserHandler) GetChanges(c *fiber.Ctx) error {
+	var req external.ListChangeReq
+	err := c.QueryParser(&req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid query parameters: " + err.Error(),
+		})
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Validation error: " + err.Error(),
+		})
+	}
+
+	events, err := h.userUc.IndexChangeEvent(c.Context(), req.NewListChangeEventParams())
+	if errors.Is(err, entities.ErrChangeResyncRequired) {
+		return c.Status(fiber.StatusGone).JSON(fiber.Map{
+			"error": "Resync required: the changes after this cursor are no longer retained",
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to fetch changes: " + err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(external.NewListChangeRes(req, events))
+}
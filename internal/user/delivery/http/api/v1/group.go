@@ -0,0 +1,306 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/middleware"
+	"github.com/laksanagusta/identity/internal/user/dtos"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func (h *userHandler) IndexGroup(c *fiber.Ctx) error {
+	groups, err := h.userUc.IndexGroup(c.Context())
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: dtos.NewListGroupRes(groups)})
+}
+
+func (h *userHandler) ShowGroup(c *fiber.Ctx) error {
+	var params struct {
+		GroupUUID string `params:"groupUUID"`
+	}
+
+	err := c.ParamsParser(&params)
+	if err != nil {
+		return err
+	}
+
+	group, err := h.userUc.ShowGroup(c.Context(), params.GroupUUID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: dtos.NewGroupRes(*group)})
+}
+
+func (h *userHandler) CreateGroup(c *fiber.Ctx) error {
+	var createGroupReq dtos.CreateGroupReq
+	err := c.BodyParser(&createGroupReq)
+	if err != nil {
+		return err
+	}
+
+	err = createGroupReq.Validate()
+	if err != nil {
+		return err
+	}
+
+	cred, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	uuid, err := h.userUc.CreateGroup(c.Context(), createGroupReq.NewGroup(*cred))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: map[string]string{"id": uuid}})
+}
+
+func (h *userHandler) UpdateGroup(c *fiber.Ctx) error {
+	var updateGroupReq dtos.UpdateGroupReq
+	err := c.ParamsParser(&updateGroupReq)
+	if err != nil {
+		return err
+	}
+
+	err = c.BodyParser(&updateGroupReq)
+	if err != nil {
+		return err
+	}
+
+	err = updateGroupReq.Validate()
+	if err != nil {
+		return err
+	}
+
+	cred, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	err = h.userUc.UpdateGroup(c.Context(), *cred, updateGroupReq)
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(http.StatusOK)
+}
+
+func (h *userHandler) DeleteGroup(c *fiber.Ctx) error {
+	var params struct {
+		GroupUUID string `params:"groupUUID"`
+	}
+
+	err := c.ParamsParser(&params)
+	if err != nil {
+		return err
+	}
+
+	err = h.userUc.DeleteGroup(c.Context(), params.GroupUUID)
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(http.StatusOK)
+}
+
+func (h *userHandler) PreviewGroup(c *fiber.Ctx) error {
+	var previewGroupReq dtos.PreviewGroupReq
+	err := c.QueryParser(&previewGroupReq)
+	if err != nil {
+		return err
+	}
+
+	err = c.BodyParser(&previewGroupReq)
+	if err != nil {
+		return err
+	}
+
+	err = previewGroupReq.Validate()
+	if err != nil {
+		return err
+	}
+
+	users, pagination, err := h.userUc.PreviewGroup(c.Context(), previewGroupReq)
+	if err != nil {
+		return err
+	}
+
+	pagination.Data = dtos.NewListReportingLineUserRes(users)
+
+	return c.JSON(pagination)
+}
+
+func (h *userHandler) GroupMembers(c *fiber.Ctx) error {
+	var listGroupMemberReq dtos.ListGroupMemberReq
+	err := c.ParamsParser(&listGroupMemberReq)
+	if err != nil {
+		return err
+	}
+
+	err = c.QueryParser(&listGroupMemberReq)
+	if err != nil {
+		return err
+	}
+
+	err = listGroupMemberReq.Validate()
+	if err != nil {
+		return err
+	}
+
+	users, pagination, err := h.userUc.GroupMembers(c.Context(), listGroupMemberReq)
+	if err != nil {
+		return err
+	}
+
+	pagination.Data = dtos.NewListReportingLineUserRes(users)
+
+	return c.JSON(pagination)
+}
+
+func (h *userHandler) IsGroupMember(c *fiber.Ctx) error {
+	var groupMemberReq dtos.GroupMemberReq
+	err := c.ParamsParser(&groupMemberReq)
+	if err != nil {
+		return err
+	}
+
+	err = groupMemberReq.Validate()
+	if err != nil {
+		return err
+	}
+
+	isMember, err := h.userUc.IsGroupMember(c.Context(), groupMemberReq)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: dtos.GroupMembershipRes{
+		GroupUUID: groupMemberReq.GroupUUID,
+		UserUUID:  groupMemberReq.UserUUID,
+		IsMember:  isMember,
+	}})
+}
+
+func (h *userHandler) IndexGroupMember(c *fiber.Ctx) error {
+	var params struct {
+		GroupUUID string `params:"groupUUID"`
+	}
+
+	err := c.ParamsParser(&params)
+	if err != nil {
+		return err
+	}
+
+	members, err := h.userUc.IndexGroupMember(c.Context(), params.GroupUUID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: dtos.NewListGroupMemberRes(members)})
+}
+
+func (h *userHandler) CreateGroupMember(c *fiber.Ctx) error {
+	var createGroupMemberReq dtos.CreateGroupMemberReq
+	err := c.ParamsParser(&createGroupMemberReq)
+	if err != nil {
+		return err
+	}
+
+	err = c.BodyParser(&createGroupMemberReq)
+	if err != nil {
+		return err
+	}
+
+	err = createGroupMemberReq.Validate()
+	if err != nil {
+		return err
+	}
+
+	cred, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	err = h.userUc.CreateGroupMember(c.Context(), createGroupMemberReq.NewGroupMember(*cred))
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(http.StatusOK)
+}
+
+func (h *userHandler) DeleteGroupMember(c *fiber.Ctx) error {
+	var params struct {
+		GroupUUID  string `params:"groupUUID"`
+		MemberUUID string `params:"memberUUID"`
+	}
+
+	err := c.ParamsParser(&params)
+	if err != nil {
+		return err
+	}
+
+	err = h.userUc.DeleteGroupMember(c.Context(), params.GroupUUID, params.MemberUUID)
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(http.StatusOK)
+}
+
+func (h *userHandler) CreateGroupRole(c *fiber.Ctx) error {
+	var groupRoleReq dtos.GroupRoleReq
+	err := c.ParamsParser(&groupRoleReq)
+	if err != nil {
+		return err
+	}
+
+	err = c.BodyParser(&groupRoleReq)
+	if err != nil {
+		return err
+	}
+
+	err = groupRoleReq.Validate()
+	if err != nil {
+		return err
+	}
+
+	cred, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	err = h.userUc.CreateGroupRole(c.Context(), groupRoleReq.NewGroupRole(*cred))
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(http.StatusOK)
+}
+
+func (h *userHandler) DeleteGroupRole(c *fiber.Ctx) error {
+	var groupRoleReq dtos.GroupRoleReq
+	err := c.ParamsParser(&groupRoleReq)
+	if err != nil {
+		return err
+	}
+
+	err = groupRoleReq.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = h.userUc.DeleteGroupRole(c.Context(), groupRoleReq)
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(http.StatusOK)
+}
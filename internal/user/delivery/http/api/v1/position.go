@@ -0,0 +1,160 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/middleware"
+	"github.com/laksanagusta/identity/internal/user/dtos"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func (h *userHandler) IndexUserPosition(c *fiber.Ctx) error {
+	var params struct {
+		UserUUID string `params:"userUUID"`
+	}
+
+	err := c.ParamsParser(&params)
+	if err != nil {
+		return err
+	}
+
+	positions, err := h.userUc.IndexUserPosition(c.Context(), params.UserUUID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: dtos.NewListUserPositionRes(positions)})
+}
+
+func (h *userHandler) CreateUserPosition(c *fiber.Ctx) error {
+	var req dtos.CreateUserPositionReq
+	err := c.ParamsParser(&req)
+	if err != nil {
+		return err
+	}
+
+	err = c.BodyParser(&req)
+	if err != nil {
+		return err
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return err
+	}
+
+	// Safely get authenticated user
+	cred, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	uuid, err := h.userUc.CreateUserPosition(
+		c.Context(),
+		*cred,
+		req,
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: map[string]string{"position_id": uuid}})
+}
+
+func (h *userHandler) UpdateUserPosition(c *fiber.Ctx) error {
+	var req dtos.UpdateUserPositionReq
+	err := c.ParamsParser(&req)
+	if err != nil {
+		return err
+	}
+
+	err = c.BodyParser(&req)
+	if err != nil {
+		return err
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return err
+	}
+
+	// Safely get authenticated user
+	cred, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	err = h.userUc.UpdateUserPosition(
+		c.Context(),
+		*cred,
+		req,
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(http.StatusOK)
+}
+
+func (h *userHandler) DeleteUserPosition(c *fiber.Ctx) error {
+	var params struct {
+		UserUUID     string `params:"userUUID"`
+		PositionUUID string `params:"positionUUID"`
+	}
+
+	err := c.ParamsParser(&params)
+	if err != nil {
+		return err
+	}
+
+	// Safely get authenticated user
+	cred, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	err = h.userUc.DeleteUserPosition(
+		c.Context(),
+		*cred,
+		params.UserUUID,
+		params.PositionUUID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(http.StatusOK)
+}
+
+// SwitchOrganization returns a fresh token acting in another organization the caller holds a position in
+func (h *userHandler) SwitchOrganization(c *fiber.Ctx) error {
+	var req dtos.SwitchOrganizationReq
+	err := c.BodyParser(&req)
+	if err != nil {
+		return err
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return err
+	}
+
+	// Safely get authenticated user
+	cred, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	token, err := h.userUc.SwitchOrganization(
+		c.Context(),
+		*cred,
+		req,
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: map[string]string{"token": token}})
+}
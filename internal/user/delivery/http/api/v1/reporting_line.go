@@ -0,0 +1,86 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/middleware"
+	"github.com/laksanagusta/identity/internal/user/dtos"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func (h *userHandler) UpdateManager(c *fiber.Ctx) error {
+	var req dtos.UpdateManagerReq
+	err := c.ParamsParser(&req)
+	if err != nil {
+		return err
+	}
+
+	err = c.BodyParser(&req)
+	if err != nil {
+		return err
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return err
+	}
+
+	// Safely get authenticated user
+	authUser, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	err = h.userUc.UpdateManager(
+		c.Context(),
+		*authUser,
+		req,
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(http.StatusOK)
+}
+
+func (h *userHandler) DirectReports(c *fiber.Ctx) error {
+	var params struct {
+		UserUUID string `params:"userUUID"`
+	}
+
+	err := c.ParamsParser(&params)
+	if err != nil {
+		return err
+	}
+
+	reports, err := h.userUc.DirectReports(c.Context(), params.UserUUID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{
+		Data: dtos.NewListReportingLineUserRes(reports),
+	})
+}
+
+func (h *userHandler) ManagementChain(c *fiber.Ctx) error {
+	var params struct {
+		UserUUID string `params:"userUUID"`
+	}
+
+	err := c.ParamsParser(&params)
+	if err != nil {
+		return err
+	}
+
+	chain, err := h.userUc.ManagementChain(c.Context(), params.UserUUID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{
+		Data: dtos.NewListReportingLineUserRes(chain),
+	})
+}
@@ -9,7 +9,9 @@ import (
 
 func MapUser(routes fiber.Router, public fiber.Router, h user.Handlers) {
 	public.Post("/login", h.Login)
+	public.Post("/login/discover", h.DiscoverLogin)
 	public.Post("/register", h.Create)
+	public.Get("/avatars/:userId.svg", h.AvatarSVG)
 
 	userGroup := routes.Group("/users")
 	userGroup.Delete("/:userUUID", h.Delete)
@@ -17,10 +19,27 @@ func MapUser(routes fiber.Router, public fiber.Router, h user.Handlers) {
 	userGroup.Post("/login", h.Login)
 	userGroup.Patch("/:userUUID", h.Update)
 	userGroup.Get("/whoami", h.Whoami)
+	userGroup.Get("/suggest", h.Suggest)
+	userGroup.Get("/bulk-export", h.BulkExport)
+	userGroup.Post("/switch-organization", h.SwitchOrganization)
+	userGroup.Post("/import", h.ImportUsers)
+	userGroup.Get("/import-jobs", h.IndexUserImportJob)
+	userGroup.Get("/import-jobs/:jobUUID", h.ShowUserImportJob)
 	userGroup.Get("/:userId", h.Show)
 	userGroup.Patch("/:userUUID/change-password", h.ChangePassword)
 	userGroup.Patch("/:userUUID/approve", h.ApproveUser)
 	userGroup.Patch("/:userUUID/reject", h.RejectUser)
+	userGroup.Put("/:userUUID/avatar", h.UploadAvatar)
+	userGroup.Delete("/:userUUID/avatar", h.DeleteAvatar)
+	userGroup.Get("/:userUUID/export", h.ExportUser)
+	userGroup.Post("/:userUUID/erase", h.EraseUser)
+	userGroup.Put("/:userUUID/manager", h.UpdateManager)
+	userGroup.Get("/:userUUID/direct-reports", h.DirectReports)
+	userGroup.Get("/:userUUID/management-chain", h.ManagementChain)
+	userGroup.Get("/:userUUID/positions", h.IndexUserPosition)
+	userGroup.Post("/:userUUID/positions", h.CreateUserPosition)
+	userGroup.Patch("/:userUUID/positions/:positionUUID", h.UpdateUserPosition)
+	userGroup.Delete("/:userUUID/positions/:positionUUID", h.DeleteUserPosition)
 
 	roleGroup := routes.Group("/roles")
 	roleGroup.Get("/", h.Role)
@@ -30,6 +49,8 @@ func MapUser(routes fiber.Router, public fiber.Router, h user.Handlers) {
 	roleGroup.Patch("/:roleUUID", h.UpdateRole)
 	roleGroup.Delete("/:roleUUID", h.DeleteRole)
 
+	routes.Get("/changes/stream", h.StreamChanges)
+
 	userRoleGroup := routes.Group("/user-roles")
 	userRoleGroup.Post("/", h.CreateUserRole)
 	userRoleGroup.Delete("/:userRoleUUID", h.DeleteUserRole)
@@ -40,6 +61,27 @@ func MapUser(routes fiber.Router, public fiber.Router, h user.Handlers) {
 	permissionGroup.Delete(":permissionUUID", h.DeletePermission)
 	permissionGroup.Get("/", h.IndexPermission)
 
+	userAttributeGroup := routes.Group("/user-attributes")
+	userAttributeGroup.Get("/", h.IndexUserAttribute)
+	userAttributeGroup.Post("/", h.CreateUserAttribute)
+	userAttributeGroup.Patch("/:attributeUUID", h.UpdateUserAttribute)
+	userAttributeGroup.Delete("/:attributeUUID", h.DeleteUserAttribute)
+
+	groupGroup := routes.Group("/groups")
+	groupGroup.Get("/", h.IndexGroup)
+	groupGroup.Post("/", h.CreateGroup)
+	groupGroup.Post("/preview", h.PreviewGroup)
+	groupGroup.Get("/:groupUUID", h.ShowGroup)
+	groupGroup.Patch("/:groupUUID", h.UpdateGroup)
+	groupGroup.Delete("/:groupUUID", h.DeleteGroup)
+	groupGroup.Get("/:groupUUID/members", h.GroupMembers)
+	groupGroup.Get("/:groupUUID/members/:userUUID", h.IsGroupMember)
+	groupGroup.Get("/:groupUUID/direct-members", h.IndexGroupMember)
+	groupGroup.Post("/:groupUUID/direct-members", h.CreateGroupMember)
+	groupGroup.Delete("/:groupUUID/direct-members/:memberUUID", h.DeleteGroupMember)
+	groupGroup.Post("/:groupUUID/roles", h.CreateGroupRole)
+	groupGroup.Delete("/:groupUUID/roles/:roleUUID", h.DeleteGroupRole)
+
 	rolePermissionGroup := routes.Group("/role-permissions")
 	rolePermissionGroup.Post("/", h.CreateRolePermission)
 	rolePermissionGroup.Delete("/:rolePermissionUUID", h.DeleteRolePermission)
@@ -51,4 +93,11 @@ func MapExternalUser(routes fiber.Router, h *ExternalUserHandler, cfg config.Con
 	usersGroup := routes.Group("/users")
 	usersGroup.Get("/", h.GetUsers)
 	usersGroup.Get("/:id", h.GetUser)
+	usersGroup.Get("/:id/approver", h.GetApprover)
+
+	routes.Get("/changes", h.GetChanges)
+
+	groupsGroup := routes.Group("/groups")
+	groupsGroup.Get("/:groupUUID/members", h.GetGroupMembers)
+	groupsGroup.Get("/:groupUUID/members/:userUUID", h.CheckGroupMember)
 }
@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/laksanagusta/identity/config"
@@ -117,6 +118,9 @@ func (h *userHandler) Login(c *fiber.Ctx) error {
 		return err
 	}
 
+	login.IPAddress = c.IP()
+	login.UserAgent = c.Get(fiber.HeaderUserAgent)
+
 	token, err := h.userUc.Login(
 		c.Context(),
 		login,
@@ -130,6 +134,24 @@ func (h *userHandler) Login(c *fiber.Ctx) error {
 	return nil
 }
 
+// DiscoverLogin is the first step of identifier-first login, it answers the same for unknown accounts
+func (h *userHandler) DiscoverLogin(c *fiber.Ctx) error {
+	var req dtos.DiscoverLoginReq
+	err := c.BodyParser(&req)
+	if err != nil {
+		return err
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return err
+	}
+
+	res := h.userUc.DiscoverLogin(c.Context(), req)
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: res})
+}
+
 func (h *userHandler) Whoami(c *fiber.Ctx) error {
 	// Safely get authenticated user
 	authUser, err := middleware.GetAuthenticatedUser(c)
@@ -145,7 +167,7 @@ func (h *userHandler) Whoami(c *fiber.Ctx) error {
 		return err
 	}
 
-	response := dtos.NewWhoamiRes(*user, permissionsStr)
+	response := dtos.NewWhoamiRes(*user, permissionsStr, authUser.ActiveOrganization.ID.String())
 
 	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: response})
 }
@@ -202,6 +224,11 @@ func (h *userHandler) Index(c *fiber.Ctx) error {
 		})
 	}
 
+	err = h.userUc.CheckUserQuery(c.Context(), params, entities.AttributeVisibilityPublic, entities.AttributeVisibilityInternal)
+	if err != nil {
+		return err
+	}
+
 	users, pagination, err := h.userUc.Index(c.Context(), params)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -215,6 +242,29 @@ func (h *userHandler) Index(c *fiber.Ctx) error {
 	return c.JSON(pagination)
 }
 
+// Suggest serves autocomplete, it returns the best few matches of ?q= with highlighted fields
+func (h *userHandler) Suggest(c *fiber.Ctx) error {
+	var req dtos.SuggestUserReq
+	err := c.QueryParser(&req)
+	if err != nil {
+		return err
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return err
+	}
+
+	users, err := h.userUc.Suggest(c.Context(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{
+		Data: dtos.NewListSuggestUserRes(users),
+	})
+}
+
 func (h *userHandler) Delete(c *fiber.Ctx) error {
 	var params struct {
 		UserUUID string `params:"userUUID"`
@@ -337,6 +387,86 @@ func (h *userHandler) RejectUser(c *fiber.Ctx) error {
 	})
 }
 
+func (h *userHandler) ExportUser(c *fiber.Ctx) error {
+	var req dtos.ExportUserReq
+
+	err := c.ParamsParser(&req)
+	if err != nil {
+		return err
+	}
+
+	err = c.QueryParser(&req)
+	if err != nil {
+		return err
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return err
+	}
+
+	// Safely get authenticated user
+	authUser, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	export, err := h.userUc.ExportUser(
+		c.Context(),
+		*authUser,
+		req.UserUUID,
+	)
+	if err != nil {
+		return err
+	}
+
+	res := dtos.NewExportUserRes(*export)
+
+	if req.Format == dtos.ExportFormatZip {
+		archive, err := res.Zip()
+		if err != nil {
+			return err
+		}
+
+		c.Set(fiber.HeaderContentType, "application/zip")
+		c.Attachment(fmt.Sprintf("user-%s-export.zip", req.UserUUID))
+
+		return c.Status(http.StatusOK).Send(archive)
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: res})
+}
+
+func (h *userHandler) EraseUser(c *fiber.Ctx) error {
+	var params struct {
+		UserUUID string `params:"userUUID"`
+	}
+
+	err := c.ParamsParser(&params)
+	if err != nil {
+		return err
+	}
+
+	// Safely get authenticated user
+	authUser, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	err = h.userUc.EraseUser(
+		c.Context(),
+		*authUser,
+		params.UserUUID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{
+		Data: map[string]string{"message": "Data user berhasil dianonimkan"},
+	})
+}
+
 func (h *userHandler) CreateRole(c *fiber.Ctx) error {
 	var createRole dtos.CreateRoleReq
 	err := c.BodyParser(&createRole)
@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"bufio"
+	"log"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/user/dtos"
+	"github.com/laksanagusta/identity/pkg/bulkexport"
+	"github.com/laksanagusta/identity/pkg/pagination"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// BulkExport streams every user matching the filters of Index as ?format=csv, xlsx or ndjson. Users are
+// written while they are read from the database, so the export has no page size limit.
+func (h *userHandler) BulkExport(c *fiber.Ctx) error {
+	var req dtos.BulkExportUserReq
+	err := c.QueryParser(&req)
+	if err != nil {
+		return err
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return err
+	}
+
+	queryParams := make(map[string]string)
+	c.Context().QueryArgs().VisitAll(func(key, value []byte) {
+		if string(key) != "format" {
+			queryParams[string(key)] = string(value)
+		}
+	})
+
+	queryParser := &pagination.QueryParser{}
+	params, err := queryParser.Parse(queryParams)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid query parameters: " + err.Error(),
+		})
+	}
+
+	err = h.userUc.CheckUserQuery(c.Context(), params, entities.AttributeVisibilityPublic, entities.AttributeVisibilityInternal)
+	if err != nil {
+		return err
+	}
+
+	users, err := h.userUc.BulkExport(c.Context(), params)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	c.Attachment("users." + req.Format)
+	c.Set(fiber.HeaderContentType, bulkexport.ContentType(req.Format))
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		// The status is already sent, a failure can only cut the file short
+		if err := bulkexport.Write(w, req.Format, dtos.BulkExportUserTable, users); err != nil {
+			log.Printf("bulk export of users failed: %v", err)
+		}
+	})
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package v1
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/laksanagusta/identity/constants"
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/middleware"
+	"github.com/laksanagusta/identity/internal/user/dtos"
+	"github.com/laksanagusta/identity/pkg/errorhelper"
+	"github.com/laksanagusta/identity/pkg/pagination"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ImportUsers accepts a multipart form with a CSV or XLSX file in the "file" field. A dry run answers
+// with the report of every failing row, otherwise the import is queued as a job.
+func (h *userHandler) ImportUsers(c *fiber.Ctx) error {
+	var req dtos.ImportUserReq
+	err := c.QueryParser(&req)
+	if err != nil {
+		return err
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"file": {constants.ErrMsgUserImportFormat},
+		})
+	}
+	if fileHeader.Size > dtos.MaxUserImportSize {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"file": {constants.ErrMsgUserImportTooLarge},
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	req.FileName = fileHeader.Filename
+	req.Data, err = io.ReadAll(io.LimitReader(file, dtos.MaxUserImportSize+1))
+	if err != nil {
+		return err
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = req.ParseRecords()
+	if err != nil {
+		return err
+	}
+
+	if req.DryRun {
+		results, err := h.userUc.CheckUserImport(c.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: dtos.NewUserImportReportRes(results)})
+	}
+
+	// Safely get authenticated user
+	authUser, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	jobUUID, err := h.userUc.ImportUsers(c.Context(), *authUser, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusAccepted).JSON(
+		entities.ResponseData{Data: map[string]any{"id": jobUUID}},
+	)
+}
+
+func (h *userHandler) IndexUserImportJob(c *fiber.Ctx) error {
+	queryParams := make(map[string]string)
+	c.Context().QueryArgs().VisitAll(func(key, value []byte) {
+		queryParams[string(key)] = string(value)
+	})
+
+	queryParser := &pagination.QueryParser{}
+	params, err := queryParser.Parse(queryParams)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid query parameters: " + err.Error(),
+		})
+	}
+
+	jobs, pagination, err := h.userUc.IndexUserImportJob(c.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	pagination.Data = dtos.NewListUserImportJobRes(jobs)
+
+	return c.JSON(pagination)
+}
+
+func (h *userHandler) ShowUserImportJob(c *fiber.Ctx) error {
+	var params struct {
+		JobUUID string `params:"jobUUID"`
+	}
+
+	err := c.ParamsParser(&params)
+	if err != nil {
+		return err
+	}
+
+	job, err := h.userUc.ShowUserImportJob(c.Context(), params.JobUUID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: dtos.NewUserImportJobRes(job)})
+}
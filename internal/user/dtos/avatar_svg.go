@@ -0,0 +1,26 @@
+package dtos
+
+import (
+	"github.com/laksanagusta/identity/pkg/helper"
+
+	"github.com/invopop/validation"
+	"github.com/invopop/validation/is"
+)
+
+type AvatarSVGReq struct {
+	UserUUID string `params:"userId"`
+	Size     int    `query:"size"`
+	Shape    string `query:"shape"`
+}
+
+func (r AvatarSVGReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Size, validation.Min(helper.AvatarMinSize), validation.Max(helper.AvatarMaxSize)),
+		validation.Field(&r.Shape, validation.In(helper.AvatarShapeCircle, helper.AvatarShapeRounded, helper.AvatarShapeSquare)),
+	)
+}
+
+// HasValidUserUUID is checked separately, a malformed id renders the placeholder instead of an error
+func (r AvatarSVGReq) HasValidUserUUID() bool {
+	return validation.Validate(r.UserUUID, validation.Required, is.UUID) == nil
+}
@@ -0,0 +1,118 @@
+package dtos
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/pkg/bulkexport"
+	"github.com/laksanagusta/identity/pkg/nullable"
+
+	"github.com/invopop/validation"
+)
+
+// BulkExportUserReq exports every user matching the filters of Index, it takes the same query parameters
+// besides page and limit
+type BulkExportUserReq struct {
+	Format string `query:"format"`
+}
+
+func (r BulkExportUserReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Format, validation.Required, validation.In(bulkexport.Formats...)),
+	)
+}
+
+type BulkExportUserResOrganization struct {
+	UUID string              `json:"id"`
+	Code nullable.NullString `json:"code"`
+	Name nullable.NullString `json:"name"`
+}
+
+// BulkExportUserRes is a line of an NDJSON export
+type BulkExportUserRes struct {
+	UUID         string                         `json:"id"`
+	EmployeeID   nullable.NullString            `json:"employee_id"`
+	Username     nullable.NullString            `json:"username"`
+	Email        nullable.NullString            `json:"email"`
+	FirstName    nullable.NullString            `json:"first_name"`
+	LastName     nullable.NullString            `json:"last_name"`
+	PhoneNumber  nullable.NullString            `json:"phone_number"`
+	Organization *BulkExportUserResOrganization `json:"organization"`
+	DirectRoles  []string                       `json:"direct_roles"`
+	IsActive     bool                           `json:"is_active"`
+	IsApproved   bool                           `json:"is_approved"`
+	LastLoginAt  *time.Time                     `json:"last_login_at"`
+	CreatedAt    time.Time                      `json:"created_at"`
+}
+
+func NewBulkExportUserRes(user *entities.User) BulkExportUserRes {
+	res := BulkExportUserRes{
+		UUID:        user.UUID,
+		EmployeeID:  user.EmployeeID,
+		Username:    user.Username,
+		Email:       user.Email,
+		FirstName:   user.FirstName,
+		LastName:    user.LastName,
+		PhoneNumber: user.PhoneNumber,
+		DirectRoles: make([]string, 0, len(user.Roles)),
+		IsActive:    user.IsActive,
+		IsApproved:  user.IsApproved,
+		LastLoginAt: user.LastLoginAt,
+		CreatedAt:   user.CreatedAt,
+	}
+	if user.Organization != nil {
+		res.Organization = &BulkExportUserResOrganization{
+			UUID: user.Organization.UUID,
+			Code: user.Organization.Code,
+			Name: user.Organization.Name,
+		}
+	}
+	for _, role := range user.Roles {
+		res.DirectRoles = append(res.DirectRoles, role.Name.GetOrDefault())
+	}
+
+	return res
+}
+
+// BulkExportUserTable lays users out like the import files, with direct roles separated by semicolons,
+// so an export can be edited and imported elsewhere
+var BulkExportUserTable = bulkexport.Table[*entities.User]{
+	Columns: []string{
+		"id", "employee_id", "username", "email", "first_name", "last_name", "phone_number",
+		"organization_code", "organization_name", "direct_roles", "is_active", "is_approved", "last_login_at", "created_at",
+	},
+	Row: func(user *entities.User) []string {
+		res := NewBulkExportUserRes(user)
+
+		var organizationCode, organizationName, lastLoginAt string
+		if res.Organization != nil {
+			organizationCode = res.Organization.Code.GetOrDefault()
+			organizationName = res.Organization.Name.GetOrDefault()
+		}
+		if res.LastLoginAt != nil {
+			lastLoginAt = res.LastLoginAt.Format(time.RFC3339)
+		}
+
+		return []string{
+			res.UUID,
+			res.EmployeeID.GetOrDefault(),
+			res.Username.GetOrDefault(),
+			res.Email.GetOrDefault(),
+			res.FirstName.GetOrDefault(),
+			res.LastName.GetOrDefault(),
+			res.PhoneNumber.GetOrDefault(),
+			organizationCode,
+			organizationName,
+			strings.Join(res.DirectRoles, ";"),
+			strconv.FormatBool(res.IsActive),
+			strconv.FormatBool(res.IsApproved),
+			lastLoginAt,
+			res.CreatedAt.Format(time.RFC3339),
+		}
+	},
+	Record: func(user *entities.User) any {
+		return NewBulkExportUserRes(user)
+	},
+}
@@ -0,0 +1,45 @@
+package dtos
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+func TestBulkExportUserTable_Row(t *testing.T) {
+	user := &entities.User{
+		EmployeeID:  nullable.NewString("007"),
+		Username:    nullable.NewString("john"),
+		FirstName:   nullable.NewString("John"),
+		PhoneNumber: nullable.NewString("081234567890"),
+		IsActive:    true,
+		Roles: []*entities.Role{
+			{Name: nullable.NewString("admin")},
+			{Name: nullable.NewString("staff")},
+		},
+		Organization: &entities.Organization{Code: nullable.NewString("HQ"), Name: nullable.NewString("Head office")},
+	}
+	user.UUID = "2f0c5d43-6b7e-4a8e-9f1a-3c2b1d0e9f8a"
+	user.CreatedAt = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	row := BulkExportUserTable.Row(user)
+	if len(row) != len(BulkExportUserTable.Columns) {
+		t.Fatalf("expected %d cells, got %d", len(BulkExportUserTable.Columns), len(row))
+	}
+
+	expected := []string{
+		user.UUID, "007", "john", "", "John", "", "081234567890",
+		"HQ", "Head office", "admin;staff", "true", "false", "", "2026-01-02T03:04:05Z",
+	}
+	if !reflect.DeepEqual(row, expected) {
+		t.Errorf("expected %q, got %q", expected, row)
+	}
+
+	res := BulkExportUserTable.Record(user).(BulkExportUserRes)
+	if !reflect.DeepEqual(res.DirectRoles, []string{"admin", "staff"}) || res.Organization.Code.GetOrDefault() != "HQ" {
+		t.Errorf("unexpected record %+v", res)
+	}
+}
@@ -0,0 +1,70 @@
+package dtos
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/laksanagusta/identity/constants"
+	"github.com/laksanagusta/identity/internal/entities"
+
+	"github.com/invopop/validation"
+	"github.com/invopop/validation/is"
+)
+
+// ChangeStreamPageSize is how many events a stream reads at once
+const ChangeStreamPageSize = 100
+
+// StreamChangeReq subscribes to the change feed. Types is a comma separated list of entity types or
+// event types like user.deleted, OrganizationUUID limits the stream to the subtree of an organization.
+// LastEventID resumes after the last event a client received, reconnecting clients send it in the
+// Last-Event-ID header.
+type StreamChangeReq struct {
+	Types            string `query:"types"`
+	OrganizationUUID string `query:"organization_id"`
+	LastEventID      string `query:"last_event_id"`
+}
+
+func (r StreamChangeReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Types, validation.By(func(value any) error {
+			for _, eventType := range r.EventTypes() {
+				if !isChangeEventType(eventType) {
+					return errors.New(constants.ErrMsgChangeEventType)
+				}
+			}
+			return nil
+		})),
+		validation.Field(&r.OrganizationUUID, is.UUID),
+		validation.Field(&r.LastEventID, validation.By(func(value any) error {
+			_, err := entities.ParseChangeCursor(r.LastEventID)
+			if err != nil {
+				return errors.New(constants.ErrMsgChangeCursor)
+			}
+			return nil
+		})),
+	)
+}
+
+// EventTypes returns the requested types, every entity type when none is given
+func (r StreamChangeReq) EventTypes() []string {
+	if r.Types == "" {
+		return entities.ChangeEntityTypes
+	}
+
+	eventTypes := []string{}
+	for _, eventType := range strings.Split(r.Types, ",") {
+		if eventType = strings.TrimSpace(eventType); eventType != "" {
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+	return eventTypes
+}
+
+func isChangeEventType(eventType string) bool {
+	entityType, action, hasAction := strings.Cut(eventType, ".")
+	if !slices.Contains(entities.ChangeEntityTypes, entityType) {
+		return false
+	}
+	return !hasAction || slices.Contains(entities.ChangeActions, action)
+}
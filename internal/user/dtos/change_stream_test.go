@@ -0,0 +1,43 @@
+package dtos
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/laksanagusta/identity/internal/entities"
+)
+
+func TestStreamChangeReq_Validate(t *testing.T) {
+	valid := StreamChangeReq{
+		Types:            "organization, user.deleted,user_role.created",
+		OrganizationUUID: "7f1c2a4e-3b1d-4f6a-9c2e-5d8b7a6f4e3c",
+		LastEventID:      entities.ChangeCursor{TxID: 9, ID: 4}.String(),
+	}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("expected a valid request, got %v", err)
+	}
+
+	invalid := []StreamChangeReq{
+		{Types: "group"},
+		{Types: "user.renamed"},
+		{Types: "user."},
+		{OrganizationUUID: "root"},
+		{LastEventID: "not a cursor"},
+	}
+	for _, req := range invalid {
+		if err := req.Validate(); err == nil {
+			t.Errorf("expected %+v to be invalid", req)
+		}
+	}
+}
+
+func TestStreamChangeReq_EventTypes(t *testing.T) {
+	if types := (StreamChangeReq{}).EventTypes(); !reflect.DeepEqual(types, entities.ChangeEntityTypes) {
+		t.Errorf("expected every entity type, got %v", types)
+	}
+
+	types := StreamChangeReq{Types: "role, user.updated,"}.EventTypes()
+	if !reflect.DeepEqual(types, []string{"role", "user.updated"}) {
+		t.Errorf("unexpected types %v", types)
+	}
+}
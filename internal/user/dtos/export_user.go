@@ -0,0 +1,186 @@
+package dtos
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/invopop/validation"
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+const (
+	ExportFormatJSON = "json"
+	ExportFormatZip  = "zip"
+)
+
+type ExportUserReq struct {
+	UserUUID string `params:"userUUID"`
+	Format   string `query:"format"`
+}
+
+func (r ExportUserReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.UserUUID, validation.Required),
+		validation.Field(&r.Format, validation.In(ExportFormatJSON, ExportFormatZip)),
+	)
+}
+
+type ExportUserRes struct {
+	Profile        ExportUserResProfile       `json:"profile"`
+	Organization   *ExportUserResOrganization `json:"organization"`
+	Roles          []ExportUserResRole        `json:"roles"`
+	Permissions    []ExportUserResPermission  `json:"permissions"`
+	Positions      []UserPositionRes          `json:"positions"`
+	LoginHistories []*entities.LoginHistory   `json:"login_histories"`
+	AuditLogs      []*entities.AuditLog       `json:"audit_logs"`
+	ExportedAt     time.Time                  `json:"exported_at"`
+}
+
+type ExportUserResProfile struct {
+	UUID                string                  `json:"id"`
+	EmployeeID          nullable.NullString     `json:"employee_id"`
+	Username            nullable.NullString     `json:"username"`
+	Email               nullable.NullString     `json:"email"`
+	FirstName           nullable.NullString     `json:"first_name"`
+	LastName            nullable.NullString     `json:"last_name"`
+	PhoneNumber         nullable.NullString     `json:"phone_number"`
+	IsActive            bool                    `json:"is_active"`
+	IsApproved          bool                    `json:"is_approved"`
+	LastLoginAt         *time.Time              `json:"last_login_at"`
+	AvatarGradientStart nullable.NullString     `json:"avatar_gradient_start"`
+	AvatarGradientEnd   nullable.NullString     `json:"avatar_gradient_end"`
+	AvatarURLs          entities.AvatarURLs     `json:"avatar_urls"`
+	ErasedAt            *time.Time              `json:"erased_at"`
+	Attributes          entities.UserAttributes `json:"attributes"`
+	CreatedAt           time.Time               `json:"created_at"`
+	CreatedBy           string                  `json:"created_by"`
+	UpdatedAt           time.Time               `json:"updated_at"`
+	UpdatedBy           string                  `json:"updated_by"`
+}
+
+type ExportUserResOrganization struct {
+	UUID string              `json:"id"`
+	Name nullable.NullString `json:"name"`
+	Code nullable.NullString `json:"code"`
+	Type nullable.NullString `json:"type"`
+}
+
+type ExportUserResRole struct {
+	UUID        string              `json:"id"`
+	Name        nullable.NullString `json:"name"`
+	Description nullable.NullString `json:"description"`
+}
+
+type ExportUserResPermission struct {
+	UUID     string              `json:"id"`
+	Name     nullable.NullString `json:"name"`
+	Resource nullable.NullString `json:"resource"`
+	Action   nullable.NullString `json:"action"`
+}
+
+func NewExportUserRes(export entities.UserDataExport) ExportUserRes {
+	user := export.User
+
+	res := ExportUserRes{
+		Profile: ExportUserResProfile{
+			UUID:                user.UUID,
+			EmployeeID:          user.EmployeeID,
+			Username:            user.Username,
+			Email:               user.Email,
+			FirstName:           user.FirstName,
+			LastName:            user.LastName,
+			PhoneNumber:         user.PhoneNumber,
+			IsActive:            user.IsActive,
+			IsApproved:          user.IsApproved,
+			LastLoginAt:         user.LastLoginAt,
+			AvatarGradientStart: user.AvatarGradientStart,
+			AvatarGradientEnd:   user.AvatarGradientEnd,
+			AvatarURLs:          user.AvatarURLs,
+			ErasedAt:            user.ErasedAt,
+			Attributes:          user.Attributes,
+			CreatedAt:           user.CreatedAt,
+			CreatedBy:           user.CreatedBy,
+			UpdatedAt:           user.UpdatedAt,
+			UpdatedBy:           user.UpdatedBy,
+		},
+		Roles:          []ExportUserResRole{},
+		Permissions:    []ExportUserResPermission{},
+		Positions:      NewListUserPositionRes(user.Positions),
+		LoginHistories: export.LoginHistories,
+		AuditLogs:      export.AuditLogs,
+		ExportedAt:     export.ExportedAt,
+	}
+
+	if user.Organization != nil {
+		res.Organization = &ExportUserResOrganization{
+			UUID: user.Organization.UUID,
+			Name: user.Organization.Name,
+			Code: user.Organization.Code,
+			Type: user.Organization.Type,
+		}
+	}
+
+	for _, role := range user.Roles {
+		res.Roles = append(res.Roles, ExportUserResRole{
+			UUID:        role.UUID,
+			Name:        role.Name,
+			Description: role.Description,
+		})
+	}
+
+	for _, permission := range user.Permissions {
+		res.Permissions = append(res.Permissions, ExportUserResPermission{
+			UUID:     permission.UUID,
+			Name:     permission.Name,
+			Resource: permission.Resource,
+			Action:   permission.Action,
+		})
+	}
+
+	return res
+}
+
+// Zip writes every section of the export into its own JSON file
+func (r ExportUserRes) Zip() ([]byte, error) {
+	files := []struct {
+		name string
+		data any
+	}{
+		{"profile.json", r.Profile},
+		{"organization.json", r.Organization},
+		{"roles.json", r.Roles},
+		{"permissions.json", r.Permissions},
+		{"positions.json", r.Positions},
+		{"login_histories.json", r.LoginHistories},
+		{"audit_logs.json", r.AuditLogs},
+	}
+
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+
+	for _, file := range files {
+		w, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     file.name,
+			Method:   zip.Deflate,
+			Modified: r.ExportedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(file.data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,131 @@
+package external
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/laksanagusta/identity/constants"
+	"github.com/laksanagusta/identity/internal/entities"
+
+	"github.com/invopop/validation"
+)
+
+const (
+	DefaultChangeLimit = 100
+	MaxChangeLimit     = 1000
+)
+
+// ListChangeReq reads the change feed after Cursor, empty for the start of the feed. Types optionally
+// restricts the feed to a comma separated list of entity types.
+type ListChangeReq struct {
+	Cursor string `query:"cursor"`
+	Types  string `query:"types"`
+	Limit  int    `query:"limit"`
+}
+
+func (r ListChangeReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Cursor, validation.By(func(value any) error {
+			_, err := entities.ParseChangeCursor(r.Cursor)
+			if err != nil {
+				return errors.New(constants.ErrMsgChangeCursor)
+			}
+			return nil
+		})),
+		validation.Field(&r.Types, validation.By(func(value any) error {
+			for _, entityType := range r.entityTypes() {
+				if err := validation.In(entities.ChangeEntityTypes...).Validate(entityType); err != nil {
+					return errors.New(constants.ErrMsgChangeEntityType)
+				}
+			}
+			return nil
+		})),
+		validation.Field(&r.Limit, validation.Min(0), validation.Max(MaxChangeLimit)),
+	)
+}
+
+func (r ListChangeReq) entityTypes() []string {
+	if r.Types == "" {
+		return entities.ChangeEntityTypes
+	}
+
+	entityTypes := []string{}
+	for _, entityType := range strings.Split(r.Types, ",") {
+		if entityType = strings.TrimSpace(entityType); entityType != "" {
+			entityTypes = append(entityTypes, entityType)
+		}
+	}
+	return entityTypes
+}
+
+// NewListChangeEventParams fetches one event more than the limit to tell whether the feed goes on
+func (r ListChangeReq) NewListChangeEventParams() entities.ListChangeEventParams {
+	cursor, _ := entities.ParseChangeCursor(r.Cursor)
+
+	limit := r.Limit
+	if limit == 0 {
+		limit = DefaultChangeLimit
+	}
+
+	return entities.ListChangeEventParams{
+		After: cursor,
+		Types: r.entityTypes(),
+		Limit: limit + 1,
+	}
+}
+
+// ChangeRes is an event of the feed, Cursor resumes the feed right after it
+type ChangeRes struct {
+	Cursor     string          `json:"cursor"`
+	Type       string          `json:"type"`
+	UUID       string          `json:"id"`
+	Action     string          `json:"action"`
+	Data       json.RawMessage `json:"data"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+func NewChangeRes(event *entities.ChangeEvent) ChangeRes {
+	data := event.Data
+	if len(data) == 0 {
+		data = json.RawMessage("null")
+	}
+
+	return ChangeRes{
+		Cursor:     event.Cursor().String(),
+		Type:       event.EntityType,
+		UUID:       event.EntityUUID,
+		Action:     event.Action,
+		Data:       data,
+		OccurredAt: event.OccurredAt,
+	}
+}
+
+// ListChangeRes is a page of the feed. NextCursor is the cursor of the last event, or the requested
+// cursor when nothing changed since, so consumers can always store it and poll again.
+type ListChangeRes struct {
+	Data       []ChangeRes `json:"data"`
+	NextCursor string      `json:"next_cursor"`
+	HasMore    bool        `json:"has_more"`
+}
+
+func NewListChangeRes(req ListChangeReq, events []*entities.ChangeEvent) ListChangeRes {
+	params := req.NewListChangeEventParams()
+
+	res := ListChangeRes{
+		Data:       make([]ChangeRes, 0, len(events)),
+		NextCursor: params.After.String(),
+	}
+	if len(events) >= params.Limit {
+		events = events[:params.Limit-1]
+		res.HasMore = true
+	}
+
+	for _, event := range events {
+		res.Data = append(res.Data, NewChangeRes(event))
+		res.NextCursor = event.Cursor().String()
+	}
+
+	return res
+}
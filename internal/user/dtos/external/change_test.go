@@ -0,0 +1,55 @@
+package external
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/laksanagusta/identity/internal/entities"
+)
+
+func TestListChangeReq_Validate(t *testing.T) {
+	valid := ListChangeReq{Cursor: entities.ChangeCursor{TxID: 7, ID: 3}.String(), Types: "user, user_role", Limit: 10}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("expected a valid request, got %v", err)
+	}
+
+	for _, req := range []ListChangeReq{{Cursor: "nope"}, {Types: "user,group"}, {Limit: MaxChangeLimit + 1}} {
+		if err := req.Validate(); err == nil {
+			t.Errorf("expected %+v to be invalid", req)
+		}
+	}
+}
+
+func TestListChangeReq_NewListChangeEventParams(t *testing.T) {
+	params := ListChangeReq{Types: "role, user_role,"}.NewListChangeEventParams()
+
+	expected := entities.ListChangeEventParams{Types: []string{"role", "user_role"}, Limit: DefaultChangeLimit + 1}
+	if !reflect.DeepEqual(params, expected) {
+		t.Errorf("expected %+v, got %+v", expected, params)
+	}
+}
+
+func TestNewListChangeRes(t *testing.T) {
+	req := ListChangeReq{Cursor: entities.ChangeCursor{TxID: 7, ID: 3}.String(), Limit: 2}
+	events := []*entities.ChangeEvent{
+		{ID: 4, TxID: 7, EntityType: entities.ChangeEntityUser, EntityUUID: "u1", Action: entities.ChangeActionUpdated, Data: []byte(`{"id":"u1"}`)},
+		{ID: 6, TxID: 8, EntityType: entities.ChangeEntityUser, EntityUUID: "u1", Action: entities.ChangeActionDeleted},
+		{ID: 5, TxID: 9, EntityType: entities.ChangeEntityRole, EntityUUID: "r1", Action: entities.ChangeActionCreated},
+	}
+
+	res := NewListChangeRes(req, events)
+	if !res.HasMore || len(res.Data) != 2 {
+		t.Fatalf("expected 2 events and more to come, got %d, %v", len(res.Data), res.HasMore)
+	}
+	if res.NextCursor != events[1].Cursor().String() || res.Data[1].Cursor != res.NextCursor {
+		t.Errorf("expected the next cursor to be the one of the last event, got %s", res.NextCursor)
+	}
+	if string(res.Data[1].Data) != "null" {
+		t.Errorf("expected a tombstone without data, got %s", res.Data[1].Data)
+	}
+
+	empty := NewListChangeRes(req, nil)
+	if empty.HasMore || empty.NextCursor != req.Cursor {
+		t.Errorf("expected the requested cursor back when nothing changed, got %+v", empty)
+	}
+}
@@ -41,11 +41,14 @@ type ExternalUserRes struct {
 	PhoneNumber         nullable.NullString      `json:"phone_number"`
 	AvatarGradientStart nullable.NullString      `json:"avatar_gradient_start"`
 	AvatarGradientEnd   nullable.NullString      `json:"avatar_gradient_end"`
+	AvatarURLs          entities.AvatarURLs      `json:"avatar_urls"`
 	IsActive            bool                     `json:"is_active"`
 	IsApproved          bool                     `json:"is_approved"`
 	LastLoginAt         *time.Time               `json:"last_login_at,omitempty"`
+	ManagerUUID         nullable.NullString      `json:"manager_id"`
 	Organization        *ExternalOrganizationRes `json:"organization,omitempty"`
 	Roles               []ExternalRoleRes        `json:"roles"`
+	Attributes          entities.UserAttributes  `json:"attributes"`
 	CreatedAt           time.Time                `json:"created_at"`
 	UpdatedAt           time.Time                `json:"updated_at"`
 }
@@ -76,9 +79,12 @@ func NewExternalUserRes(user entities.User) ExternalUserRes {
 		PhoneNumber:         user.PhoneNumber,
 		AvatarGradientStart: user.AvatarGradientStart,
 		AvatarGradientEnd:   user.AvatarGradientEnd,
+		AvatarURLs:          user.AvatarURLs,
 		IsActive:            user.IsActive,
 		IsApproved:          user.IsApproved,
 		LastLoginAt:         user.LastLoginAt,
+		ManagerUUID:         user.ManagerUUID,
+		Attributes:          user.Attributes,
 		CreatedAt:           user.CreatedAt,
 		UpdatedAt:           user.UpdatedAt,
 	}
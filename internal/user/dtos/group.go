@@ -0,0 +1,315 @@
+package dtos
+
+import (
+	"errors"
+	"time"
+
+	"github.com/laksanagusta/identity/constants"
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/pkg/nullable"
+	"github.com/laksanagusta/identity/pkg/pagination"
+
+	"github.com/invopop/validation"
+	"github.com/invopop/validation/is"
+)
+
+const (
+	DefaultGroupMemberLimit = 20
+	MaxGroupMemberLimit     = 100
+
+	GroupMemberTypeUser  = "user"
+	GroupMemberTypeGroup = "group"
+)
+
+func validGroupFilter(value interface{}) error {
+	filter, ok := value.(entities.GroupFilter)
+	if !ok {
+		return nil
+	}
+
+	if msg := filter.Validate(); msg != "" {
+		return errors.New(msg)
+	}
+
+	return nil
+}
+
+// CreateGroupReq creates a dynamic group unless type is static, static groups get members afterwards
+type CreateGroupReq struct {
+	Name        nullable.NullString    `json:"name"`
+	Description nullable.NullString    `json:"description"`
+	Type        nullable.NullString    `json:"type"`
+	Filters     []entities.GroupFilter `json:"filters"`
+}
+
+func (r CreateGroupReq) Validate() error {
+	isStatic := r.Type.GetOrDefault() == entities.GroupTypeStatic
+
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Name, validation.Required, validation.Length(1, 255)),
+		validation.Field(&r.Type, validation.In(entities.GroupTypeDynamic, entities.GroupTypeStatic)),
+		validation.Field(&r.Filters,
+			validation.When(isStatic, validation.Empty.Error(constants.ErrMsgGroupNotDynamic)).Else(validation.Required),
+			validation.Each(validation.By(validGroupFilter)),
+		),
+	)
+}
+
+func (r CreateGroupReq) NewGroup(cred entities.AuthenticatedUser) entities.Group {
+	group := entities.Group{
+		Name:        r.Name,
+		Description: r.Description,
+		Type:        nullable.NewString(r.Type.GetOrDefault(entities.GroupTypeDynamic)),
+		Filters:     r.Filters,
+	}
+
+	group.BaseModel = entities.NewBaseModel(cred.Username)
+
+	return group
+}
+
+type UpdateGroupReq struct {
+	UUID        string                 `params:"groupUUID"`
+	Name        nullable.NullString    `json:"name"`
+	Description nullable.NullString    `json:"description"`
+	Filters     []entities.GroupFilter `json:"filters"`
+}
+
+func (r UpdateGroupReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.UUID, validation.Required, is.UUID),
+		validation.Field(&r.Name, validation.Length(1, 255)),
+		validation.Field(&r.Filters, validation.NilOrNotEmpty, validation.Each(validation.By(validGroupFilter))),
+	)
+}
+
+// Apply copies the provided fields onto the stored group, filters are replaced as a whole
+func (r UpdateGroupReq) Apply(group *entities.Group, cred entities.AuthenticatedUser) {
+	if r.Name.IsNotEmpty() {
+		group.Name = r.Name
+	}
+	if r.Description.IsExists {
+		group.Description = r.Description
+	}
+	if r.Filters != nil {
+		group.Filters = r.Filters
+	}
+
+	group.UpdateModel(cred.Username)
+}
+
+// PreviewGroupReq lists the users a filter set would match, before the group is saved
+type PreviewGroupReq struct {
+	Filters []entities.GroupFilter `json:"filters"`
+	Page    int                    `query:"page"`
+	Limit   int                    `query:"limit"`
+}
+
+func (r PreviewGroupReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Filters, validation.Required, validation.Each(validation.By(validGroupFilter))),
+		validation.Field(&r.Page, validation.Min(1)),
+		validation.Field(&r.Limit, validation.Min(1), validation.Max(MaxGroupMemberLimit)),
+	)
+}
+
+func (r PreviewGroupReq) Pagination() pagination.Pagination {
+	return newGroupMemberPage(r.Page, r.Limit)
+}
+
+type ListGroupMemberReq struct {
+	GroupUUID string `params:"groupUUID"`
+	Page      int    `query:"page"`
+	Limit     int    `query:"limit"`
+}
+
+func (r ListGroupMemberReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.GroupUUID, validation.Required, is.UUID),
+		validation.Field(&r.Page, validation.Min(1)),
+		validation.Field(&r.Limit, validation.Min(1), validation.Max(MaxGroupMemberLimit)),
+	)
+}
+
+func (r ListGroupMemberReq) Pagination() pagination.Pagination {
+	return newGroupMemberPage(r.Page, r.Limit)
+}
+
+func newGroupMemberPage(page, limit int) pagination.Pagination {
+	if page == 0 {
+		page = 1
+	}
+	if limit == 0 {
+		limit = DefaultGroupMemberLimit
+	}
+
+	return pagination.Pagination{Page: page, Limit: limit}
+}
+
+type GroupMemberReq struct {
+	GroupUUID string `params:"groupUUID"`
+	UserUUID  string `params:"userUUID"`
+}
+
+func (r GroupMemberReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.GroupUUID, validation.Required, is.UUID),
+		validation.Field(&r.UserUUID, validation.Required, is.UUID),
+	)
+}
+
+type CreateGroupMemberReq struct {
+	GroupUUID       string              `params:"groupUUID"`
+	UserUUID        nullable.NullString `json:"user_id"`
+	MemberGroupUUID nullable.NullString `json:"group_id"`
+}
+
+func (r CreateGroupMemberReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.GroupUUID, validation.Required, is.UUID),
+		validation.Field(&r.UserUUID, validation.When(!r.MemberGroupUUID.IsNotEmpty(), validation.Required).Else(validation.Empty), is.UUID),
+		validation.Field(&r.MemberGroupUUID, is.UUID),
+	)
+}
+
+func (r CreateGroupMemberReq) NewGroupMember(cred entities.AuthenticatedUser) entities.GroupMember {
+	member := entities.GroupMember{
+		GroupUUID:       r.GroupUUID,
+		MemberUserUUID:  r.UserUUID,
+		MemberGroupUUID: r.MemberGroupUUID,
+	}
+
+	member.BaseModel = entities.NewBaseModel(cred.Username)
+
+	return member
+}
+
+type GroupRoleReq struct {
+	GroupUUID string `params:"groupUUID"`
+	RoleUUID  string `json:"role_id" params:"roleUUID"`
+}
+
+func (r GroupRoleReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.GroupUUID, validation.Required, is.UUID),
+		validation.Field(&r.RoleUUID, validation.Required, is.UUID),
+	)
+}
+
+func (r GroupRoleReq) NewGroupRole(cred entities.AuthenticatedUser) entities.GroupRole {
+	groupRole := entities.GroupRole{
+		GroupUUID: r.GroupUUID,
+		RoleUUID:  r.RoleUUID,
+	}
+
+	groupRole.BaseModel = entities.NewBaseModel(cred.Username)
+
+	return groupRole
+}
+
+type GroupRes struct {
+	UUID        string                 `json:"id"`
+	Name        nullable.NullString    `json:"name"`
+	Description nullable.NullString    `json:"description"`
+	Type        nullable.NullString    `json:"type"`
+	Filters     []entities.GroupFilter `json:"filters"`
+	Roles       []GroupRoleRes         `json:"roles,omitempty"`
+	CreatedAt   string                 `json:"created_at"`
+	UpdatedAt   string                 `json:"updated_at"`
+}
+
+type GroupRoleRes struct {
+	UUID        string              `json:"id"`
+	Name        nullable.NullString `json:"name"`
+	Description nullable.NullString `json:"description"`
+}
+
+func NewGroupRes(group entities.Group) GroupRes {
+	res := GroupRes{
+		UUID:        group.UUID,
+		Name:        group.Name,
+		Description: group.Description,
+		Type:        group.Type,
+		Filters:     group.Filters,
+		CreatedAt:   group.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   group.UpdatedAt.Format(time.RFC3339),
+	}
+
+	for _, role := range group.Roles {
+		res.Roles = append(res.Roles, GroupRoleRes{
+			UUID:        role.UUID,
+			Name:        role.Name,
+			Description: role.Description,
+		})
+	}
+
+	return res
+}
+
+func NewListGroupRes(groups []*entities.Group) []GroupRes {
+	res := make([]GroupRes, 0, len(groups))
+	for _, group := range groups {
+		res = append(res, NewGroupRes(*group))
+	}
+
+	return res
+}
+
+type GroupMemberRes struct {
+	UUID      string               `json:"id"`
+	Type      string               `json:"type"`
+	User      *GroupMemberUserRes  `json:"user,omitempty"`
+	Group     *GroupMemberGroupRes `json:"group,omitempty"`
+	CreatedAt string               `json:"created_at"`
+}
+
+type GroupMemberUserRes struct {
+	UUID      string              `json:"id"`
+	Username  nullable.NullString `json:"username"`
+	FirstName nullable.NullString `json:"first_name"`
+	LastName  nullable.NullString `json:"last_name"`
+}
+
+type GroupMemberGroupRes struct {
+	UUID string              `json:"id"`
+	Name nullable.NullString `json:"name"`
+	Type nullable.NullString `json:"type"`
+}
+
+func NewListGroupMemberRes(members []*entities.GroupMember) []GroupMemberRes {
+	res := make([]GroupMemberRes, 0, len(members))
+	for _, member := range members {
+		item := GroupMemberRes{
+			UUID:      member.UUID,
+			CreatedAt: member.CreatedAt.Format(time.RFC3339),
+		}
+
+		if member.User != nil {
+			item.Type = GroupMemberTypeUser
+			item.User = &GroupMemberUserRes{
+				UUID:      member.User.UUID,
+				Username:  member.User.Username,
+				FirstName: member.User.FirstName,
+				LastName:  member.User.LastName,
+			}
+		}
+		if member.Group != nil {
+			item.Type = GroupMemberTypeGroup
+			item.Group = &GroupMemberGroupRes{
+				UUID: member.Group.UUID,
+				Name: member.Group.Name,
+				Type: member.Group.Type,
+			}
+		}
+
+		res = append(res, item)
+	}
+
+	return res
+}
+
+type GroupMembershipRes struct {
+	GroupUUID string `json:"group_id"`
+	UserUUID  string `json:"user_id"`
+	IsMember  bool   `json:"is_member"`
+}
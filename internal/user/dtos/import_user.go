@@ -0,0 +1,306 @@
+package dtos
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/laksanagusta/identity/constants"
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/pkg/errorhelper"
+	"github.com/laksanagusta/identity/pkg/nullable"
+	"github.com/laksanagusta/identity/pkg/spreadsheet"
+
+	"github.com/invopop/validation"
+)
+
+const (
+	// MaxUserImportSize is the upload limit in bytes, kept below the default fiber body limit
+	MaxUserImportSize = 3 << 20
+	MaxUserImportRows = 1000
+)
+
+// userImportColumns are read from the header of an import file in any order, roles holds role names
+// separated by semicolons and may also be named direct_roles like in exports
+var userImportColumns = []string{"employee_id", "username", "password", "first_name", "last_name", "phone_number", "organization_code", "roles"}
+
+// ImportUserReq creates the users of a CSV or XLSX file. With DryRun the rows are only checked.
+type ImportUserReq struct {
+	DryRun   bool   `query:"dry_run"`
+	FileName string `json:"-"`
+	Data     []byte `json:"-"`
+
+	Records []UserImportRecord `query:"-"`
+}
+
+// Validate reports every problem with the uploaded file under "file"
+func (r ImportUserReq) Validate() error {
+	return validation.Errors{
+		"file": validation.Validate(r.Data,
+			validation.Required.Error(constants.ErrMsgUserImportEmpty),
+			validation.Length(1, MaxUserImportSize).Error(constants.ErrMsgUserImportTooLarge),
+			validation.By(func(value any) error {
+				_, err := spreadsheet.FormatOf(r.FileName)
+				if err != nil {
+					return errors.New(constants.ErrMsgUserImportFormat)
+				}
+				return nil
+			}),
+		),
+	}.Filter()
+}
+
+// UserImportRecord is a user of an import file as CreateNewUserReq reads it, with the organization
+// and roles still given by code and names. Row numbers the users of the file from 1.
+type UserImportRecord struct {
+	CreateNewUserReq
+	Row              int
+	OrganizationCode string
+	RoleNames        []string
+}
+
+// ParseRecords reads the users of r.Data, the first row being the header
+func (r *ImportUserReq) ParseRecords() error {
+	format, err := spreadsheet.FormatOf(r.FileName)
+	if err != nil {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"file": {constants.ErrMsgUserImportFormat},
+		})
+	}
+
+	rows, err := spreadsheet.Read(format, r.Data)
+	if errors.Is(err, spreadsheet.ErrTooManyRows) {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"file": {constants.ErrMsgUserImportTooMany},
+		})
+	}
+	if err != nil {
+		return errorhelper.BadRequest(err.Error())
+	}
+	if len(rows) < 2 {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"file": {constants.ErrMsgUserImportEmpty},
+		})
+	}
+	if len(rows) > MaxUserImportRows+1 {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"file": {constants.ErrMsgUserImportTooMany},
+		})
+	}
+
+	columns := make(map[string]int, len(rows[0]))
+	for i, column := range rows[0] {
+		columns[strings.ToLower(column)] = i
+	}
+	if j, ok := columns["direct_roles"]; ok {
+		if _, ok := columns["roles"]; !ok {
+			columns["roles"] = j
+		}
+	}
+
+	errs := map[string][]string{}
+	for _, column := range userImportColumns {
+		if _, ok := columns[column]; !ok && column != "last_name" {
+			errs["columns."+column] = []string{constants.ErrMsgUserImportColumn}
+		}
+	}
+	if len(errs) > 0 {
+		return errorhelper.BadRequestMap(errs)
+	}
+
+	r.Records = make([]UserImportRecord, 0, len(rows)-1)
+	for i, row := range rows[1:] {
+		cell := func(column string) string {
+			j, ok := columns[column]
+			if !ok || j >= len(row) {
+				return ""
+			}
+			return row[j]
+		}
+
+		record := UserImportRecord{
+			CreateNewUserReq: CreateNewUserReq{
+				EmployeeID:  cell("employee_id"),
+				Username:    cell("username"),
+				Password:    cell("password"),
+				FirstName:   cell("first_name"),
+				PhoneNumber: cell("phone_number"),
+			},
+			Row:              i + 1,
+			OrganizationCode: cell("organization_code"),
+		}
+		if lastName := cell("last_name"); lastName != "" {
+			record.LastName = nullable.NewString(lastName)
+		}
+		for _, name := range strings.Split(cell("roles"), ";") {
+			if name = strings.TrimSpace(name); name != "" {
+				record.RoleNames = append(record.RoleNames, name)
+			}
+		}
+
+		r.Records = append(r.Records, record)
+	}
+
+	return nil
+}
+
+// Errors checks the record like CreateNewUserReq once its organization and roles are resolved,
+// errors of organization_id and role_ids are keyed by the columns they come from
+func (r UserImportRecord) Errors() (map[string][]string, error) {
+	err := r.CreateNewUserReq.Validate()
+	if err == nil {
+		return map[string][]string{}, nil
+	}
+
+	fieldErrs, ok := errorhelper.FieldErrors(err)
+	if !ok {
+		return nil, err
+	}
+
+	columns := map[string]string{
+		"organization_id": "organization_code",
+		"role_ids":        "roles",
+	}
+
+	errs := make(map[string][]string, len(fieldErrs))
+	for field, messages := range fieldErrs {
+		if column, ok := columns[field]; ok {
+			field = column
+		}
+		errs[field] = append(errs[field], messages...)
+	}
+
+	return errs, nil
+}
+
+// DuplicateErrors returns the errors of the records repeating the username, phone number or employee
+// ID of an earlier record, keyed by record number
+func DuplicateErrors(records []UserImportRecord) map[int]map[string][]string {
+	seen := map[string]map[string]bool{
+		"employee_id":  {},
+		"username":     {},
+		"phone_number": {},
+	}
+
+	errs := map[int]map[string][]string{}
+	for _, record := range records {
+		values := map[string]string{
+			"employee_id":  record.EmployeeID,
+			"username":     strings.ToLower(record.Username),
+			"phone_number": record.PhoneNumber,
+		}
+		for field, value := range values {
+			if value == "" {
+				continue
+			}
+			if seen[field][value] {
+				if errs[record.Row] == nil {
+					errs[record.Row] = map[string][]string{}
+				}
+				errs[record.Row][field] = append(errs[record.Row][field], constants.ErrMsgUserImportDuplicate)
+				continue
+			}
+			seen[field][value] = true
+		}
+	}
+
+	return errs
+}
+
+// UserImportErrors returns the errors of the failed results keyed like rows.<row>.<column>
+func UserImportErrors(results entities.UserImportResults) map[string][]string {
+	errs := map[string][]string{}
+	for _, result := range results {
+		for field, messages := range result.Errors {
+			errs["rows."+strconv.Itoa(result.Row)+"."+field] = messages
+		}
+	}
+
+	return errs
+}
+
+type UserImportRowRes struct {
+	Row      int                 `json:"row"`
+	UserUUID string              `json:"user_id,omitempty"`
+	Errors   map[string][]string `json:"errors,omitempty"`
+}
+
+// UserImportReportRes is the outcome of a dry run, Rows only holds the rows that would fail
+type UserImportReportRes struct {
+	DryRun      bool               `json:"dry_run"`
+	TotalRows   int                `json:"total_rows"`
+	ValidRows   int                `json:"valid_rows"`
+	InvalidRows int                `json:"invalid_rows"`
+	Rows        []UserImportRowRes `json:"rows"`
+}
+
+func NewUserImportReportRes(results entities.UserImportResults) UserImportReportRes {
+	failed := results.Failed()
+
+	return UserImportReportRes{
+		DryRun:      true,
+		TotalRows:   len(results),
+		ValidRows:   len(results) - len(failed),
+		InvalidRows: len(failed),
+		Rows:        newUserImportRowsRes(failed),
+	}
+}
+
+type UserImportJobRes struct {
+	UUID          string              `json:"id"`
+	Status        string              `json:"status"`
+	FileName      string              `json:"file_name"`
+	TotalRows     int                 `json:"total_rows"`
+	ProcessedRows int                 `json:"processed_rows"`
+	CreatedCount  int                 `json:"created_count"`
+	FailedCount   int                 `json:"failed_count"`
+	Error         nullable.NullString `json:"error"`
+	StartedAt     *time.Time          `json:"started_at"`
+	FinishedAt    *time.Time          `json:"finished_at"`
+	CreatedAt     time.Time           `json:"created_at"`
+	CreatedBy     string              `json:"created_by"`
+	// Rows are the results of the processed rows, only shown for a single job
+	Rows []UserImportRowRes `json:"rows,omitempty"`
+}
+
+func NewUserImportJobRes(job *entities.UserImportJob) UserImportJobRes {
+	res := NewListUserImportJobRes([]*entities.UserImportJob{job})[0]
+	res.Rows = newUserImportRowsRes(job.Results)
+	return res
+}
+
+func NewListUserImportJobRes(jobs []*entities.UserImportJob) []UserImportJobRes {
+	res := make([]UserImportJobRes, 0, len(jobs))
+	for _, job := range jobs {
+		res = append(res, UserImportJobRes{
+			UUID:          job.UUID,
+			Status:        job.Status,
+			FileName:      job.FileName,
+			TotalRows:     job.TotalRows,
+			ProcessedRows: job.ProcessedRows,
+			CreatedCount:  job.CreatedCount,
+			FailedCount:   job.FailedCount,
+			Error:         job.Error,
+			StartedAt:     job.StartedAt,
+			FinishedAt:    job.FinishedAt,
+			CreatedAt:     job.CreatedAt,
+			CreatedBy:     job.CreatedBy,
+		})
+	}
+
+	return res
+}
+
+func newUserImportRowsRes(results entities.UserImportResults) []UserImportRowRes {
+	res := make([]UserImportRowRes, 0, len(results))
+	for _, result := range results {
+		res = append(res, UserImportRowRes{
+			Row:      result.Row,
+			UserUUID: result.UserUUID,
+			Errors:   result.Errors,
+		})
+	}
+
+	return res
+}
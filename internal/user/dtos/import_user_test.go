@@ -0,0 +1,122 @@
+package dtos
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/laksanagusta/identity/constants"
+	"github.com/laksanagusta/identity/pkg/errorhelper"
+)
+
+const importCSV = "Username,Employee_ID,password,first_name,phone_number,organization_code,roles\n" +
+	"john,E1,Secret#123,John,081234567890,HQ,admin; staff\n" +
+	",,,,,,\n" +
+	"JOHN,E2,weak,Johnny,081234567890,,\n"
+
+func TestImportUserReq_ParseRecords(t *testing.T) {
+	req := ImportUserReq{FileName: "users.CSV", Data: []byte(importCSV)}
+	if err := req.ParseRecords(); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	if len(req.Records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(req.Records))
+	}
+
+	first := req.Records[0]
+	if first.Row != 1 || first.Username != "john" || first.EmployeeID != "E1" || first.OrganizationCode != "HQ" {
+		t.Errorf("unexpected first record %+v", first)
+	}
+	if !reflect.DeepEqual(first.RoleNames, []string{"admin", "staff"}) {
+		t.Errorf("expected roles admin and staff, got %q", first.RoleNames)
+	}
+	if first.LastName.IsExists {
+		t.Errorf("expected no last name without the column, got %q", first.LastName.GetOrDefault())
+	}
+	if req.Records[1].Row != 2 {
+		t.Errorf("expected empty lines to be skipped, got row %d", req.Records[1].Row)
+	}
+}
+
+func TestImportUserReq_ParseRecordsExportedRoles(t *testing.T) {
+	data := "employee_id,username,password,first_name,phone_number,organization_code,direct_roles\n" +
+		"E1,john,Secret#123,John,081234567890,HQ,admin;staff\n"
+	req := ImportUserReq{FileName: "users.csv", Data: []byte(data)}
+	if err := req.ParseRecords(); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	if !reflect.DeepEqual(req.Records[0].RoleNames, []string{"admin", "staff"}) {
+		t.Errorf("expected roles admin and staff, got %q", req.Records[0].RoleNames)
+	}
+}
+
+func TestImportUserReq_ParseRecordsMissingColumns(t *testing.T) {
+	req := ImportUserReq{FileName: "users.csv", Data: []byte("username,password\njohn,Secret#123\n")}
+
+	err := req.ParseRecords()
+	errs, ok := errorhelper.FieldErrors(err)
+	if !ok {
+		t.Fatalf("expected field errors, got %v", err)
+	}
+
+	for _, column := range []string{"employee_id", "first_name", "phone_number", "organization_code", "roles"} {
+		if !reflect.DeepEqual(errs["columns."+column], []string{constants.ErrMsgUserImportColumn}) {
+			t.Errorf("expected %s to be missing, got %v", column, errs)
+		}
+	}
+	if _, ok := errs["columns.last_name"]; ok {
+		t.Error("expected last_name to be optional")
+	}
+}
+
+func TestUserImportRecord_Errors(t *testing.T) {
+	req := ImportUserReq{FileName: "users.csv", Data: []byte(importCSV)}
+	if err := req.ParseRecords(); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	record := req.Records[1]
+	errs, err := record.Errors()
+	if err != nil {
+		t.Fatalf("errors: %v", err)
+	}
+
+	for _, field := range []string{"password", "organization_code", "roles"} {
+		if len(errs[field]) == 0 {
+			t.Errorf("expected an error on %s, got %v", field, errs)
+		}
+	}
+	if _, ok := errs["organization_id"]; ok {
+		t.Error("expected organization_id errors under organization_code")
+	}
+}
+
+func TestDuplicateErrors(t *testing.T) {
+	req := ImportUserReq{FileName: "users.csv", Data: []byte(importCSV)}
+	if err := req.ParseRecords(); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	errs := DuplicateErrors(req.Records)
+	expected := map[int]map[string][]string{
+		2: {
+			"username":     {constants.ErrMsgUserImportDuplicate},
+			"phone_number": {constants.ErrMsgUserImportDuplicate},
+		},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, got %v", expected, errs)
+	}
+}
+
+func TestImportUserReq_Validate(t *testing.T) {
+	errs, ok := errorhelper.FieldErrors(ImportUserReq{FileName: "users.xls", Data: []byte("x")}.Validate())
+	if !ok || !reflect.DeepEqual(errs["file"], []string{constants.ErrMsgUserImportFormat}) {
+		t.Errorf("expected a format error, got %v", errs)
+	}
+
+	if err := (ImportUserReq{FileName: "users.xlsx", Data: []byte("x")}).Validate(); err != nil {
+		t.Errorf("expected a valid request, got %v", err)
+	}
+}
@@ -30,10 +30,12 @@ type ListUserRespData struct {
 	PhoneNumber         nullable.NullString          `json:"phone_number"`
 	AvatarGradientStart nullable.NullString          `json:"avatar_gradient_start"`
 	AvatarGradientEnd   nullable.NullString          `json:"avatar_gradient_end"`
+	AvatarURLs          entities.AvatarURLs          `json:"avatar_urls"`
 	Roles               []ListUserRespDataRole       `json:"roles"`
 	Organization        ListUserRespDataOrganization `json:"organizations"`
 	CreatedAt           nullable.NullString          `json:"created_at"`
 	IsApproved          bool                         `json:"is_approved"`
+	Highlights          map[string]string            `json:"highlights,omitempty"`
 }
 
 type ListUserRespDataRole struct {
@@ -80,12 +82,14 @@ func NewListUserResp(users []*entities.User) []ListUserRespData {
 			PhoneNumber:         user.PhoneNumber,
 			AvatarGradientStart: user.AvatarGradientStart,
 			AvatarGradientEnd:   user.AvatarGradientEnd,
+			AvatarURLs:          user.AvatarURLs,
 			Organization: ListUserRespDataOrganization{
 				UUID: user.Organization.UUID,
 				Name: user.Organization.Name,
 			},
 			CreatedAt:  nullable.NewString(user.CreatedAt.Format(time.RFC3339)),
 			IsApproved: user.IsApproved,
+			Highlights: user.SearchHighlights,
 		}
 
 		for _, role := range user.Roles {
@@ -2,9 +2,15 @@ package dtos
 
 import "github.com/invopop/validation"
 
+// LoginReq accepts any identifier (username, email, phone number or employee ID),
+// username is kept for clients built before identifier-first login
 type LoginReq struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+	Identifier string `json:"identifier"`
+	Username   string `json:"username"`
+	Password   string `json:"password"`
+
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
 }
 
 type LoginRes struct {
@@ -13,7 +19,31 @@ type LoginRes struct {
 
 func (r LoginReq) Validate() error {
 	return validation.ValidateStruct(&r,
-		validation.Field(&r.Username, validation.Required, validation.Length(1, 255)),
+		validation.Field(&r.Identifier, validation.When(r.Username == "", validation.Required), validation.Length(1, 255)),
+		validation.Field(&r.Username, validation.Length(1, 255)),
 		validation.Field(&r.Password, validation.Required, validation.Length(1, 255)),
 	)
 }
+
+func (r LoginReq) LoginIdentifier() string {
+	if r.Identifier != "" {
+		return r.Identifier
+	}
+	return r.Username
+}
+
+type DiscoverLoginReq struct {
+	Identifier string `json:"identifier"`
+}
+
+func (r DiscoverLoginReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Identifier, validation.Required, validation.Length(1, 255)),
+	)
+}
+
+// DiscoverLoginRes lists the usable methods in order of preference
+type DiscoverLoginRes struct {
+	Methods []string `json:"methods"`
+	SSOURL  *string  `json:"sso_url,omitempty"`
+}
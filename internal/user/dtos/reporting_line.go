@@ -0,0 +1,76 @@
+package dtos
+
+import (
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/pkg/nullable"
+
+	"github.com/invopop/validation"
+	"github.com/invopop/validation/is"
+)
+
+const MaxApproverLevel = 10
+
+// UpdateManagerReq replaces the manager of a user, a null or missing manager_id clears it
+type UpdateManagerReq struct {
+	UserUUID    string              `params:"userUUID"`
+	ManagerUUID nullable.NullString `json:"manager_id"`
+}
+
+func (r UpdateManagerReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.ManagerUUID, is.UUID),
+	)
+}
+
+type ApproverReq struct {
+	UserUUID string `params:"id"`
+	Level    int    `query:"level"`
+}
+
+func (r ApproverReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.UserUUID, validation.Required, is.UUID),
+		validation.Field(&r.Level, validation.Min(1), validation.Max(MaxApproverLevel)),
+	)
+}
+
+type ReportingLineUserRes struct {
+	UUID                string              `json:"id"`
+	EmployeeID          nullable.NullString `json:"employee_id"`
+	Username            nullable.NullString `json:"username"`
+	FirstName           nullable.NullString `json:"first_name"`
+	LastName            nullable.NullString `json:"last_name"`
+	Email               nullable.NullString `json:"email"`
+	OrganizationUUID    nullable.NullString `json:"organization_id"`
+	ManagerUUID         nullable.NullString `json:"manager_id"`
+	IsActive            bool                `json:"is_active"`
+	AvatarGradientStart nullable.NullString `json:"avatar_gradient_start"`
+	AvatarGradientEnd   nullable.NullString `json:"avatar_gradient_end"`
+	AvatarURLs          entities.AvatarURLs `json:"avatar_urls"`
+}
+
+func NewReportingLineUserRes(user entities.User) ReportingLineUserRes {
+	return ReportingLineUserRes{
+		UUID:                user.UUID,
+		EmployeeID:          user.EmployeeID,
+		Username:            user.Username,
+		FirstName:           user.FirstName,
+		LastName:            user.LastName,
+		Email:               user.Email,
+		OrganizationUUID:    user.OrganizationUUID,
+		ManagerUUID:         user.ManagerUUID,
+		IsActive:            user.IsActive,
+		AvatarGradientStart: user.AvatarGradientStart,
+		AvatarGradientEnd:   user.AvatarGradientEnd,
+		AvatarURLs:          user.AvatarURLs,
+	}
+}
+
+func NewListReportingLineUserRes(users []*entities.User) []ReportingLineUserRes {
+	res := make([]ReportingLineUserRes, 0, len(users))
+	for _, user := range users {
+		res = append(res, NewReportingLineUserRes(*user))
+	}
+
+	return res
+}
@@ -16,8 +16,12 @@ type ShowUserRes struct {
 	PhoneNumber         nullable.NullString     `json:"phone_number"`
 	AvatarGradientStart nullable.NullString     `json:"avatar_gradient_start"`
 	AvatarGradientEnd   nullable.NullString     `json:"avatar_gradient_end"`
+	AvatarURLs          entities.AvatarURLs     `json:"avatar_urls"`
+	ManagerUUID         nullable.NullString     `json:"manager_id"`
 	Organization        ShowUserResOrganization `json:"organization"`
 	Roles               []ShowUserResRole       `json:"role"`
+	Positions           []UserPositionRes       `json:"positions"`
+	Attributes          entities.UserAttributes `json:"attributes"`
 	CreatedAt           time.Time               `json:"created_at"`
 }
 
@@ -41,7 +45,11 @@ func NewShowUserRes(user entities.User) ShowUserRes {
 		PhoneNumber:         user.PhoneNumber,
 		AvatarGradientStart: user.AvatarGradientStart,
 		AvatarGradientEnd:   user.AvatarGradientEnd,
+		AvatarURLs:          user.AvatarURLs,
+		ManagerUUID:         user.ManagerUUID,
 		Organization:        ShowUserResOrganization{UUID: user.Organization.UUID, Name: user.Organization.Name},
+		Positions:           NewListUserPositionRes(user.Positions),
+		Attributes:          user.Attributes,
 		CreatedAt:           user.CreatedAt,
 	}
 
@@ -0,0 +1,58 @@
+package dtos
+
+import (
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/pkg/nullable"
+
+	"github.com/invopop/validation"
+)
+
+const (
+	DefaultSuggestLimit = 8
+	MaxSuggestLimit     = 20
+)
+
+type SuggestUserReq struct {
+	Query string `query:"q"`
+	Limit int    `query:"limit"`
+}
+
+func (r SuggestUserReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Query, validation.Required, validation.Length(1, 255)),
+		validation.Field(&r.Limit, validation.Min(1), validation.Max(MaxSuggestLimit)),
+	)
+}
+
+type SuggestUserRes struct {
+	UUID                string              `json:"id"`
+	EmployeeID          nullable.NullString `json:"employee_id"`
+	Username            nullable.NullString `json:"username"`
+	FirstName           nullable.NullString `json:"first_name"`
+	LastName            nullable.NullString `json:"last_name"`
+	Email               nullable.NullString `json:"email"`
+	AvatarGradientStart nullable.NullString `json:"avatar_gradient_start"`
+	AvatarGradientEnd   nullable.NullString `json:"avatar_gradient_end"`
+	AvatarURLs          entities.AvatarURLs `json:"avatar_urls"`
+	Highlights          map[string]string   `json:"highlights"`
+}
+
+func NewListSuggestUserRes(users []*entities.User) []SuggestUserRes {
+	res := make([]SuggestUserRes, 0, len(users))
+	for _, user := range users {
+		res = append(res, SuggestUserRes{
+			UUID:                user.UUID,
+			EmployeeID:          user.EmployeeID,
+			Username:            user.Username,
+			FirstName:           user.FirstName,
+			LastName:            user.LastName,
+			Email:               user.Email,
+			AvatarGradientStart: user.AvatarGradientStart,
+			AvatarGradientEnd:   user.AvatarGradientEnd,
+			AvatarURLs:          user.AvatarURLs,
+			Highlights:          user.SearchHighlights,
+		})
+	}
+
+	return res
+}
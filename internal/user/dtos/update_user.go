@@ -9,14 +9,15 @@ import (
 )
 
 type UpdateUserReq struct {
-	UserUUID    string              `params:"userUUID"`
-	EmployeeID  nullable.NullString `json:"employee_id"`
-	Username    nullable.NullString `json:"username"`
-	FirstName   nullable.NullString `json:"first_name"`
-	LastName    nullable.NullString `json:"last_name"`
-	PhoneNumber nullable.NullString `json:"phone_number"`
-	RoleUUIDs   []string            `json:"role_ids"`
-	Password    nullable.NullString `json:"password"`
+	UserUUID    string                  `params:"userUUID"`
+	EmployeeID  nullable.NullString     `json:"employee_id"`
+	Username    nullable.NullString     `json:"username"`
+	FirstName   nullable.NullString     `json:"first_name"`
+	LastName    nullable.NullString     `json:"last_name"`
+	PhoneNumber nullable.NullString     `json:"phone_number"`
+	RoleUUIDs   []string                `json:"role_ids"`
+	Password    nullable.NullString     `json:"password"`
+	Attributes  entities.UserAttributes `json:"attributes"`
 }
 
 func (r UpdateUserReq) Validate() error {
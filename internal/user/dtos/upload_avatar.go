@@ -0,0 +1,25 @@
+package dtos
+
+import (
+	"github.com/laksanagusta/identity/constants"
+
+	"github.com/invopop/validation"
+)
+
+// MaxAvatarSize is the upload limit in bytes, kept below the default fiber body limit
+const MaxAvatarSize = 2 << 20
+
+type UploadAvatarReq struct {
+	UserUUID string `params:"userUUID"`
+	Data     []byte `json:"-"`
+}
+
+func (r UploadAvatarReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.UserUUID, validation.Required),
+		validation.Field(&r.Data,
+			validation.Required,
+			validation.Length(1, MaxAvatarSize).Error(constants.ErrMsgImageTooLarge),
+		),
+	)
+}
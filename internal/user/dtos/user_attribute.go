@@ -0,0 +1,179 @@
+package dtos
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/pkg/nullable"
+
+	"github.com/invopop/validation"
+)
+
+var (
+	attributeTypes = []interface{}{
+		entities.AttributeTypeString,
+		entities.AttributeTypeNumber,
+		entities.AttributeTypeBoolean,
+		entities.AttributeTypeDate,
+		entities.AttributeTypeEnum,
+	}
+
+	attributeVisibilities = []interface{}{
+		entities.AttributeVisibilityPublic,
+		entities.AttributeVisibilityInternal,
+		entities.AttributeVisibilityHidden,
+	}
+)
+
+func validRegex(value interface{}) error {
+	pattern, ok := value.(nullable.NullString)
+	if !ok || !pattern.IsNotEmpty() {
+		return nil
+	}
+
+	if _, err := regexp.Compile(pattern.GetOrDefault()); err != nil {
+		return errors.New("must be a valid regular expression")
+	}
+
+	return nil
+}
+
+type CreateUserAttributeReq struct {
+	Key         nullable.NullString `json:"key"`
+	Label       nullable.NullString `json:"label"`
+	Description nullable.NullString `json:"description"`
+	Type        nullable.NullString `json:"type"`
+	IsRequired  bool                `json:"is_required"`
+	EnumValues  []string            `json:"enum_values"`
+	Regex       nullable.NullString `json:"regex"`
+	Visibility  nullable.NullString `json:"visibility"`
+	IsEditable  *bool               `json:"is_editable"`
+}
+
+func (r CreateUserAttributeReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Key, validation.Required, validation.Match(entities.AttributeKeyRegex)),
+		validation.Field(&r.Label, validation.Required, validation.Length(1, 255)),
+		validation.Field(&r.Type, validation.Required, validation.In(attributeTypes...)),
+		validation.Field(&r.EnumValues,
+			validation.When(r.Type.GetOrDefault() == entities.AttributeTypeEnum, validation.Required),
+			validation.Each(validation.Required, validation.Length(1, 255)),
+		),
+		validation.Field(&r.Regex, validation.By(validRegex)),
+		validation.Field(&r.Visibility, validation.In(attributeVisibilities...)),
+	)
+}
+
+func (r CreateUserAttributeReq) NewUserAttributeDefinition(cred entities.AuthenticatedUser) entities.UserAttributeDefinition {
+	definition := entities.UserAttributeDefinition{
+		Key:         r.Key,
+		Label:       r.Label,
+		Description: r.Description,
+		Type:        r.Type,
+		IsRequired:  r.IsRequired,
+		EnumValues:  r.EnumValues,
+		Regex:       r.Regex,
+		Visibility:  r.Visibility,
+		IsEditable:  true,
+	}
+
+	if !definition.Visibility.IsNotEmpty() {
+		definition.Visibility = nullable.NewString(entities.AttributeVisibilityInternal)
+	}
+	if r.IsEditable != nil {
+		definition.IsEditable = *r.IsEditable
+	}
+	if definition.EnumValues == nil {
+		definition.EnumValues = []string{}
+	}
+
+	definition.BaseModel = entities.NewBaseModel(cred.Username)
+
+	return definition
+}
+
+// UpdateUserAttributeReq does not allow changing key and type, existing values depend on them
+type UpdateUserAttributeReq struct {
+	UUID        string              `params:"attributeUUID"`
+	Label       nullable.NullString `json:"label"`
+	Description nullable.NullString `json:"description"`
+	IsRequired  *bool               `json:"is_required"`
+	EnumValues  []string            `json:"enum_values"`
+	Regex       nullable.NullString `json:"regex"`
+	Visibility  nullable.NullString `json:"visibility"`
+	IsEditable  *bool               `json:"is_editable"`
+}
+
+func (r UpdateUserAttributeReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.UUID, validation.Required),
+		validation.Field(&r.Label, validation.Length(1, 255)),
+		validation.Field(&r.EnumValues, validation.Each(validation.Required, validation.Length(1, 255))),
+		validation.Field(&r.Regex, validation.By(validRegex)),
+		validation.Field(&r.Visibility, validation.In(attributeVisibilities...)),
+	)
+}
+
+// Apply copies the provided fields onto the stored definition
+func (r UpdateUserAttributeReq) Apply(definition *entities.UserAttributeDefinition, cred entities.AuthenticatedUser) {
+	if r.Label.IsExists {
+		definition.Label = r.Label
+	}
+	if r.Description.IsExists {
+		definition.Description = r.Description
+	}
+	if r.IsRequired != nil {
+		definition.IsRequired = *r.IsRequired
+	}
+	if r.EnumValues != nil {
+		definition.EnumValues = r.EnumValues
+	}
+	if r.Regex.IsExists {
+		definition.Regex = r.Regex
+	}
+	if r.Visibility.IsNotEmpty() {
+		definition.Visibility = r.Visibility
+	}
+	if r.IsEditable != nil {
+		definition.IsEditable = *r.IsEditable
+	}
+
+	definition.UpdateModel(cred.Username)
+}
+
+type UserAttributeRes struct {
+	UUID        string              `json:"id"`
+	Key         nullable.NullString `json:"key"`
+	Label       nullable.NullString `json:"label"`
+	Description nullable.NullString `json:"description"`
+	Type        nullable.NullString `json:"type"`
+	IsRequired  bool                `json:"is_required"`
+	EnumValues  []string            `json:"enum_values"`
+	Regex       nullable.NullString `json:"regex"`
+	Visibility  nullable.NullString `json:"visibility"`
+	IsEditable  bool                `json:"is_editable"`
+}
+
+func NewUserAttributeRes(definition entities.UserAttributeDefinition) UserAttributeRes {
+	return UserAttributeRes{
+		UUID:        definition.UUID,
+		Key:         definition.Key,
+		Label:       definition.Label,
+		Description: definition.Description,
+		Type:        definition.Type,
+		IsRequired:  definition.IsRequired,
+		EnumValues:  definition.EnumValues,
+		Regex:       definition.Regex,
+		Visibility:  definition.Visibility,
+		IsEditable:  definition.IsEditable,
+	}
+}
+
+func NewListUserAttributeRes(definitions []*entities.UserAttributeDefinition) []UserAttributeRes {
+	res := make([]UserAttributeRes, 0, len(definitions))
+	for _, definition := range definitions {
+		res = append(res, NewUserAttributeRes(*definition))
+	}
+	return res
+}
@@ -0,0 +1,161 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/pkg/nullable"
+
+	"github.com/invopop/validation"
+	"github.com/invopop/validation/is"
+)
+
+type CreateUserPositionReq struct {
+	UserUUID         string              `params:"userUUID"`
+	OrganizationUUID nullable.NullString `json:"organization_id"`
+	Title            nullable.NullString `json:"title"`
+	IsPrimary        bool                `json:"is_primary"`
+	StartDate        nullable.NullString `json:"start_date"`
+	EndDate          nullable.NullString `json:"end_date"`
+}
+
+func (r CreateUserPositionReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.OrganizationUUID, validation.Required, is.UUID),
+		validation.Field(&r.Title, validation.Required, validation.Length(1, 255)),
+		validation.Field(&r.StartDate, validation.Required, validation.Date(entities.PositionDateLayout)),
+		validation.Field(&r.EndDate, validation.Date(entities.PositionDateLayout)),
+	)
+}
+
+func (r CreateUserPositionReq) NewUserPosition(cred entities.AuthenticatedUser) entities.UserPosition {
+	position := entities.UserPosition{
+		UserUUID:         r.UserUUID,
+		OrganizationUUID: r.OrganizationUUID.GetOrDefault(),
+		Title:            r.Title,
+		IsPrimary:        r.IsPrimary,
+		StartDate:        parsePositionDate(r.StartDate),
+	}
+
+	if r.EndDate.IsNotEmpty() {
+		endDate := parsePositionDate(r.EndDate)
+		position.EndDate = &endDate
+	}
+
+	position.BaseModel = entities.NewBaseModel(cred.Username)
+
+	return position
+}
+
+type UpdateUserPositionReq struct {
+	UserUUID         string              `params:"userUUID"`
+	PositionUUID     string              `params:"positionUUID"`
+	OrganizationUUID nullable.NullString `json:"organization_id"`
+	Title            nullable.NullString `json:"title"`
+	IsPrimary        *bool               `json:"is_primary"`
+	StartDate        nullable.NullString `json:"start_date"`
+	EndDate          nullable.NullString `json:"end_date"`
+}
+
+func (r UpdateUserPositionReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.OrganizationUUID, is.UUID),
+		validation.Field(&r.Title, validation.Length(1, 255)),
+		validation.Field(&r.StartDate, validation.Date(entities.PositionDateLayout)),
+		validation.Field(&r.EndDate, validation.Date(entities.PositionDateLayout)),
+	)
+}
+
+// Apply copies the provided fields onto the stored position, a null end_date makes it open ended
+func (r UpdateUserPositionReq) Apply(position *entities.UserPosition, cred entities.AuthenticatedUser) {
+	if r.OrganizationUUID.IsNotEmpty() {
+		position.OrganizationUUID = r.OrganizationUUID.GetOrDefault()
+	}
+	if r.Title.IsNotEmpty() {
+		position.Title = r.Title
+	}
+	if r.IsPrimary != nil {
+		position.IsPrimary = *r.IsPrimary
+	}
+	if r.StartDate.IsNotEmpty() {
+		position.StartDate = parsePositionDate(r.StartDate)
+	}
+	if r.EndDate.IsExists {
+		position.EndDate = nil
+		if r.EndDate.IsNotEmpty() {
+			endDate := parsePositionDate(r.EndDate)
+			position.EndDate = &endDate
+		}
+	}
+
+	position.UpdateModel(cred.Username)
+}
+
+// parsePositionDate is only called after Validate, so the layout is known to match
+func parsePositionDate(value nullable.NullString) time.Time {
+	date, _ := time.Parse(entities.PositionDateLayout, value.GetOrDefault())
+	return date
+}
+
+type SwitchOrganizationReq struct {
+	OrganizationUUID nullable.NullString `json:"organization_id"`
+}
+
+func (r SwitchOrganizationReq) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.OrganizationUUID, validation.Required, is.UUID),
+	)
+}
+
+type UserPositionRes struct {
+	UUID         string                      `json:"id"`
+	Title        nullable.NullString         `json:"title"`
+	IsPrimary    bool                        `json:"is_primary"`
+	IsActive     bool                        `json:"is_active"`
+	StartDate    string                      `json:"start_date"`
+	EndDate      *string                     `json:"end_date"`
+	Organization UserPositionResOrganization `json:"organization"`
+}
+
+type UserPositionResOrganization struct {
+	UUID string              `json:"id"`
+	Name nullable.NullString `json:"name"`
+	Code nullable.NullString `json:"code"`
+	Type nullable.NullString `json:"type"`
+}
+
+func NewUserPositionRes(position entities.UserPosition, now time.Time) UserPositionRes {
+	res := UserPositionRes{
+		UUID:      position.UUID,
+		Title:     position.Title,
+		IsPrimary: position.IsPrimary,
+		IsActive:  position.IsActiveAt(now),
+		StartDate: position.StartDate.Format(entities.PositionDateLayout),
+		Organization: UserPositionResOrganization{
+			UUID: position.OrganizationUUID,
+		},
+	}
+
+	if position.EndDate != nil {
+		endDate := position.EndDate.Format(entities.PositionDateLayout)
+		res.EndDate = &endDate
+	}
+
+	if position.Organization != nil {
+		res.Organization.Name = position.Organization.Name
+		res.Organization.Code = position.Organization.Code
+		res.Organization.Type = position.Organization.Type
+	}
+
+	return res
+}
+
+func NewListUserPositionRes(positions entities.UserPositions) []UserPositionRes {
+	now := time.Now()
+	res := make([]UserPositionRes, 0, len(positions))
+	for _, position := range positions {
+		res = append(res, NewUserPositionRes(*position, now))
+	}
+
+	return res
+}
@@ -6,18 +6,22 @@ import (
 )
 
 type WhoamiRes struct {
-	UUID                string                `json:"id"`
-	EmployeeID          nullable.NullString   `json:"employee_id"`
-	Username            nullable.NullString   `json:"username"`
-	FirstName           nullable.NullString   `json:"first_name"`
-	LastName            nullable.NullString   `json:"last_name"`
-	PhoneNumber         nullable.NullString   `json:"phone_number"`
-	AvatarGradientStart nullable.NullString   `json:"avatar_gradient_start"`
-	AvatarGradientEnd   nullable.NullString   `json:"avatar_gradient_end"`
-	Roles               []WhoamiResRole       `json:"roles"`
-	Permissions         []WhoamiResPermission `json:"permissions"`
-	Organization        WhoamiResOrganization `json:"organization"`
-	Scopes              []string              `json:"scopes"`
+	UUID                string                  `json:"id"`
+	EmployeeID          nullable.NullString     `json:"employee_id"`
+	Username            nullable.NullString     `json:"username"`
+	FirstName           nullable.NullString     `json:"first_name"`
+	LastName            nullable.NullString     `json:"last_name"`
+	PhoneNumber         nullable.NullString     `json:"phone_number"`
+	AvatarGradientStart nullable.NullString     `json:"avatar_gradient_start"`
+	AvatarGradientEnd   nullable.NullString     `json:"avatar_gradient_end"`
+	AvatarURLs          entities.AvatarURLs     `json:"avatar_urls"`
+	Roles               []WhoamiResRole         `json:"roles"`
+	Permissions         []WhoamiResPermission   `json:"permissions"`
+	Organization        WhoamiResOrganization   `json:"organization"`
+	ActiveOrganization  WhoamiResOrganization   `json:"active_organization"`
+	Positions           []UserPositionRes       `json:"positions"`
+	Attributes          entities.UserAttributes `json:"attributes"`
+	Scopes              []string                `json:"scopes"`
 }
 
 type WhoamiResRole struct {
@@ -38,7 +42,8 @@ type WhoamiResPermission struct {
 	Action   nullable.NullString `json:"action"`
 }
 
-func NewWhoamiRes(user entities.User, scopes []string) WhoamiRes {
+// NewWhoamiRes builds the response, activeOrganizationUUID is the organization the token acts in
+func NewWhoamiRes(user entities.User, scopes []string, activeOrganizationUUID string) WhoamiRes {
 	whoami := WhoamiRes{
 		UUID:                user.UUID,
 		EmployeeID:          user.EmployeeID,
@@ -48,12 +53,31 @@ func NewWhoamiRes(user entities.User, scopes []string) WhoamiRes {
 		PhoneNumber:         user.PhoneNumber,
 		AvatarGradientStart: user.AvatarGradientStart,
 		AvatarGradientEnd:   user.AvatarGradientEnd,
+		AvatarURLs:          user.AvatarURLs,
 		Organization: WhoamiResOrganization{
 			UUID: user.Organization.UUID,
 			Name: user.Organization.Name,
 			Type: user.Organization.Type,
 		},
-		Scopes: scopes,
+		ActiveOrganization: WhoamiResOrganization{
+			UUID: user.Organization.UUID,
+			Name: user.Organization.Name,
+			Type: user.Organization.Type,
+		},
+		Positions:  NewListUserPositionRes(user.Positions),
+		Attributes: user.Attributes,
+		Scopes:     scopes,
+	}
+
+	for _, position := range user.Positions {
+		if position.OrganizationUUID == activeOrganizationUUID && position.Organization != nil {
+			whoami.ActiveOrganization = WhoamiResOrganization{
+				UUID: position.OrganizationUUID,
+				Name: position.Organization.Name,
+				Type: position.Organization.Type,
+			}
+			break
+		}
 	}
 
 	for _, role := range user.Roles {
@@ -2,20 +2,30 @@ package user
 
 import (
 	"context"
+	"iter"
+	"time"
 
 	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/pkg/database"
+	"github.com/laksanagusta/identity/pkg/nullable"
 	"github.com/laksanagusta/identity/pkg/pagination"
 )
 
 type Repository interface {
+	WithTransaction(tx database.DBTx) Repository
+
 	Insert(ctx context.Context, user entities.User) (string, error)
 	FindByUsername(ctx context.Context, username string) (*entities.User, error)
+	FindByEmail(ctx context.Context, email string) (*entities.User, error)
 	FindByPhoneNumber(ctx context.Context, phoneNumber string) (*entities.User, error)
 	FindByEmployeeID(ctx context.Context, employeeID string) (*entities.User, error)
 	Update(ctx context.Context, user entities.User) error
+	UpdateAvatarKey(ctx context.Context, user entities.User) error
 	UpdateApprovalStatus(ctx context.Context, userUUID string, isApproved bool, updatedBy string) error
 	FindByUUID(ctx context.Context, uuid string) (*entities.User, error)
 	Index(ctx context.Context, params *pagination.QueryParams) ([]*entities.User, int64, error)
+	Suggest(ctx context.Context, term string, limit int) ([]*entities.User, error)
+	StreamUsers(ctx context.Context, params *pagination.QueryParams) (iter.Seq2[*entities.User, error], error)
 	Delete(ctx context.Context, uuid string, username string) error
 
 	// role
@@ -53,4 +63,76 @@ type Repository interface {
 	DeleteRolePermission(ctx context.Context, uuid string) error
 	FindRolePermissionByUUID(ctx context.Context, uuid string) (*entities.RolaPermission, error)
 	FindPermissionByRoleUUIDs(ctx context.Context, roleUUIDs []string) ([]*entities.Permission, error)
+
+	// user attribute definition
+	FindAttributeDefinitions(ctx context.Context) ([]*entities.UserAttributeDefinition, error)
+	FindAttributeDefinitionByUUID(ctx context.Context, uuid string) (*entities.UserAttributeDefinition, error)
+	FindAttributeDefinitionByKey(ctx context.Context, key string) (*entities.UserAttributeDefinition, error)
+	InsertAttributeDefinition(ctx context.Context, definition entities.UserAttributeDefinition) (string, error)
+	UpdateAttributeDefinition(ctx context.Context, definition entities.UserAttributeDefinition) error
+	DeleteAttributeDefinition(ctx context.Context, uuid string) error
+	RemoveUserAttribute(ctx context.Context, key string) error
+
+	// reporting line
+	UpdateManager(ctx context.Context, user entities.User) error
+	LockReportingLines(ctx context.Context) error
+	FindDirectReports(ctx context.Context, managerUUID string) ([]*entities.User, error)
+	FindManagementChain(ctx context.Context, userUUID string) ([]*entities.User, error)
+	FindUsersByOrganizationUUIDs(ctx context.Context, organizationUUIDs []string) ([]*entities.User, error)
+
+	// position
+	FindPositionsByUserUUID(ctx context.Context, userUUID string) (entities.UserPositions, error)
+	FindPositionByUUID(ctx context.Context, uuid string) (*entities.UserPosition, error)
+	InsertPosition(ctx context.Context, position entities.UserPosition) (string, error)
+	UpdatePosition(ctx context.Context, position entities.UserPosition) error
+	DeletePosition(ctx context.Context, uuid string) error
+	ClearPrimaryPosition(ctx context.Context, userUUID string, exceptUUID string) error
+	UpdateOrganizationUUID(ctx context.Context, userUUID string, organizationUUID string, updatedBy string) error
+
+	// group
+	FindGroups(ctx context.Context) ([]*entities.Group, error)
+	FindGrantedDynamicGroups(ctx context.Context) ([]*entities.Group, error)
+	FindGroupByUUID(ctx context.Context, uuid string) (*entities.Group, error)
+	FindGroupByName(ctx context.Context, name string) (*entities.Group, error)
+	InsertGroup(ctx context.Context, group entities.Group) (string, error)
+	UpdateGroup(ctx context.Context, group entities.Group) error
+	DeleteGroup(ctx context.Context, uuid string) error
+	InsertGroupRole(ctx context.Context, groupRole entities.GroupRole) error
+	DeleteGroupRole(ctx context.Context, groupUUID string, roleUUID string) error
+	FindRolesByGroupUUIDs(ctx context.Context, groupUUIDs []string) ([]*entities.Role, error)
+	FindGroupMembers(ctx context.Context, group entities.Group, page pagination.Pagination) ([]*entities.User, int64, error)
+	IsGroupMember(ctx context.Context, group entities.Group, userUUID string) (bool, error)
+	FindGroupMembersByGroupUUID(ctx context.Context, groupUUID string) ([]*entities.GroupMember, error)
+	FindGroupMemberByUUID(ctx context.Context, uuid string) (*entities.GroupMember, error)
+	InsertGroupMember(ctx context.Context, member entities.GroupMember) error
+	DeleteGroupMember(ctx context.Context, uuid string) error
+	LockGroupMembers(ctx context.Context) error
+	FindGroupDescendantUUIDs(ctx context.Context, groupUUID string) ([]string, error)
+	FindStaticGroupUUIDsByUserUUID(ctx context.Context, userUUID string) ([]string, error)
+
+	// audit & login history
+	InsertAuditLog(ctx context.Context, auditLog entities.AuditLog) error
+	FindAuditLogsByUser(ctx context.Context, userUUID string, username string) ([]*entities.AuditLog, error)
+	InsertLoginHistory(ctx context.Context, loginHistory entities.LoginHistory) error
+	FindLoginHistoriesByUserUUID(ctx context.Context, userUUID string) ([]*entities.LoginHistory, error)
+	UpdateLastLoginAt(ctx context.Context, userUUID string, loginAt time.Time) error
+
+	// user import
+	InsertUserImportJob(ctx context.Context, job entities.UserImportJob) (string, error)
+	FindUserImportJobByUUID(ctx context.Context, uuid string) (*entities.UserImportJob, error)
+	IndexUserImportJob(ctx context.Context, params *pagination.QueryParams) ([]*entities.UserImportJob, int64, error)
+	ClaimUserImportJob(ctx context.Context, staleBefore time.Time) (*entities.UserImportJob, error)
+	SaveUserImportResult(ctx context.Context, jobUUID string, result entities.UserImportResult) error
+	FinishUserImportJob(ctx context.Context, jobUUID string, status string, errMsg nullable.NullString) error
+
+	// change feed
+	IndexChangeEvent(ctx context.Context, params entities.ListChangeEventParams) ([]*entities.ChangeEvent, error)
+	LatestChangeCursor(ctx context.Context) (entities.ChangeCursor, error)
+	PurgeChangeEvents(ctx context.Context, before time.Time) (int64, error)
+	IsChangeCursorPurged(ctx context.Context, cursor entities.ChangeCursor) (bool, error)
+
+	// erasure
+	Pseudonymize(ctx context.Context, user entities.User) error
+	ReplaceActorReferences(ctx context.Context, oldUsername string, newUsername string) error
+	PseudonymizeChangeEvents(ctx context.Context, user entities.User) error
 }
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/laksanagusta/identity/internal/entities"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+func scanAttributeDefinition(row interface{ Scan(dest ...any) error }) (*entities.UserAttributeDefinition, error) {
+	var definition entities.UserAttributeDefinition
+	err := row.Scan(
+		&definition.UUID,
+		&definition.Key,
+		&definition.Label,
+		&definition.Description,
+		&definition.Type,
+		&definition.IsRequired,
+		pq.Array(&definition.EnumValues),
+		&definition.Regex,
+		&definition.Visibility,
+		&definition.IsEditable,
+		&definition.CreatedAt,
+		&definition.CreatedBy,
+		&definition.UpdatedAt,
+		&definition.UpdatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &definition, nil
+}
+
+func (r *userRepo) FindAttributeDefinitions(ctx context.Context) ([]*entities.UserAttributeDefinition, error) {
+	rows, err := r.db.QueryxContext(ctx, findAttributeDefinitions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	definitions := []*entities.UserAttributeDefinition{}
+	for rows.Next() {
+		definition, err := scanAttributeDefinition(rows)
+		if err != nil {
+			return nil, err
+		}
+		definitions = append(definitions, definition)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return definitions, nil
+}
+
+func (r *userRepo) FindAttributeDefinitionByUUID(ctx context.Context, uuid string) (*entities.UserAttributeDefinition, error) {
+	return r.findAttributeDefinition(r.db.QueryRowxContext(ctx, findAttributeDefinitionByUUID, uuid))
+}
+
+func (r *userRepo) FindAttributeDefinitionByKey(ctx context.Context, key string) (*entities.UserAttributeDefinition, error) {
+	return r.findAttributeDefinition(r.db.QueryRowxContext(ctx, findAttributeDefinitionByKey, key))
+}
+
+func (r *userRepo) findAttributeDefinition(row *sqlx.Row) (*entities.UserAttributeDefinition, error) {
+	definition, err := scanAttributeDefinition(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return definition, nil
+}
+
+func (r *userRepo) InsertAttributeDefinition(ctx context.Context, definition entities.UserAttributeDefinition) (string, error) {
+	var returnedUUID string
+	err := r.db.GetContext(ctx,
+		&returnedUUID,
+		insertAttributeDefinition,
+		definition.UUID,
+		definition.Key,
+		definition.Label,
+		definition.Description,
+		definition.Type,
+		definition.IsRequired,
+		pq.Array(definition.EnumValues),
+		definition.Regex,
+		definition.Visibility,
+		definition.IsEditable,
+		definition.CreatedAt,
+		definition.CreatedBy,
+		definition.UpdatedAt,
+		definition.UpdatedBy,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return returnedUUID, nil
+}
+
+func (r *userRepo) UpdateAttributeDefinition(ctx context.Context, definition entities.UserAttributeDefinition) error {
+	_, err := r.db.ExecContext(ctx,
+		updateAttributeDefinition,
+		definition.Label,
+		definition.Description,
+		definition.IsRequired,
+		pq.Array(definition.EnumValues),
+		definition.Regex,
+		definition.Visibility,
+		definition.IsEditable,
+		definition.UpdatedAt,
+		definition.UpdatedBy,
+		definition.UUID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *userRepo) DeleteAttributeDefinition(ctx context.Context, uuid string) error {
+	_, err := r.db.ExecContext(ctx, deleteAttributeDefinition, uuid)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *userRepo) RemoveUserAttribute(ctx context.Context, key string) error {
+	_, err := r.db.ExecContext(ctx, removeUserAttribute, key)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
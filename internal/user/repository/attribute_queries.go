@@ -0,0 +1,63 @@
+package repository
+
+var (
+	selectAttributeDefinition = `
+		SELECT
+			uuid,
+			key,
+			label,
+			description,
+			type,
+			is_required,
+			enum_values,
+			regex,
+			visibility,
+			is_editable,
+			created_at,
+			created_by,
+			updated_at,
+			updated_by
+		FROM user_attribute_definitions
+	`
+
+	findAttributeDefinitions = selectAttributeDefinition + ` ORDER BY key ASC`
+
+	findAttributeDefinitionByUUID = selectAttributeDefinition + ` WHERE uuid = $1 LIMIT 1`
+
+	findAttributeDefinitionByKey = selectAttributeDefinition + ` WHERE key = $1 LIMIT 1`
+
+	insertAttributeDefinition = `INSERT INTO user_attribute_definitions (
+		uuid,
+		key,
+		label,
+		description,
+		type,
+		is_required,
+		enum_values,
+		regex,
+		visibility,
+		is_editable,
+		created_at,
+		created_by,
+		updated_at,
+		updated_by
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) RETURNING uuid`
+
+	updateAttributeDefinition = `
+		UPDATE user_attribute_definitions SET
+			label = $1,
+			description = $2,
+			is_required = $3,
+			enum_values = $4,
+			regex = $5,
+			visibility = $6,
+			is_editable = $7,
+			updated_at = $8,
+			updated_by = $9
+		WHERE uuid = $10
+	`
+
+	deleteAttributeDefinition = `DELETE FROM user_attribute_definitions WHERE uuid = $1`
+
+	removeUserAttribute = `UPDATE users SET attributes = attributes - $1 WHERE attributes ? $1`
+)
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/laksanagusta/identity/internal/entities"
+)
+
+func (r *userRepo) InsertAuditLog(ctx context.Context, auditLog entities.AuditLog) error {
+	_, err := r.db.ExecContext(ctx,
+		insertAuditLog,
+		auditLog.UUID,
+		auditLog.Actor,
+		auditLog.Action,
+		auditLog.EntityType,
+		auditLog.EntityUUID,
+		auditLog.Metadata,
+		auditLog.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *userRepo) FindAuditLogsByUser(ctx context.Context, userUUID string, username string) ([]*entities.AuditLog, error) {
+	rows, err := r.db.QueryxContext(ctx, findAuditLogsByUser, entities.AuditEntityUser, userUUID, username)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	auditLogs := []*entities.AuditLog{}
+	for rows.Next() {
+		var auditLog entities.AuditLog
+		err := rows.Scan(
+			&auditLog.UUID,
+			&auditLog.Actor,
+			&auditLog.Action,
+			&auditLog.EntityType,
+			&auditLog.EntityUUID,
+			&auditLog.Metadata,
+			&auditLog.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		auditLogs = append(auditLogs, &auditLog)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return auditLogs, nil
+}
+
+func (r *userRepo) InsertLoginHistory(ctx context.Context, loginHistory entities.LoginHistory) error {
+	_, err := r.db.ExecContext(ctx,
+		insertLoginHistory,
+		loginHistory.UUID,
+		loginHistory.UserUUID,
+		loginHistory.IPAddress,
+		loginHistory.UserAgent,
+		loginHistory.IsSuccess,
+		loginHistory.FailureReason,
+		loginHistory.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *userRepo) FindLoginHistoriesByUserUUID(ctx context.Context, userUUID string) ([]*entities.LoginHistory, error) {
+	rows, err := r.db.QueryxContext(ctx, findLoginHistoriesByUserUUID, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	loginHistories := []*entities.LoginHistory{}
+	for rows.Next() {
+		var loginHistory entities.LoginHistory
+		err := rows.Scan(
+			&loginHistory.UUID,
+			&loginHistory.UserUUID,
+			&loginHistory.IPAddress,
+			&loginHistory.UserAgent,
+			&loginHistory.IsSuccess,
+			&loginHistory.FailureReason,
+			&loginHistory.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		loginHistories = append(loginHistories, &loginHistory)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return loginHistories, nil
+}
+
+func (r *userRepo) UpdateLastLoginAt(ctx context.Context, userUUID string, loginAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, updateLastLoginAt, loginAt, userUUID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *userRepo) Pseudonymize(ctx context.Context, user entities.User) error {
+	res, err := r.db.ExecContext(ctx,
+		pseudonymizeUser,
+		user.Username,
+		user.FirstName,
+		user.ErasedAt,
+		user.UpdatedBy,
+		user.UUID,
+	)
+	if err != nil {
+		return err
+	}
+	rowAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowAffected == 0 {
+		return errors.New("no row affected")
+	}
+
+	_, err = r.db.ExecContext(ctx, anonymizeLoginHistories, user.UUID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *userRepo) ReplaceActorReferences(ctx context.Context, oldUsername string, newUsername string) error {
+	for _, query := range replaceActorReferences {
+		_, err := r.db.ExecContext(ctx, query, oldUsername, newUsername)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *userRepo) PseudonymizeChangeEvents(ctx context.Context, user entities.User) error {
+	_, err := r.db.ExecContext(ctx,
+		pseudonymizeChangeEvents,
+		user.Username,
+		user.FirstName,
+		user.UUID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package repository
+
+var (
+	insertAuditLog = `INSERT INTO audit_logs (
+		uuid,
+		actor,
+		action,
+		entity_type,
+		entity_uuid,
+		metadata,
+		created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	findAuditLogsByUser = `
+		SELECT
+			uuid,
+			actor,
+			action,
+			entity_type,
+			entity_uuid,
+			metadata,
+			created_at
+		FROM audit_logs
+		WHERE (entity_type = $1 AND entity_uuid = $2) OR actor = $3
+		ORDER BY created_at DESC
+	`
+
+	insertLoginHistory = `INSERT INTO login_histories (
+		uuid,
+		user_uuid,
+		ip_address,
+		user_agent,
+		is_success,
+		failure_reason,
+		created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	findLoginHistoriesByUserUUID = `
+		SELECT
+			uuid,
+			user_uuid,
+			ip_address,
+			user_agent,
+			is_success,
+			failure_reason,
+			created_at
+		FROM login_histories
+		WHERE user_uuid = $1
+		ORDER BY created_at DESC
+	`
+
+	updateLastLoginAt = `UPDATE users SET last_login_at = $1 WHERE uuid = $2`
+
+	pseudonymizeUser = `
+		UPDATE users SET
+			username = $1,
+			first_name = $2,
+			last_name = NULL,
+			email = NULL,
+			phone_number = NULL,
+			employee_id = NULL,
+			attributes = '{}'::jsonb,
+			avatar_key = NULL,
+			password_hash = '',
+			is_active = false,
+			erased_at = $3,
+			updated_at = $3,
+			updated_by = $4
+		WHERE uuid = $5 AND erased_at IS NULL
+	`
+
+	anonymizeLoginHistories = `UPDATE login_histories SET ip_address = NULL, user_agent = NULL WHERE user_uuid = $1`
+
+	// change events keep a copy of the user as it was written, so the same fields are replaced there
+	pseudonymizeChangeEvents = `
+		UPDATE change_events SET data = data || jsonb_build_object(
+			'username', $1::text,
+			'first_name', $2::text,
+			'last_name', NULL,
+			'email', NULL,
+			'phone_number', NULL,
+			'employee_id', NULL,
+			'attributes', '{}'::jsonb
+		)
+		WHERE entity_type = 'user' AND entity_uuid = $3 AND data IS NOT NULL
+	`
+
+	// actor columns store usernames, so they are rewritten to the pseudonym
+	// to keep created_by/updated_by and the audit trail pointing at the same principal
+	replaceActorReferences = []string{
+		`UPDATE users SET created_by = CASE WHEN created_by = $1 THEN $2 ELSE created_by END, updated_by = CASE WHEN updated_by = $1 THEN $2 ELSE updated_by END, deleted_by = CASE WHEN deleted_by = $1 THEN $2 ELSE deleted_by END WHERE created_by = $1 OR updated_by = $1 OR deleted_by = $1`,
+		`UPDATE organizations SET created_by = CASE WHEN created_by = $1 THEN $2 ELSE created_by END, updated_by = CASE WHEN updated_by = $1 THEN $2 ELSE updated_by END, deleted_by = CASE WHEN deleted_by = $1 THEN $2 ELSE deleted_by END WHERE created_by = $1 OR updated_by = $1 OR deleted_by = $1`,
+		`UPDATE roles SET created_by = CASE WHEN created_by = $1 THEN $2 ELSE created_by END, updated_by = CASE WHEN updated_by = $1 THEN $2 ELSE updated_by END, deleted_by = CASE WHEN deleted_by = $1 THEN $2 ELSE deleted_by END WHERE created_by = $1 OR updated_by = $1 OR deleted_by = $1`,
+		`UPDATE permissions SET created_by = CASE WHEN created_by = $1 THEN $2 ELSE created_by END, updated_by = CASE WHEN updated_by = $1 THEN $2 ELSE updated_by END WHERE created_by = $1 OR updated_by = $1`,
+		`UPDATE role_permissions SET created_by = CASE WHEN created_by = $1 THEN $2 ELSE created_by END, updated_by = CASE WHEN updated_by = $1 THEN $2 ELSE updated_by END WHERE created_by = $1 OR updated_by = $1`,
+		`UPDATE user_roles SET created_by = CASE WHEN created_by = $1 THEN $2 ELSE created_by END, updated_by = CASE WHEN updated_by = $1 THEN $2 ELSE updated_by END WHERE created_by = $1 OR updated_by = $1`,
+		`UPDATE user_attribute_definitions SET created_by = CASE WHEN created_by = $1 THEN $2 ELSE created_by END, updated_by = CASE WHEN updated_by = $1 THEN $2 ELSE updated_by END WHERE created_by = $1 OR updated_by = $1`,
+		`UPDATE user_positions SET created_by = CASE WHEN created_by = $1 THEN $2 ELSE created_by END, updated_by = CASE WHEN updated_by = $1 THEN $2 ELSE updated_by END WHERE created_by = $1 OR updated_by = $1`,
+		`UPDATE user_groups SET created_by = CASE WHEN created_by = $1 THEN $2 ELSE created_by END, updated_by = CASE WHEN updated_by = $1 THEN $2 ELSE updated_by END WHERE created_by = $1 OR updated_by = $1`,
+		`UPDATE user_group_roles SET created_by = CASE WHEN created_by = $1 THEN $2 ELSE created_by END, updated_by = CASE WHEN updated_by = $1 THEN $2 ELSE updated_by END WHERE created_by = $1 OR updated_by = $1`,
+		`UPDATE user_group_members SET created_by = CASE WHEN created_by = $1 THEN $2 ELSE created_by END, updated_by = CASE WHEN updated_by = $1 THEN $2 ELSE updated_by END WHERE created_by = $1 OR updated_by = $1`,
+		`UPDATE organization_types SET created_by = CASE WHEN created_by = $1 THEN $2 ELSE created_by END, updated_by = CASE WHEN updated_by = $1 THEN $2 ELSE updated_by END WHERE created_by = $1 OR updated_by = $1`,
+		`UPDATE organization_versions SET created_by = CASE WHEN created_by = $1 THEN $2 ELSE created_by END, updated_by = CASE WHEN updated_by = $1 THEN $2 ELSE updated_by END WHERE created_by = $1 OR updated_by = $1`,
+		`UPDATE organization_setting_definitions SET created_by = CASE WHEN created_by = $1 THEN $2 ELSE created_by END, updated_by = CASE WHEN updated_by = $1 THEN $2 ELSE updated_by END WHERE created_by = $1 OR updated_by = $1`,
+		`UPDATE organization_settings SET created_by = CASE WHEN created_by = $1 THEN $2 ELSE created_by END, updated_by = CASE WHEN updated_by = $1 THEN $2 ELSE updated_by END WHERE created_by = $1 OR updated_by = $1`,
+		`UPDATE audit_logs SET actor = $2 WHERE actor = $1`,
+	}
+)
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/laksanagusta/identity/internal/entities"
+
+	"github.com/lib/pq"
+)
+
+func (r *userRepo) IndexChangeEvent(ctx context.Context, params entities.ListChangeEventParams) ([]*entities.ChangeEvent, error) {
+	rows, err := r.db.QueryxContext(ctx,
+		indexChangeEvents,
+		// xid8 exceeds the signed integers the driver accepts
+		strconv.FormatUint(params.After.TxID, 10),
+		params.After.ID,
+		pq.Array(params.Types),
+		params.Limit,
+		params.OrganizationUUID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []*entities.ChangeEvent{}
+	for rows.Next() {
+		var event entities.ChangeEvent
+		var data []byte
+		err := rows.Scan(
+			&event.ID,
+			&event.TxID,
+			&event.EntityType,
+			&event.EntityUUID,
+			&event.Action,
+			&data,
+			&event.OccurredAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if data != nil {
+			event.Data = data
+		}
+
+		events = append(events, &event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// LatestChangeCursor returns the cursor of the last visible event, the start of the feed when it is empty
+func (r *userRepo) LatestChangeCursor(ctx context.Context) (entities.ChangeCursor, error) {
+	var cursor entities.ChangeCursor
+	err := r.db.QueryRowxContext(ctx, latestChangeEvent).Scan(&cursor.TxID, &cursor.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return entities.ChangeCursor{}, err
+	}
+
+	return cursor, nil
+}
+
+// PurgeChangeEvents deletes the events that occurred before and returns how many were deleted
+func (r *userRepo) PurgeChangeEvents(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+	err := r.db.GetContext(ctx, &purged, purgeChangeEvents, before)
+	if err != nil {
+		return 0, err
+	}
+
+	return purged, nil
+}
+
+// IsChangeCursorPurged reports whether events after cursor were purged, reading on from it would skip them
+func (r *userRepo) IsChangeCursorPurged(ctx context.Context, cursor entities.ChangeCursor) (bool, error) {
+	var purged bool
+	err := r.db.GetContext(ctx, &purged, isChangeCursorPurged, strconv.FormatUint(cursor.TxID, 10), cursor.ID)
+	if err != nil {
+		return false, err
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,61 @@
+package repository
+
+const (
+	// indexChangeEvents stops before the oldest running transaction, events it is still writing
+	// would otherwise appear behind cursors that consumers already have. $3 holds entity types or
+	// event types, a non empty $5 keeps the events of the organizations below it and of roles.
+	indexChangeEvents = `
+		SELECT id, txid, entity_type, entity_uuid, action, data, occurred_at
+		FROM change_events e
+		WHERE (txid, id) > ($1::xid8, $2)
+			AND txid < pg_snapshot_xmin(pg_current_snapshot())
+			AND (entity_type = ANY($3) OR entity_type || '.' || action = ANY($3))
+			AND ($5::text = '' OR organization_uuids IS NULL OR EXISTS (
+				SELECT 1 FROM organization_closure c
+				WHERE c.ancestor_uuid = NULLIF($5::text, '')::uuid AND c.descendant_uuid = ANY(e.organization_uuids)
+			))
+		ORDER BY txid, id
+		LIMIT $4
+	`
+
+	// latestChangeEvent is the last event readers can see, new streams start after it
+	latestChangeEvent = `
+		SELECT txid, id
+		FROM change_events
+		WHERE txid < pg_snapshot_xmin(pg_current_snapshot())
+		ORDER BY txid DESC, id DESC
+		LIMIT 1
+	`
+
+	// purgeChangeEvents deletes the events up to the last one that occurred before $1 and readers can
+	// see, and remembers that event as the end of the purged range
+	purgeChangeEvents = `
+		WITH boundary AS (
+			SELECT txid, id
+			FROM change_events
+			WHERE occurred_at < $1 AND txid < pg_snapshot_xmin(pg_current_snapshot())
+			ORDER BY txid DESC, id DESC
+			LIMIT 1
+		),
+		purged AS (
+			DELETE FROM change_events e
+			USING boundary b
+			WHERE (e.txid, e.id) <= (b.txid, b.id)
+			RETURNING e.id
+		),
+		retention AS (
+			INSERT INTO change_event_retention (txid, event_id)
+			SELECT txid, id FROM boundary
+			ON CONFLICT (id) DO UPDATE SET
+				txid = EXCLUDED.txid,
+				event_id = EXCLUDED.event_id,
+				purged_at = NOW()
+		)
+		SELECT count(*) FROM purged
+	`
+
+	// isChangeCursorPurged tells whether events after the cursor were already deleted
+	isChangeCursorPurged = `
+		SELECT EXISTS (SELECT 1 FROM change_event_retention WHERE (txid, event_id) > ($1::xid8, $2))
+	`
+)
@@ -0,0 +1,480 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/pkg/pagination"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+func scanGroup(row interface{ Scan(dest ...any) error }) (*entities.Group, error) {
+	var group entities.Group
+	err := row.Scan(
+		&group.UUID,
+		&group.Name,
+		&group.Description,
+		&group.Type,
+		&group.Filters,
+		&group.CreatedAt,
+		&group.CreatedBy,
+		&group.UpdatedAt,
+		&group.UpdatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &group, nil
+}
+
+func (r *userRepo) FindGroups(ctx context.Context) ([]*entities.Group, error) {
+	return r.findGroups(ctx, findGroups)
+}
+
+func (r *userRepo) FindGrantedDynamicGroups(ctx context.Context) ([]*entities.Group, error) {
+	return r.findGroups(ctx, findGrantedDynamicGroups)
+}
+
+func (r *userRepo) findGroups(ctx context.Context, query string) ([]*entities.Group, error) {
+	rows, err := r.db.QueryxContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	groups := []*entities.Group{}
+	for rows.Next() {
+		group, err := scanGroup(rows)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+func (r *userRepo) FindGroupByUUID(ctx context.Context, uuid string) (*entities.Group, error) {
+	return r.findGroup(r.db.QueryRowxContext(ctx, findGroupByUUID, uuid))
+}
+
+func (r *userRepo) FindGroupByName(ctx context.Context, name string) (*entities.Group, error) {
+	return r.findGroup(r.db.QueryRowxContext(ctx, findGroupByName, name))
+}
+
+func (r *userRepo) findGroup(row *sqlx.Row) (*entities.Group, error) {
+	group, err := scanGroup(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (r *userRepo) InsertGroup(ctx context.Context, group entities.Group) (string, error) {
+	var returnedUUID string
+	err := r.db.GetContext(ctx,
+		&returnedUUID,
+		insertGroup,
+		group.UUID,
+		group.Name,
+		group.Description,
+		group.Type,
+		group.Filters,
+		group.CreatedAt,
+		group.CreatedBy,
+		group.UpdatedAt,
+		group.UpdatedBy,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return returnedUUID, nil
+}
+
+func (r *userRepo) UpdateGroup(ctx context.Context, group entities.Group) error {
+	_, err := r.db.ExecContext(ctx,
+		updateGroup,
+		group.Name,
+		group.Description,
+		group.Filters,
+		group.UpdatedAt,
+		group.UpdatedBy,
+		group.UUID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *userRepo) DeleteGroup(ctx context.Context, uuid string) error {
+	_, err := r.db.ExecContext(ctx, deleteGroup, uuid)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *userRepo) InsertGroupRole(ctx context.Context, groupRole entities.GroupRole) error {
+	_, err := r.db.ExecContext(ctx,
+		insertGroupRole,
+		groupRole.UUID,
+		groupRole.GroupUUID,
+		groupRole.RoleUUID,
+		groupRole.CreatedAt,
+		groupRole.CreatedBy,
+		groupRole.UpdatedAt,
+		groupRole.UpdatedBy,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *userRepo) DeleteGroupRole(ctx context.Context, groupUUID string, roleUUID string) error {
+	_, err := r.db.ExecContext(ctx, deleteGroupRole, groupUUID, roleUUID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *userRepo) FindRolesByGroupUUIDs(ctx context.Context, groupUUIDs []string) ([]*entities.Role, error) {
+	rows, err := r.db.QueryxContext(ctx, findRolesByGroupUUIDs, pq.Array(groupUUIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := []*entities.Role{}
+	for rows.Next() {
+		var role entities.Role
+		err := rows.Scan(
+			&role.UUID,
+			&role.Name,
+			&role.Description,
+		)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, &role)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+func (r *userRepo) FindGroupMembers(ctx context.Context, group entities.Group, page pagination.Pagination) ([]*entities.User, int64, error) {
+	countBuilder, err := newGroupMemberQuery(countGroupMembers, group)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	countQuery, countArgs := countBuilder.Build()
+
+	var totalCount int64
+	err = r.db.GetContext(ctx, &totalCount, countQuery, countArgs...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	queryBuilder, err := newGroupMemberQuery(selectGroupMembers, group)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, sort := range []pagination.Sort{{Field: "first_name", Order: "asc"}, {Field: "uuid", Order: "asc"}} {
+		if err := queryBuilder.AddSort(sort); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	query, args := queryBuilder.Build()
+	query += fmt.Sprintf(" LIMIT %d OFFSET %d", page.Limit, (page.Page-1)*page.Limit)
+
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	users, err := scanReportingLineUsers(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return users, totalCount, nil
+}
+
+func (r *userRepo) IsGroupMember(ctx context.Context, group entities.Group, userUUID string) (bool, error) {
+	queryBuilder, err := newGroupMemberQuery(checkGroupMember, group)
+	if err != nil {
+		return false, err
+	}
+	queryBuilder.AddRawCondition("u.uuid = " + queryBuilder.Placeholder(userUUID))
+
+	query, args := queryBuilder.Build()
+
+	var isMember bool
+	err = r.db.GetContext(ctx, &isMember, "SELECT EXISTS ("+query+")", args...)
+	if err != nil {
+		return false, err
+	}
+
+	return isMember, nil
+}
+
+// findMemberGroupUUIDs returns the groups among groups that userUUID is a member of in one statement
+func (r *userRepo) findMemberGroupUUIDs(ctx context.Context, groups []*entities.Group, userUUID string) ([]string, error) {
+	if len(groups) == 0 {
+		return nil, nil
+	}
+
+	query, args, err := buildMemberGroupsQuery(groups, userUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	groupUUIDs := []string{}
+	err = r.db.SelectContext(ctx, &groupUUIDs, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return groupUUIDs, nil
+}
+
+// buildMemberGroupsQuery selects the UUID of every group that has userUUID as member, with one EXISTS
+// per group joined by UNION ALL
+func buildMemberGroupsQuery(groups []*entities.Group, userUUID string) (string, []interface{}, error) {
+	checks := make([]string, 0, len(groups))
+	var args []interface{}
+	for _, group := range groups {
+		queryBuilder, err := newGroupMemberQueryAfter(checkGroupMember, *group, args)
+		if err != nil {
+			return "", nil, err
+		}
+		queryBuilder.AddRawCondition("u.uuid = " + queryBuilder.Placeholder(userUUID))
+		groupPlaceholder := queryBuilder.Placeholder(group.UUID)
+
+		var query string
+		query, args = queryBuilder.Build()
+		checks = append(checks, fmt.Sprintf("SELECT %s::text WHERE EXISTS (%s)", groupPlaceholder, query))
+	}
+
+	return strings.Join(checks, " UNION ALL "), args, nil
+}
+
+// newGroupMemberQuery narrows baseQuery, which selects from users aliased u, to the live members
+// of group. Filters are validated on write, the checks here only guard the SQL.
+func newGroupMemberQuery(baseQuery string, group entities.Group) (*pagination.QueryBuilder, error) {
+	return newGroupMemberQueryAfter(baseQuery, group, nil)
+}
+
+// newGroupMemberQueryAfter is newGroupMemberQuery with placeholders numbered after args
+func newGroupMemberQueryAfter(baseQuery string, group entities.Group, args []interface{}) (*pagination.QueryBuilder, error) {
+	queryBuilder := pagination.NewQueryBuilder(baseQuery).
+		ContinueArgs(args).
+		AllowJSONField("attributes").
+		AddRawCondition("u.deleted_at IS NULL").
+		AddRawCondition("u.erased_at IS NULL")
+
+	if group.IsStatic() {
+		queryBuilder.AddRawCondition(fmt.Sprintf(groupStaticMemberCondition, queryBuilder.Placeholder(group.UUID)))
+		return queryBuilder, nil
+	}
+
+	for _, filter := range group.Filters {
+		switch {
+		case filter.Field == entities.GroupFilterFieldRole:
+			queryBuilder.AddRawCondition(groupSetCondition(queryBuilder, filter, groupRoleCondition))
+		case filter.Field == entities.GroupFilterFieldOrganizationSubtree:
+			queryBuilder.AddRawCondition(groupSetCondition(queryBuilder, filter, groupOrganizationSubtreeCondition))
+		case strings.HasPrefix(filter.Field, entities.GroupFilterAttributePrefix):
+			if err := queryBuilder.AddFilter(filter.PaginationFilter(filter.Field)); err != nil {
+				return nil, err
+			}
+		default:
+			column, ok := entities.GroupFilterUserFields[filter.Field]
+			if !ok {
+				return nil, fmt.Errorf("invalid group filter field: %s", filter.Field)
+			}
+
+			queryBuilder.AllowFields(column)
+			if err := queryBuilder.AddFilter(filter.PaginationFilter(column)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return queryBuilder, nil
+}
+
+// groupSetCondition binds the filter values into condition, negated operators exclude the set
+func groupSetCondition(queryBuilder *pagination.QueryBuilder, filter entities.GroupFilter, condition string) string {
+	values := filter.Values()
+	placeholders := make([]string, len(values))
+	for i, value := range values {
+		placeholders[i] = queryBuilder.Placeholder(value)
+	}
+
+	condition = fmt.Sprintf(condition, strings.Join(placeholders, ","))
+	if filter.IsNegated() {
+		return "NOT " + condition
+	}
+
+	return condition
+}
+
+func (r *userRepo) FindGroupMembersByGroupUUID(ctx context.Context, groupUUID string) ([]*entities.GroupMember, error) {
+	rows, err := r.db.QueryxContext(ctx, findGroupMembersByGroupUUID, groupUUID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := []*entities.GroupMember{}
+	for rows.Next() {
+		var (
+			member entities.GroupMember
+			user   entities.User
+			group  entities.Group
+		)
+		err := rows.Scan(
+			&member.UUID,
+			&member.GroupUUID,
+			&member.MemberUserUUID,
+			&member.MemberGroupUUID,
+			&member.CreatedAt,
+			&member.CreatedBy,
+			&member.UpdatedAt,
+			&member.UpdatedBy,
+			&user.Username,
+			&user.FirstName,
+			&user.LastName,
+			&group.Name,
+			&group.Type,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if member.MemberUserUUID.IsNotEmpty() {
+			user.UUID = member.MemberUserUUID.GetOrDefault()
+			member.User = &user
+		}
+		if member.MemberGroupUUID.IsNotEmpty() {
+			group.UUID = member.MemberGroupUUID.GetOrDefault()
+			member.Group = &group
+		}
+		members = append(members, &member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (r *userRepo) FindGroupMemberByUUID(ctx context.Context, uuid string) (*entities.GroupMember, error) {
+	var member entities.GroupMember
+	err := r.db.QueryRowxContext(ctx, findGroupMemberByUUID, uuid).Scan(
+		&member.UUID,
+		&member.GroupUUID,
+		&member.MemberUserUUID,
+		&member.MemberGroupUUID,
+		&member.CreatedAt,
+		&member.CreatedBy,
+		&member.UpdatedAt,
+		&member.UpdatedBy,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+func (r *userRepo) InsertGroupMember(ctx context.Context, member entities.GroupMember) error {
+	_, err := r.db.ExecContext(ctx,
+		insertGroupMember,
+		member.UUID,
+		member.GroupUUID,
+		member.MemberUserUUID,
+		member.MemberGroupUUID,
+		member.CreatedAt,
+		member.CreatedBy,
+		member.UpdatedAt,
+		member.UpdatedBy,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *userRepo) DeleteGroupMember(ctx context.Context, uuid string) error {
+	_, err := r.db.ExecContext(ctx, deleteGroupMember, uuid)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *userRepo) LockGroupMembers(ctx context.Context) error {
+	_, err := r.db.ExecContext(ctx, lockGroupMembers)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *userRepo) FindGroupDescendantUUIDs(ctx context.Context, groupUUID string) ([]string, error) {
+	return r.findUUIDs(ctx, findGroupDescendantUUIDs, groupUUID)
+}
+
+func (r *userRepo) FindStaticGroupUUIDsByUserUUID(ctx context.Context, userUUID string) ([]string, error) {
+	return r.findUUIDs(ctx, findStaticGroupUUIDsByUserUUID, userUUID)
+}
+
+func (r *userRepo) findUUIDs(ctx context.Context, query string, args ...any) ([]string, error) {
+	uuids := []string{}
+	err := r.db.SelectContext(ctx, &uuids, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return uuids, nil
+}
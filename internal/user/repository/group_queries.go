@@ -0,0 +1,157 @@
+package repository
+
+var (
+	selectGroup = `
+		SELECT
+			uuid,
+			name,
+			description,
+			type,
+			filters,
+			created_at,
+			created_by,
+			updated_at,
+			updated_by
+		FROM user_groups
+	`
+
+	findGroups = selectGroup + ` ORDER BY name ASC`
+
+	findGroupByUUID = selectGroup + ` WHERE uuid = $1 LIMIT 1`
+
+	findGroupByName = selectGroup + ` WHERE LOWER(name) = LOWER($1) LIMIT 1`
+
+	// Only dynamic groups holding a role grant need their filters evaluated during role resolution
+	findGrantedDynamicGroups = selectGroup + ` WHERE type = 'dynamic' AND uuid IN (SELECT group_uuid FROM user_group_roles)`
+
+	insertGroup = `INSERT INTO user_groups (
+		uuid,
+		name,
+		description,
+		type,
+		filters,
+		created_at,
+		created_by,
+		updated_at,
+		updated_by
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING uuid`
+
+	updateGroup = `
+		UPDATE user_groups SET
+			name = $1,
+			description = $2,
+			filters = $3,
+			updated_at = $4,
+			updated_by = $5
+		WHERE uuid = $6
+	`
+
+	deleteGroup = `DELETE FROM user_groups WHERE uuid = $1`
+
+	insertGroupRole = `INSERT INTO user_group_roles (
+		uuid,
+		group_uuid,
+		role_uuid,
+		created_at,
+		created_by,
+		updated_at,
+		updated_by
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (group_uuid, role_uuid) DO NOTHING`
+
+	deleteGroupRole = `DELETE FROM user_group_roles WHERE group_uuid = $1 AND role_uuid = $2`
+
+	findRolesByGroupUUIDs = `
+		SELECT DISTINCT r.uuid, r.name, r.description
+		FROM user_group_roles gr
+		JOIN roles r ON r.uuid = gr.role_uuid
+		WHERE gr.group_uuid = ANY($1::uuid[]) AND r.deleted_at IS NULL
+	`
+
+	// Base queries of group membership, filters are appended on the users alias u
+	selectGroupMembers = `SELECT` + reportingLineColumns + ` FROM users u`
+
+	countGroupMembers = `SELECT COUNT(*) FROM users u`
+
+	checkGroupMember = `SELECT 1 FROM users u`
+
+	// Users of a static group, directly or through nested groups. UNION stops on cycles.
+	groupStaticMemberCondition = `u.uuid IN (
+		WITH RECURSIVE descendants AS (
+			SELECT %s::uuid AS uuid
+			UNION
+			SELECT m.member_group_uuid FROM user_group_members m JOIN descendants d ON m.group_uuid = d.uuid WHERE m.member_group_uuid IS NOT NULL
+		)
+		SELECT m.member_user_uuid FROM user_group_members m JOIN descendants d ON m.group_uuid = d.uuid WHERE m.member_user_uuid IS NOT NULL
+	)`
+
+	groupRoleCondition = `EXISTS (SELECT 1 FROM user_roles ur WHERE ur.user_uuid = u.uuid AND ur.role_uuid IN (%s))`
+
+	groupOrganizationSubtreeCondition = `u.organization_uuid IN (
+		SELECT c.descendant_uuid FROM organization_closure c WHERE c.ancestor_uuid IN (%s)
+	)`
+
+	findGroupMembersByGroupUUID = `
+		SELECT
+			m.uuid,
+			m.group_uuid,
+			m.member_user_uuid,
+			m.member_group_uuid,
+			m.created_at,
+			m.created_by,
+			m.updated_at,
+			m.updated_by,
+			u.username,
+			u.first_name,
+			u.last_name,
+			g.name,
+			g.type
+		FROM user_group_members m
+		LEFT JOIN users u ON u.uuid = m.member_user_uuid
+		LEFT JOIN user_groups g ON g.uuid = m.member_group_uuid
+		WHERE m.group_uuid = $1
+		ORDER BY g.name, u.first_name, u.last_name
+	`
+
+	findGroupMemberByUUID = `
+		SELECT uuid, group_uuid, member_user_uuid, member_group_uuid, created_at, created_by, updated_at, updated_by
+		FROM user_group_members
+		WHERE uuid = $1 LIMIT 1
+	`
+
+	insertGroupMember = `INSERT INTO user_group_members (
+		uuid,
+		group_uuid,
+		member_user_uuid,
+		member_group_uuid,
+		created_at,
+		created_by,
+		updated_at,
+		updated_by
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT DO NOTHING`
+
+	deleteGroupMember = `DELETE FROM user_group_members WHERE uuid = $1`
+
+	// Serializes nesting changes, two concurrent inserts could otherwise each pass the cycle check
+	lockGroupMembers = `SELECT pg_advisory_xact_lock(hashtext('user_group_members'))`
+
+	findGroupDescendantUUIDs = `
+		WITH RECURSIVE descendants AS (
+			SELECT member_group_uuid AS uuid FROM user_group_members WHERE group_uuid = $1 AND member_group_uuid IS NOT NULL
+			UNION
+			SELECT m.member_group_uuid FROM user_group_members m JOIN descendants d ON m.group_uuid = d.uuid WHERE m.member_group_uuid IS NOT NULL
+		)
+		SELECT uuid FROM descendants
+	`
+
+	// Static groups a user belongs to, directly or because a group holding them is nested
+	findStaticGroupUUIDsByUserUUID = `
+		WITH RECURSIVE ancestors AS (
+			SELECT group_uuid AS uuid FROM user_group_members WHERE member_user_uuid = $1
+			UNION
+			SELECT m.group_uuid FROM user_group_members m JOIN ancestors a ON m.member_group_uuid = a.uuid
+		)
+		SELECT uuid FROM ancestors
+	`
+)
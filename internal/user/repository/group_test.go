@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+func TestBuildMemberGroupsQuery(t *testing.T) {
+	groups := []*entities.Group{
+		{
+			BaseModel: entities.BaseModel{UUID: "group-a"},
+			Type:      nullable.NewString(entities.GroupTypeDynamic),
+			Filters:   entities.GroupFilters{{Field: "username", Operator: "eq", Value: "john"}},
+		},
+		{
+			BaseModel: entities.BaseModel{UUID: "group-b"},
+			Type:      nullable.NewString(entities.GroupTypeDynamic),
+			Filters:   entities.GroupFilters{{Field: entities.GroupFilterFieldRole, Operator: "in", Value: []any{"role-a", "role-b"}}},
+		},
+	}
+
+	query, args, err := buildMemberGroupsQuery(groups, "user-a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	checks := strings.Split(query, " UNION ALL ")
+	if len(checks) != 2 {
+		t.Fatalf("expected one check per group, got %q", query)
+	}
+	if !strings.HasPrefix(checks[0], "SELECT $3::text WHERE EXISTS (") || !strings.Contains(checks[0], "username = $1") {
+		t.Errorf("unexpected first check %q", checks[0])
+	}
+	if !strings.HasPrefix(checks[1], "SELECT $7::text WHERE EXISTS (") || !strings.Contains(checks[1], "$4,$5") || !strings.Contains(checks[1], "u.uuid = $6") {
+		t.Errorf("unexpected second check %q", checks[1])
+	}
+
+	expected := []interface{}{"john", "user-a", "group-a", "role-a", "role-b", "user-a", "group-b"}
+	if len(args) != len(expected) {
+		t.Fatalf("expected args %v, got %v", expected, args)
+	}
+	for i := range expected {
+		if args[i] != expected[i] {
+			t.Errorf("expected args %v, got %v", expected, args)
+			break
+		}
+	}
+}
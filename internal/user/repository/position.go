@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/laksanagusta/identity/internal/entities"
+)
+
+func scanPosition(row interface{ Scan(dest ...any) error }) (*entities.UserPosition, error) {
+	var position entities.UserPosition
+	organization := &entities.Organization{}
+	err := row.Scan(
+		&position.UUID,
+		&position.UserUUID,
+		&position.OrganizationUUID,
+		&position.Title,
+		&position.IsPrimary,
+		&position.StartDate,
+		&position.EndDate,
+		&position.CreatedAt,
+		&position.CreatedBy,
+		&position.UpdatedAt,
+		&position.UpdatedBy,
+		&organization.Name,
+		&organization.Code,
+		&organization.Type,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	organization.UUID = position.OrganizationUUID
+	position.Organization = organization
+
+	return &position, nil
+}
+
+func (r *userRepo) FindPositionsByUserUUID(ctx context.Context, userUUID string) (entities.UserPositions, error) {
+	rows, err := r.db.QueryxContext(ctx, findPositionsByUserUUID, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	positions := entities.UserPositions{}
+	for rows.Next() {
+		position, err := scanPosition(rows)
+		if err != nil {
+			return nil, err
+		}
+		positions = append(positions, position)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return positions, nil
+}
+
+func (r *userRepo) FindPositionByUUID(ctx context.Context, uuid string) (*entities.UserPosition, error) {
+	position, err := scanPosition(r.db.QueryRowxContext(ctx, findPositionByUUID, uuid))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return position, nil
+}
+
+func (r *userRepo) InsertPosition(ctx context.Context, position entities.UserPosition) (string, error) {
+	var returnedUUID string
+	err := r.db.GetContext(ctx,
+		&returnedUUID,
+		insertPosition,
+		position.UUID,
+		position.UserUUID,
+		position.OrganizationUUID,
+		position.Title,
+		position.IsPrimary,
+		position.StartDate,
+		position.EndDate,
+		position.CreatedAt,
+		position.CreatedBy,
+		position.UpdatedAt,
+		position.UpdatedBy,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return returnedUUID, nil
+}
+
+func (r *userRepo) UpdatePosition(ctx context.Context, position entities.UserPosition) error {
+	_, err := r.db.ExecContext(ctx,
+		updatePosition,
+		position.OrganizationUUID,
+		position.Title,
+		position.IsPrimary,
+		position.StartDate,
+		position.EndDate,
+		position.UpdatedAt,
+		position.UpdatedBy,
+		position.UUID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *userRepo) DeletePosition(ctx context.Context, uuid string) error {
+	_, err := r.db.ExecContext(ctx, deletePosition, uuid)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ClearPrimaryPosition unflags every primary position of the user except exceptUUID
+func (r *userRepo) ClearPrimaryPosition(ctx context.Context, userUUID string, exceptUUID string) error {
+	_, err := r.db.ExecContext(ctx, clearPrimaryPosition, userUUID, exceptUUID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *userRepo) UpdateOrganizationUUID(ctx context.Context, userUUID string, organizationUUID string, updatedBy string) error {
+	_, err := r.db.ExecContext(ctx, updateUserOrganization, organizationUUID, updatedBy, time.Now(), userUUID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package repository
+
+var (
+	positionColumns = `
+			p.uuid,
+			p.user_uuid,
+			p.organization_uuid,
+			p.title,
+			p.is_primary,
+			p.start_date,
+			p.end_date,
+			p.created_at,
+			p.created_by,
+			p.updated_at,
+			p.updated_by,
+			o.name,
+			o.code,
+			o.type`
+
+	findPositionsByUserUUID = `
+		SELECT` + positionColumns + `
+		FROM user_positions p
+		JOIN organizations o ON o.uuid = p.organization_uuid
+		WHERE p.user_uuid = $1
+		ORDER BY p.is_primary DESC, p.start_date DESC
+	`
+
+	findPositionByUUID = `
+		SELECT` + positionColumns + `
+		FROM user_positions p
+		JOIN organizations o ON o.uuid = p.organization_uuid
+		WHERE p.uuid = $1
+	`
+
+	insertPosition = `INSERT INTO user_positions (
+		uuid,
+		user_uuid,
+		organization_uuid,
+		title,
+		is_primary,
+		start_date,
+		end_date,
+		created_at,
+		created_by,
+		updated_at,
+		updated_by
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING uuid`
+
+	updatePosition = `
+		UPDATE user_positions SET
+			organization_uuid = $1,
+			title = $2,
+			is_primary = $3,
+			start_date = $4,
+			end_date = $5,
+			updated_at = $6,
+			updated_by = $7
+		WHERE uuid = $8
+	`
+
+	deletePosition = `DELETE FROM user_positions WHERE uuid = $1`
+
+	clearPrimaryPosition = `UPDATE user_positions SET is_primary = false WHERE user_uuid = $1 AND uuid <> $2 AND is_primary`
+
+	updateUserOrganization = `UPDATE users SET organization_uuid = $1, updated_by = $2, updated_at = $3 WHERE uuid = $4`
+)
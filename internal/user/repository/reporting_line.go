@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/laksanagusta/identity/internal/entities"
+
+	"github.com/lib/pq"
+)
+
+func scanReportingLineUsers(rows interface {
+	Next() bool
+	Scan(dest ...any) error
+	Err() error
+}) ([]*entities.User, error) {
+	users := []*entities.User{}
+	for rows.Next() {
+		var user entities.User
+		err := rows.Scan(
+			&user.UUID,
+			&user.EmployeeID,
+			&user.Username,
+			&user.FirstName,
+			&user.LastName,
+			&user.Email,
+			&user.OrganizationUUID,
+			&user.ManagerUUID,
+			&user.IsActive,
+			&user.AvatarGradientStart,
+			&user.AvatarGradientEnd,
+			&user.AvatarKey,
+			&user.ErasedAt,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, &user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (r *userRepo) UpdateManager(ctx context.Context, user entities.User) error {
+	_, err := r.db.ExecContext(ctx,
+		updateManager,
+		user.ManagerUUID,
+		user.UpdatedBy,
+		user.UpdatedAt,
+		user.UUID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *userRepo) LockReportingLines(ctx context.Context) error {
+	_, err := r.db.ExecContext(ctx, lockReportingLines)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *userRepo) FindDirectReports(ctx context.Context, managerUUID string) ([]*entities.User, error) {
+	rows, err := r.db.QueryxContext(ctx, findDirectReports, managerUUID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanReportingLineUsers(rows)
+}
+
+// FindManagementChain returns the managers of userUUID ordered from the direct manager up to the root
+func (r *userRepo) FindManagementChain(ctx context.Context, userUUID string) ([]*entities.User, error) {
+	rows, err := r.db.QueryxContext(ctx, findManagementChain, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanReportingLineUsers(rows)
+}
+
+func (r *userRepo) FindUsersByOrganizationUUIDs(ctx context.Context, organizationUUIDs []string) ([]*entities.User, error) {
+	rows, err := r.db.QueryxContext(ctx, findUsersByOrganizationUUIDs, pq.Array(organizationUUIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanReportingLineUsers(rows)
+}
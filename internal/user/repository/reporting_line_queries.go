@@ -0,0 +1,60 @@
+package repository
+
+var (
+	reportingLineColumns = `
+			u.uuid,
+			u.employee_id,
+			u.username,
+			u.first_name,
+			u.last_name,
+			u.email,
+			u.organization_uuid,
+			u.manager_uuid,
+			u.is_active,
+			u.avatar_gradient_start,
+			u.avatar_gradient_end,
+			u.avatar_key,
+			u.erased_at,
+			u.created_at,
+			u.updated_at`
+
+	updateManager = `UPDATE users SET manager_uuid = $1, updated_by = $2, updated_at = $3 WHERE uuid = $4`
+
+	// Serializes manager changes, two concurrent updates could otherwise each pass the cycle check
+	lockReportingLines = `SELECT pg_advisory_xact_lock(hashtext('users.manager_uuid'))`
+
+	findDirectReports = `
+		SELECT` + reportingLineColumns + `
+		FROM users u
+		WHERE u.manager_uuid = $1 AND u.deleted_at IS NULL
+		ORDER BY u.first_name, u.last_name
+	`
+
+	// Walks manager_uuid up to the root, the visited array stops on cycles created outside the API.
+	// Deleted managers are walked through but left out of the result.
+	findManagementChain = `
+		WITH RECURSIVE chain AS (
+			SELECT m.uuid, m.manager_uuid, 1 AS depth, ARRAY[s.uuid, m.uuid] AS visited
+			FROM users s
+			JOIN users m ON m.uuid = s.manager_uuid
+			WHERE s.uuid = $1
+			UNION ALL
+			SELECT m.uuid, m.manager_uuid, c.depth + 1, c.visited || m.uuid
+			FROM chain c
+			JOIN users m ON m.uuid = c.manager_uuid
+			WHERE NOT m.uuid = ANY(c.visited)
+		)
+		SELECT` + reportingLineColumns + `
+		FROM chain c
+		JOIN users u ON u.uuid = c.uuid
+		WHERE u.deleted_at IS NULL
+		ORDER BY c.depth
+	`
+
+	findUsersByOrganizationUUIDs = `
+		SELECT` + reportingLineColumns + `
+		FROM users u
+		WHERE u.organization_uuid = ANY($1::uuid[]) AND u.deleted_at IS NULL
+		ORDER BY u.first_name, u.last_name
+	`
+)
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -28,6 +29,13 @@ type userRepo struct {
 	db   database.Queryer
 }
 
+func (r *userRepo) WithTransaction(tx database.DBTx) user.Repository {
+	return &userRepo{
+		conn: r.conn,
+		db:   tx,
+	}
+}
+
 func (r *userRepo) Insert(ctx context.Context, user entities.User) (string, error) {
 	var returnedUUID string
 	err := r.db.GetContext(ctx,
@@ -81,6 +89,31 @@ func (r *userRepo) FindByUsername(ctx context.Context, username string) (*entiti
 	return &user, nil
 }
 
+func (r *userRepo) FindByEmail(ctx context.Context, email string) (*entities.User, error) {
+	var user entities.User
+	row := r.db.QueryRowxContext(ctx, findByEmail, email)
+	err := row.Scan(
+		&user.UUID,
+		&user.EmployeeID,
+		&user.Username,
+		&user.FirstName,
+		&user.LastName,
+		&user.PhoneNumber,
+		&user.PasswordHash,
+		&user.OrganizationUUID,
+		&user.IsApproved,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &user, nil
+}
+
 func (r *userRepo) FindByPhoneNumber(ctx context.Context, phoneNumber string) (*entities.User, error) {
 	var user entities.User
 	row := r.db.QueryRowxContext(ctx, findByPhoneNumber, phoneNumber)
@@ -121,6 +154,8 @@ func (r *userRepo) Update(ctx context.Context, user entities.User) error {
 		user.Username.IsExists,
 		user.Username,
 		user.UUID,
+		user.Attributes != nil,
+		user.Attributes,
 	)
 	if err != nil {
 		return err
@@ -136,6 +171,21 @@ func (r *userRepo) Update(ctx context.Context, user entities.User) error {
 	return nil
 }
 
+func (r *userRepo) UpdateAvatarKey(ctx context.Context, user entities.User) error {
+	_, err := r.db.ExecContext(ctx,
+		updateAvatarKey,
+		user.AvatarKey,
+		user.UpdatedBy,
+		user.UpdatedAt,
+		user.UUID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (r *userRepo) UpdateApprovalStatus(ctx context.Context, userUUID string, isApproved bool, updatedBy string) error {
 	res, err := r.db.ExecContext(ctx,
 		`UPDATE users SET is_approved = $1, updated_by = $2, updated_at = $3 WHERE uuid = $4`,
@@ -174,6 +224,14 @@ func (r *userRepo) FindByUUID(ctx context.Context, uuid string) (*entities.User,
 		&user.IsApproved,
 		&user.AvatarGradientStart,
 		&user.AvatarGradientEnd,
+		&user.Email,
+		&user.IsActive,
+		&user.LastLoginAt,
+		&user.UpdatedAt,
+		&user.ErasedAt,
+		&user.Attributes,
+		&user.AvatarKey,
+		&user.ManagerUUID,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -230,7 +288,52 @@ func (r *userRepo) FindRoleByUUID(ctx context.Context, uuid string) (*entities.R
 	return &role, nil
 }
 
+// FindRoleByUserUUID returns the effective roles of a user, granted directly or through a group
 func (r *userRepo) FindRoleByUserUUID(ctx context.Context, uuid string) ([]*entities.Role, error) {
+	roles, err := r.findDirectRolesByUserUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	groupRoles, err := r.findGroupRolesByUserUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, role := range groupRoles {
+		if !slices.ContainsFunc(roles, func(r *entities.Role) bool { return r.UUID == role.UUID }) {
+			roles = append(roles, role)
+		}
+	}
+
+	return roles, nil
+}
+
+func (r *userRepo) findGroupRolesByUserUUID(ctx context.Context, userUUID string) ([]*entities.Role, error) {
+	groupUUIDs, err := r.FindStaticGroupUUIDsByUserUUID(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := r.FindGrantedDynamicGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dynamicGroupUUIDs, err := r.findMemberGroupUUIDs(ctx, groups, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	groupUUIDs = append(groupUUIDs, dynamicGroupUUIDs...)
+
+	if len(groupUUIDs) == 0 {
+		return nil, nil
+	}
+
+	return r.FindRolesByGroupUUIDs(ctx, groupUUIDs)
+}
+
+func (r *userRepo) findDirectRolesByUserUUID(ctx context.Context, uuid string) ([]*entities.Role, error) {
 	rows, err := r.db.QueryxContext(ctx, findRoleByUserId, uuid)
 	if err != nil {
 		return nil, err
@@ -282,49 +385,86 @@ func (r *userRepo) FindRole(ctx context.Context) ([]entities.Role, error) {
 	return roles, nil
 }
 
+// buildUserIndexQuery applies the filters, search and sorts of params to base, a select from users
+func buildUserIndexQuery(base string, params *pagination.QueryParams) (string, []interface{}, error) {
+	queryBuilder := pagination.NewQueryBuilder(base).AllowJSONField("attributes")
+	for _, filter := range params.Filters {
+		if err := queryBuilder.AddFilter(filter); err != nil {
+			return "", nil, err
+		}
+	}
+	queryBuilder.AddFuzzySearch(params.Search, userSearchDocument)
+	for _, sort := range params.Sorts {
+		if err := queryBuilder.AddSort(sort); err != nil {
+			return "", nil, err
+		}
+	}
+	queryBuilder.AddRelevanceSort(params.Search, userSearchDocument)
+
+	query, args := queryBuilder.Build()
+	return query, args, nil
+}
+
+// searchDB returns where to run a query with the fuzzy user search of term. The word similarity
+// threshold of the search is set for a transaction only, done ends the transaction it began.
+func (r *userRepo) searchDB(ctx context.Context, term string) (db database.Queryer, done func(), err error) {
+	if len(pagination.SearchTokens(term)) == 0 {
+		return r.db, func() {}, nil
+	}
+
+	if tx, ok := r.db.(database.DBTx); ok {
+		_, err := tx.ExecContext(ctx, setUserSearchThreshold)
+		return tx, func() {}, err
+	}
+
+	tx, err := r.conn.BeginTxx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := tx.ExecContext(ctx, setUserSearchThreshold); err != nil {
+		tx.Rollback()
+		return nil, nil, err
+	}
+
+	// Nothing is written, rolling back only ends the transaction
+	return tx, func() { tx.Rollback() }, nil
+}
+
 func (r *userRepo) Index(ctx context.Context, params *pagination.QueryParams) ([]*entities.User, int64, error) {
+	db, done, err := r.searchDB(ctx, params.Search)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer done()
+
 	// Build count query
-	countBuilder := pagination.NewQueryBuilder("SELECT COUNT(*) FROM users")
+	countBuilder := pagination.NewQueryBuilder("SELECT COUNT(*) FROM users").AllowJSONField("attributes")
 	for _, filter := range params.Filters {
 		if err := countBuilder.AddFilter(filter); err != nil {
 			return nil, 0, err
 		}
 	}
-	if err := countBuilder.AddSearch(params.Search, []string{"username", "first_name"}); err != nil {
-		return nil, 0, err
-	}
+	countBuilder.AddFuzzySearch(params.Search, userSearchDocument)
 	countQuery, countArgs := countBuilder.Build()
 
 	var totalCount int64
-	err := r.db.GetContext(ctx, &totalCount, countQuery, countArgs...)
+	err = db.GetContext(ctx, &totalCount, countQuery, countArgs...)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	// Build main query
-	queryBuilder := pagination.NewQueryBuilder("SELECT * FROM users")
-	for _, filter := range params.Filters {
-		if err := queryBuilder.AddFilter(filter); err != nil {
-			return nil, 0, err
-		}
-	}
-	if err := queryBuilder.AddSearch(params.Search, []string{"username", "first_name"}); err != nil {
+	query, args, err := buildUserIndexQuery("SELECT * FROM users", params)
+	if err != nil {
 		return nil, 0, err
 	}
-	for _, sort := range params.Sorts {
-		if err := queryBuilder.AddSort(sort); err != nil {
-			return nil, 0, err
-		}
-	}
-
-	query, args := queryBuilder.Build()
 
 	// Add pagination
 	offset := (params.Pagination.Page - 1) * params.Pagination.Limit
 	query += fmt.Sprintf(" LIMIT %d OFFSET %d", params.Pagination.Limit, offset)
 
 	var users []*entities.User
-	rows, err := r.db.QueryxContext(ctx, query, args...)
+	rows, err := db.QueryxContext(ctx, query, args...)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -343,6 +483,57 @@ func (r *userRepo) Index(ctx context.Context, params *pagination.QueryParams) ([
 	return users, totalCount, nil
 }
 
+// Suggest returns the best matches of term among users that are neither deleted nor erased
+func (r *userRepo) Suggest(ctx context.Context, term string, limit int) ([]*entities.User, error) {
+	queryBuilder := pagination.NewQueryBuilder(suggestUsers).
+		AddRawCondition("deleted_at IS NULL").
+		AddRawCondition("erased_at IS NULL")
+	queryBuilder.AddFuzzySearch(term, userSearchDocument)
+	queryBuilder.AddRelevanceSort(term, userSearchDocument)
+
+	query, args := queryBuilder.Build()
+	query += fmt.Sprintf(" LIMIT %d", limit)
+
+	db, done, err := r.searchDB(ctx, term)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	rows, err := db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []*entities.User{}
+	for rows.Next() {
+		var user entities.User
+		err := rows.Scan(
+			&user.UUID,
+			&user.EmployeeID,
+			&user.Username,
+			&user.FirstName,
+			&user.LastName,
+			&user.Email,
+			&user.PhoneNumber,
+			&user.AvatarGradientStart,
+			&user.AvatarGradientEnd,
+			&user.AvatarKey,
+		)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, &user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
 func (r *userRepo) Delete(ctx context.Context, uuid string, username string) error {
 	res, err := r.db.ExecContext(ctx,
 		deleteUser,
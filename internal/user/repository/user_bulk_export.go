@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"iter"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/pkg/nullable"
+	"github.com/laksanagusta/identity/pkg/pagination"
+
+	"github.com/lib/pq"
+)
+
+type streamUserRow struct {
+	entities.User
+	OrganizationCode nullable.NullString `db:"organization_code"`
+	OrganizationName nullable.NullString `db:"organization_name"`
+	DirectRoleNames  pq.StringArray      `db:"direct_role_names"`
+}
+
+// StreamUsers runs the query of Index without pagination and yields the users as they are read, with
+// the code and name of their organization and the names of the roles assigned to them directly. The
+// rows, and the transaction of a search, are released once the loop ends.
+func (r *userRepo) StreamUsers(ctx context.Context, params *pagination.QueryParams) (iter.Seq2[*entities.User, error], error) {
+	streamParams := *params
+	if len(streamParams.Sorts) == 0 && streamParams.Search == "" {
+		streamParams.Sorts = []pagination.Sort{{Field: "created_at", Order: "asc"}, {Field: "uuid", Order: "asc"}}
+	}
+
+	query, args, err := buildUserIndexQuery(streamUsers, &streamParams)
+	if err != nil {
+		return nil, err
+	}
+
+	db, done, err := r.searchDB(ctx, params.Search)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		done()
+		return nil, err
+	}
+
+	return func(yield func(*entities.User, error) bool) {
+		defer done()
+		defer rows.Close()
+
+		for rows.Next() {
+			var row streamUserRow
+			if err := rows.StructScan(&row); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			user := row.User
+			if user.OrganizationUUID.IsExists {
+				user.Organization = &entities.Organization{Code: row.OrganizationCode, Name: row.OrganizationName}
+				user.Organization.UUID = user.OrganizationUUID.GetOrDefault()
+			}
+			for _, name := range row.DirectRoleNames {
+				user.Roles = append(user.Roles, &entities.Role{Name: nullable.NewString(name)})
+			}
+
+			if !yield(&user, nil) {
+				return
+			}
+		}
+
+		if err := rows.Err(); err != nil {
+			yield(nil, err)
+		}
+	}, nil
+}
@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/pkg/nullable"
+	"github.com/laksanagusta/identity/pkg/pagination"
+)
+
+func scanUserImportJob(row interface{ Scan(dest ...any) error }, dest ...any) (*entities.UserImportJob, error) {
+	var job entities.UserImportJob
+	err := row.Scan(append(dest,
+		&job.UUID,
+		&job.Status,
+		&job.FileName,
+		&job.TotalRows,
+		&job.ProcessedRows,
+		&job.CreatedCount,
+		&job.FailedCount,
+		&job.Results,
+		&job.Error,
+		&job.StartedAt,
+		&job.FinishedAt,
+		&job.CreatedAt,
+		&job.CreatedBy,
+		&job.UpdatedAt,
+		&job.UpdatedBy,
+	)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (r *userRepo) InsertUserImportJob(ctx context.Context, job entities.UserImportJob) (string, error) {
+	var returnedUUID string
+	err := r.db.GetContext(ctx,
+		&returnedUUID,
+		insertUserImportJob,
+		job.UUID,
+		job.Status,
+		job.FileName,
+		job.Rows,
+		job.TotalRows,
+		job.CreatedAt,
+		job.CreatedBy,
+		job.UpdatedAt,
+		job.UpdatedBy,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return returnedUUID, nil
+}
+
+// FindUserImportJobByUUID returns the job without the rows it imports
+func (r *userRepo) FindUserImportJobByUUID(ctx context.Context, uuid string) (*entities.UserImportJob, error) {
+	job, err := scanUserImportJob(r.db.QueryRowxContext(ctx, findUserImportJobByUUID, uuid))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (r *userRepo) IndexUserImportJob(ctx context.Context, params *pagination.QueryParams) ([]*entities.UserImportJob, int64, error) {
+	countBuilder := pagination.NewQueryBuilder("SELECT COUNT(*) FROM user_import_jobs").AllowFields("status", "file_name", "created_by")
+	for _, filter := range params.Filters {
+		if err := countBuilder.AddFilter(filter); err != nil {
+			return nil, 0, err
+		}
+	}
+	countQuery, countArgs := countBuilder.Build()
+
+	var totalCount int64
+	err := r.db.GetContext(ctx, &totalCount, countQuery, countArgs...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	queryBuilder := pagination.NewQueryBuilder("SELECT"+userImportJobColumns+" FROM user_import_jobs").AllowFields("status", "file_name", "created_by")
+	for _, filter := range params.Filters {
+		if err := queryBuilder.AddFilter(filter); err != nil {
+			return nil, 0, err
+		}
+	}
+	sorts := params.Sorts
+	if len(sorts) == 0 {
+		sorts = []pagination.Sort{{Field: "created_at", Order: "desc"}}
+	}
+	for _, sort := range sorts {
+		if err := queryBuilder.AddSort(sort); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	query, args := queryBuilder.Build()
+
+	offset := (params.Pagination.Page - 1) * params.Pagination.Limit
+	query += fmt.Sprintf(" LIMIT %d OFFSET %d", params.Pagination.Limit, offset)
+
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	jobs := []*entities.UserImportJob{}
+	for rows.Next() {
+		job, err := scanUserImportJob(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return jobs, totalCount, nil
+}
+
+// ClaimUserImportJob marks the oldest pending job as running and returns it with its rows. Running jobs
+// last updated before staleBefore are taken over, they continue after their last processed row.
+func (r *userRepo) ClaimUserImportJob(ctx context.Context, staleBefore time.Time) (*entities.UserImportJob, error) {
+	var rows entities.UserImportRows
+	job, err := scanUserImportJob(r.db.QueryRowxContext(ctx, claimUserImportJob, staleBefore), &rows)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	job.Rows = rows
+	return job, nil
+}
+
+// SaveUserImportResult adds the result of the next row to the progress of the job
+func (r *userRepo) SaveUserImportResult(ctx context.Context, jobUUID string, result entities.UserImportResult) error {
+	created, failed := 0, 0
+	if len(result.Errors) > 0 {
+		failed = 1
+	} else {
+		created = 1
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		saveUserImportResult,
+		jobUUID,
+		created,
+		failed,
+		entities.UserImportResults{result},
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *userRepo) FinishUserImportJob(ctx context.Context, jobUUID string, status string, errMsg nullable.NullString) error {
+	_, err := r.db.ExecContext(ctx, finishUserImportJob, jobUUID, status, errMsg)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
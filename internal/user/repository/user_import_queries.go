@@ -0,0 +1,75 @@
+package repository
+
+var (
+	userImportJobColumns = `
+			uuid,
+			status,
+			file_name,
+			total_rows,
+			processed_rows,
+			created_count,
+			failed_count,
+			results,
+			error,
+			started_at,
+			finished_at,
+			created_at,
+			created_by,
+			updated_at,
+			updated_by`
+
+	insertUserImportJob = `INSERT INTO user_import_jobs (
+		uuid,
+		status,
+		file_name,
+		rows,
+		total_rows,
+		created_at,
+		created_by,
+		updated_at,
+		updated_by
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING uuid`
+
+	findUserImportJobByUUID = `
+		SELECT` + userImportJobColumns + `
+		FROM user_import_jobs
+		WHERE uuid = $1
+	`
+
+	// The oldest pending job is taken, or a running one that stopped making progress
+	claimUserImportJob = `
+		UPDATE user_import_jobs SET
+			status = 'running',
+			started_at = COALESCE(started_at, NOW()),
+			updated_at = NOW()
+		WHERE uuid = (
+			SELECT uuid FROM user_import_jobs
+			WHERE status = 'pending' OR (status = 'running' AND updated_at < $1)
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING rows,` + userImportJobColumns
+
+	saveUserImportResult = `
+		UPDATE user_import_jobs SET
+			processed_rows = processed_rows + 1,
+			created_count = created_count + $2,
+			failed_count = failed_count + $3,
+			results = results || $4::jsonb,
+			updated_at = NOW()
+		WHERE uuid = $1
+	`
+
+	// Rows hold password hashes, they are dropped once the job is done
+	finishUserImportJob = `
+		UPDATE user_import_jobs SET
+			status = $2,
+			error = $3,
+			rows = '[]',
+			finished_at = NOW(),
+			updated_at = NOW()
+		WHERE uuid = $1
+	`
+)
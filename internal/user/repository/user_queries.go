@@ -1,6 +1,15 @@
 package repository
 
 var (
+	// userSearchDocument must match the expression of the search indexes in migration 000013
+	userSearchDocument = `users_search_document(first_name, last_name, username, email, phone_number, employee_id)`
+
+	// setUserSearchThreshold lowers the word similarity threshold of the user search for the current
+	// transaction, the default (0.6) misses typos such as "jon" for "john"
+	setUserSearchThreshold = `SET LOCAL pg_trgm.word_similarity_threshold = 0.45`
+
+	suggestUsers = `SELECT uuid, employee_id, username, first_name, last_name, email, phone_number, avatar_gradient_start, avatar_gradient_end, avatar_key FROM users`
+
 	insertUser = `INSERT INTO users (
 		uuid,
 		employee_id,
@@ -33,6 +42,19 @@ var (
 	FROM users
 	WHERE username = $1 AND deleted_at is null LIMIT 1`
 
+	findByEmail = `SELECT
+		uuid,
+		employee_id,
+		username,
+		first_name,
+		last_name,
+		phone_number,
+		password_hash,
+		organization_uuid,
+		is_approved
+	FROM users
+	WHERE LOWER(email) = LOWER($1) AND deleted_at is null LIMIT 1`
+
 	findByPhoneNumber = `SELECT
 		uuid,
 		employee_id,
@@ -52,10 +74,13 @@ var (
 			password_hash = CASE WHEN $9 THEN $10 ELSE password_hash END,
 			updated_by = $11,
 			updated_at = $12,
-			username = CASE WHEN $13 THEN $14 ELSE username END
+			username = CASE WHEN $13 THEN $14 ELSE username END,
+			attributes = CASE WHEN $16 THEN $17 ELSE attributes END
 		WHERE uuid = $15
 	`
 
+	updateAvatarKey = `UPDATE users SET avatar_key = $1, updated_by = $2, updated_at = $3 WHERE uuid = $4`
+
 	findUserById = `
 			SELECT
 			uuid,
@@ -69,7 +94,15 @@ var (
 			created_at,
 			is_approved,
 			avatar_gradient_start,
-			avatar_gradient_end
+			avatar_gradient_end,
+			email,
+			is_active,
+			last_login_at,
+			updated_at,
+			erased_at,
+			attributes,
+			avatar_key,
+			manager_uuid
 		FROM users
 		WHERE uuid = $1 AND deleted_at is null LIMIT 1
 	`
@@ -282,4 +315,19 @@ var (
 		WHERE 
 			ur.user_uuid IN (?)
 	`
+
+	// streamUsers selects users with the code and name of their organization and the names of the roles
+	// assigned to them directly.
+	// Only users is in FROM so the unqualified columns of the Index filters stay unambiguous.
+	streamUsers = `
+		SELECT
+			users.*,
+			(SELECT o.code FROM organizations o WHERE o.uuid = users.organization_uuid) AS organization_code,
+			(SELECT o.name FROM organizations o WHERE o.uuid = users.organization_uuid) AS organization_name,
+			ARRAY(
+				SELECT r.name FROM user_roles ur JOIN roles r ON r.uuid = ur.role_uuid
+				WHERE ur.user_uuid = users.uuid ORDER BY r.name
+			) AS direct_role_names
+		FROM users
+	`
 )
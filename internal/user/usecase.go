@@ -2,6 +2,8 @@ package user
 
 import (
 	"context"
+	"iter"
+	"time"
 
 	"github.com/laksanagusta/identity/internal/entities"
 	"github.com/laksanagusta/identity/internal/user/dtos"
@@ -13,11 +15,37 @@ type UseCase interface {
 	Update(ctx context.Context, cred entities.AuthenticatedUser, req dtos.UpdateUserReq) error
 	Show(ctx context.Context, uuid string) (*entities.User, []string, error)
 	Login(ctx context.Context, req dtos.LoginReq) (string, error)
+	DiscoverLogin(ctx context.Context, req dtos.DiscoverLoginReq) dtos.DiscoverLoginRes
 	Index(ctx context.Context, params *pagination.QueryParams) ([]*entities.User, *pagination.PagedResponse, error)
+	Suggest(ctx context.Context, req dtos.SuggestUserReq) ([]*entities.User, error)
+	BulkExport(ctx context.Context, params *pagination.QueryParams) (iter.Seq2[*entities.User, error], error)
 	Delete(ctx context.Context, cred entities.AuthenticatedUser, uuid string) error
 	ChangePassword(ctx context.Context, cred entities.AuthenticatedUser, req dtos.ChangePassword) error
 	ApproveUser(ctx context.Context, cred entities.AuthenticatedUser, userUUID string) error
 	RejectUser(ctx context.Context, cred entities.AuthenticatedUser, userUUID string) error
+	UploadAvatar(ctx context.Context, cred entities.AuthenticatedUser, req dtos.UploadAvatarReq) (entities.AvatarURLs, error)
+	DeleteAvatar(ctx context.Context, cred entities.AuthenticatedUser, userUUID string) error
+	ShowAvatar(ctx context.Context, userUUID string) (*entities.User, error)
+	ExportUser(ctx context.Context, cred entities.AuthenticatedUser, userUUID string) (*entities.UserDataExport, error)
+	EraseUser(ctx context.Context, cred entities.AuthenticatedUser, userUUID string) error
+	UpdateManager(ctx context.Context, cred entities.AuthenticatedUser, req dtos.UpdateManagerReq) error
+	DirectReports(ctx context.Context, userUUID string) ([]*entities.User, error)
+	ManagementChain(ctx context.Context, userUUID string) ([]*entities.User, error)
+	Approver(ctx context.Context, req dtos.ApproverReq) (*entities.User, error)
+	ListByOrganizations(ctx context.Context, organizationUUIDs []string) ([]*entities.User, error)
+	SwitchOrganization(ctx context.Context, cred entities.AuthenticatedUser, req dtos.SwitchOrganizationReq) (string, error)
+
+	CheckUserImport(ctx context.Context, req dtos.ImportUserReq) (entities.UserImportResults, error)
+	ImportUsers(ctx context.Context, cred entities.AuthenticatedUser, req dtos.ImportUserReq) (string, error)
+	ShowUserImportJob(ctx context.Context, uuid string) (*entities.UserImportJob, error)
+	IndexUserImportJob(ctx context.Context, params *pagination.QueryParams) ([]*entities.UserImportJob, *pagination.PagedResponse, error)
+	RunUserImportJobs(ctx context.Context) (int, error)
+	IndexChangeEvent(ctx context.Context, params entities.ListChangeEventParams) ([]*entities.ChangeEvent, error)
+	NewChangeStream(ctx context.Context, cred entities.AuthenticatedUser, req dtos.StreamChangeReq) (entities.ListChangeEventParams, error)
+	StreamChangeEvent(ctx context.Context, params entities.ListChangeEventParams) ([]*entities.ChangeEvent, error)
+	WatchChangeEvent() <-chan struct{}
+	NotifyChangeEvent()
+	PurgeChangeEvents(ctx context.Context, before time.Time) (int64, error)
 
 	Role(ctx context.Context) ([]entities.Role, error)
 	CreateRole(ctx context.Context, req dtos.CreateRoleReq, cred entities.AuthenticatedUser) (string, error)
@@ -34,6 +62,32 @@ type UseCase interface {
 	UpdatePermission(ctx context.Context, permission entities.Permission) error
 	IndexPermission(ctx context.Context, params *pagination.QueryParams) ([]*entities.Permission, *pagination.PagedResponse, error)
 
+	IndexUserAttribute(ctx context.Context) ([]*entities.UserAttributeDefinition, error)
+	CreateUserAttribute(ctx context.Context, definition entities.UserAttributeDefinition) (string, error)
+	UpdateUserAttribute(ctx context.Context, cred entities.AuthenticatedUser, req dtos.UpdateUserAttributeReq) error
+	DeleteUserAttribute(ctx context.Context, uuid string) error
+	FilterUserAttributes(ctx context.Context, users []*entities.User, visibilities ...string) error
+	CheckUserQuery(ctx context.Context, params *pagination.QueryParams, visibilities ...string) error
+
+	IndexUserPosition(ctx context.Context, userUUID string) (entities.UserPositions, error)
+	CreateUserPosition(ctx context.Context, cred entities.AuthenticatedUser, req dtos.CreateUserPositionReq) (string, error)
+	UpdateUserPosition(ctx context.Context, cred entities.AuthenticatedUser, req dtos.UpdateUserPositionReq) error
+	DeleteUserPosition(ctx context.Context, cred entities.AuthenticatedUser, userUUID string, positionUUID string) error
+
+	IndexGroup(ctx context.Context) ([]*entities.Group, error)
+	ShowGroup(ctx context.Context, uuid string) (*entities.Group, error)
+	CreateGroup(ctx context.Context, group entities.Group) (string, error)
+	UpdateGroup(ctx context.Context, cred entities.AuthenticatedUser, req dtos.UpdateGroupReq) error
+	DeleteGroup(ctx context.Context, uuid string) error
+	PreviewGroup(ctx context.Context, req dtos.PreviewGroupReq) ([]*entities.User, *pagination.PagedResponse, error)
+	GroupMembers(ctx context.Context, req dtos.ListGroupMemberReq) ([]*entities.User, *pagination.PagedResponse, error)
+	IsGroupMember(ctx context.Context, req dtos.GroupMemberReq) (bool, error)
+	IndexGroupMember(ctx context.Context, groupUUID string) ([]*entities.GroupMember, error)
+	CreateGroupMember(ctx context.Context, member entities.GroupMember) error
+	DeleteGroupMember(ctx context.Context, groupUUID string, memberUUID string) error
+	CreateGroupRole(ctx context.Context, groupRole entities.GroupRole) error
+	DeleteGroupRole(ctx context.Context, req dtos.GroupRoleReq) error
+
 	CreateRolePermission(ctx context.Context, rolePermission entities.RolaPermission) error
 	DeleteRolePermission(ctx context.Context, uuid string) error
 }
@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/laksanagusta/identity/constants"
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/user/dtos"
+	"github.com/laksanagusta/identity/pkg/database"
+	"github.com/laksanagusta/identity/pkg/errorhelper"
+	"github.com/laksanagusta/identity/pkg/pagination"
+)
+
+func (uc *UserUseCase) IndexUserAttribute(ctx context.Context) ([]*entities.UserAttributeDefinition, error) {
+	return uc.userRepo.FindAttributeDefinitions(ctx)
+}
+
+func (uc *UserUseCase) CreateUserAttribute(ctx context.Context, definition entities.UserAttributeDefinition) (string, error) {
+	existing, err := uc.userRepo.FindAttributeDefinitionByKey(ctx, definition.Key.GetOrDefault())
+	if err != nil {
+		return "", err
+	}
+	if existing != nil {
+		return "", errorhelper.BadRequestMap(map[string][]string{
+			"key": {constants.ErrMsgAlreadyExist},
+		})
+	}
+
+	return uc.userRepo.InsertAttributeDefinition(ctx, definition)
+}
+
+func (uc *UserUseCase) UpdateUserAttribute(ctx context.Context, cred entities.AuthenticatedUser, req dtos.UpdateUserAttributeReq) error {
+	definition, err := uc.userRepo.FindAttributeDefinitionByUUID(ctx, req.UUID)
+	if err != nil {
+		return err
+	}
+	if definition == nil {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"attribute_id": {constants.ErrMsgNotFound},
+		})
+	}
+
+	req.Apply(definition, cred)
+
+	if definition.Type.GetOrDefault() == entities.AttributeTypeEnum && len(definition.EnumValues) == 0 {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"enum_values": {constants.ErrMsgAttributeRequired},
+		})
+	}
+
+	return uc.userRepo.UpdateAttributeDefinition(ctx, *definition)
+}
+
+func (uc *UserUseCase) DeleteUserAttribute(ctx context.Context, uuid string) error {
+	definition, err := uc.userRepo.FindAttributeDefinitionByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+	if definition == nil {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"attribute_id": {constants.ErrMsgNotFound},
+		})
+	}
+
+	return uc.txManager.Atomic(ctx, func(ctx context.Context, tx database.DBTx) error {
+		userRepoTrx := uc.userRepo.WithTransaction(tx)
+
+		err := userRepoTrx.RemoveUserAttribute(ctx, definition.Key.GetOrDefault())
+		if err != nil {
+			return err
+		}
+
+		return userRepoTrx.DeleteAttributeDefinition(ctx, uuid)
+	})
+}
+
+// FilterUserAttributes strips attribute values whose definition visibility is not listed
+func (uc *UserUseCase) FilterUserAttributes(ctx context.Context, users []*entities.User, visibilities ...string) error {
+	definitions, err := uc.userRepo.FindAttributeDefinitions(ctx)
+	if err != nil {
+		return err
+	}
+
+	definitionList := entities.UserAttributeDefinitions(definitions)
+	for _, user := range users {
+		user.Attributes = definitionList.Visible(user.Attributes, visibilities...)
+	}
+
+	return nil
+}
+
+// CheckUserQuery rejects filters and sorts on attributes the given visibilities do not show, their
+// values would otherwise leak through the results of probing queries
+func (uc *UserUseCase) CheckUserQuery(ctx context.Context, params *pagination.QueryParams, visibilities ...string) error {
+	fields := make([]string, 0, len(params.Filters)+len(params.Sorts))
+	for _, filter := range params.Filters {
+		fields = append(fields, filter.Field)
+	}
+	for _, sort := range params.Sorts {
+		fields = append(fields, sort.Field)
+	}
+
+	definitions, err := uc.userRepo.FindAttributeDefinitions(ctx)
+	if err != nil {
+		return err
+	}
+
+	hidden := entities.UserAttributeDefinitions(definitions).HiddenFields(fields, visibilities...)
+	if len(hidden) == 0 {
+		return nil
+	}
+
+	errs := map[string][]string{}
+	for _, field := range hidden {
+		errs[field] = []string{constants.ErrMsgAttributeUnknown}
+	}
+	return errorhelper.BadRequestMap(errs)
+}
+
+// mergeUserAttributes validates the requested attribute changes against the registry.
+// Non editable attributes can only be changed by someone other than the user themselves.
+func (uc *UserUseCase) mergeUserAttributes(ctx context.Context, cred entities.AuthenticatedUser, userUUID string, patch entities.UserAttributes) (entities.UserAttributes, error) {
+	current, err := uc.userRepo.FindByUUID(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, errorhelper.BadRequestMap(map[string][]string{
+			"user_id": {constants.ErrMsgNotFound},
+		})
+	}
+
+	definitions, err := uc.userRepo.FindAttributeDefinitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	merged, errs := entities.UserAttributeDefinitions(definitions).Merge(current.Attributes, patch, cred.ID != userUUID)
+	if len(errs) > 0 {
+		return nil, errorhelper.BadRequestMap(errs)
+	}
+
+	return merged, nil
+}
@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/laksanagusta/identity/constants"
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/user/dtos"
+	"github.com/laksanagusta/identity/pkg/errorhelper"
+	"github.com/laksanagusta/identity/pkg/imaging"
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+func (uc *UserUseCase) UploadAvatar(ctx context.Context, cred entities.AuthenticatedUser, req dtos.UploadAvatarReq) (entities.AvatarURLs, error) {
+	user, err := uc.userRepo.FindByUUID(ctx, req.UserUUID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errorhelper.BadRequestMap(map[string][]string{
+			"user_id": {constants.ErrMsgNotFound},
+		})
+	}
+
+	img, err := imaging.Decode(req.Data)
+	if err != nil {
+		if errors.Is(err, imaging.ErrTooLarge) {
+			return nil, errorhelper.BadRequestMap(map[string][]string{
+				"avatar": {constants.ErrMsgImageTooLarge},
+			})
+		}
+		return nil, errorhelper.BadRequestMap(map[string][]string{
+			"avatar": {constants.ErrMsgUnsupportedImage},
+		})
+	}
+
+	// every upload gets a new prefix so cached URLs of the previous avatar never serve the new one
+	avatarKey := "avatars/" + user.UUID + "/" + strconv.FormatInt(time.Now().UnixNano(), 36)
+
+	variants := make(map[int][]byte, len(entities.AvatarSizes))
+	for _, size := range entities.AvatarSizes {
+		variant, err := imaging.SquareJPEG(img, size)
+		if err != nil {
+			return nil, err
+		}
+		variants[size] = variant
+	}
+
+	for size, variant := range variants {
+		err := uc.storage.Put(ctx, entities.AvatarVariantKey(avatarKey, size), variant, entities.AvatarContentType)
+		if err != nil {
+			uc.deleteAvatarVariants(ctx, avatarKey)
+			return nil, err
+		}
+	}
+
+	previousKey := user.AvatarKey.GetOrDefault()
+
+	user.AvatarKey = nullable.NewString(avatarKey)
+	user.UpdateModel(cred.Username)
+
+	err = uc.userRepo.UpdateAvatarKey(ctx, *user)
+	if err != nil {
+		uc.deleteAvatarVariants(ctx, avatarKey)
+		return nil, err
+	}
+
+	if previousKey != "" {
+		uc.deleteAvatarVariants(ctx, previousKey)
+	}
+
+	uc.setAvatarURLs(user)
+
+	return user.AvatarURLs, nil
+}
+
+func (uc *UserUseCase) DeleteAvatar(ctx context.Context, cred entities.AuthenticatedUser, userUUID string) error {
+	user, err := uc.userRepo.FindByUUID(ctx, userUUID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"user_id": {constants.ErrMsgNotFound},
+		})
+	}
+	if !user.AvatarKey.IsNotEmpty() {
+		return nil
+	}
+
+	previousKey := user.AvatarKey.GetOrDefault()
+
+	user.AvatarKey = nullable.NewNilString()
+	user.UpdateModel(cred.Username)
+
+	err = uc.userRepo.UpdateAvatarKey(ctx, *user)
+	if err != nil {
+		return err
+	}
+
+	uc.deleteAvatarVariants(ctx, previousKey)
+
+	return nil
+}
+
+// ShowAvatar returns the user whose initials avatar is rendered, nil when the user does not exist
+func (uc *UserUseCase) ShowAvatar(ctx context.Context, userUUID string) (*entities.User, error) {
+	user, err := uc.userRepo.FindByUUID(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// deleteAvatarVariants is best effort, an orphaned blob is preferable to failing the request
+func (uc *UserUseCase) deleteAvatarVariants(ctx context.Context, avatarKey string) {
+	for _, size := range entities.AvatarSizes {
+		_ = uc.storage.Delete(ctx, entities.AvatarVariantKey(avatarKey, size))
+	}
+}
+
+// setAvatarURLs fills AvatarURLs for users with an uploaded avatar, the others keep using the gradient
+func (uc *UserUseCase) setAvatarURLs(users ...*entities.User) {
+	for _, user := range users {
+		if !user.AvatarKey.IsNotEmpty() {
+			continue
+		}
+
+		user.AvatarURLs = make(entities.AvatarURLs, len(entities.AvatarSizes))
+		for _, size := range entities.AvatarSizes {
+			user.AvatarURLs[strconv.Itoa(size)] = uc.storage.URL(entities.AvatarVariantKey(user.AvatarKey.GetOrDefault(), size))
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/laksanagusta/identity/internal/entities"
+)
+
+// IndexChangeEvent returns the change feed for external consumers, users only carry their public attributes.
+// A cursor whose following events were purged fails with entities.ErrChangeResyncRequired.
+func (uc *UserUseCase) IndexChangeEvent(ctx context.Context, params entities.ListChangeEventParams) ([]*entities.ChangeEvent, error) {
+	err := uc.checkChangeCursor(ctx, params.After)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.indexChangeEvent(ctx, params, entities.AttributeVisibilityPublic)
+}
+
+// PurgeChangeEvents deletes the events that occurred before and returns how many were deleted
+func (uc *UserUseCase) PurgeChangeEvents(ctx context.Context, before time.Time) (int64, error) {
+	return uc.userRepo.PurgeChangeEvents(ctx, before)
+}
+
+// checkChangeCursor returns entities.ErrChangeResyncRequired when events after cursor were purged, the
+// start of the feed is always valid and reads the events that are left
+func (uc *UserUseCase) checkChangeCursor(ctx context.Context, cursor entities.ChangeCursor) error {
+	if cursor == (entities.ChangeCursor{}) {
+		return nil
+	}
+
+	purged, err := uc.userRepo.IsChangeCursorPurged(ctx, cursor)
+	if err != nil {
+		return err
+	}
+	if purged {
+		return entities.ErrChangeResyncRequired
+	}
+
+	return nil
+}
+
+// indexChangeEvent returns the events with the user attributes of the given visibilities. The feed
+// records changes of every attribute, so a user event whose only changes are in attributes of other
+// visibilities carries the same data as the event before it.
+func (uc *UserUseCase) indexChangeEvent(ctx context.Context, params entities.ListChangeEventParams, visibilities ...string) ([]*entities.ChangeEvent, error) {
+	events, err := uc.userRepo.IndexChangeEvent(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var definitions entities.UserAttributeDefinitions
+	loaded := false
+	for _, event := range events {
+		if event.EntityType != entities.ChangeEntityUser || len(event.Data) == 0 {
+			continue
+		}
+
+		if !loaded {
+			definitionList, err := uc.userRepo.FindAttributeDefinitions(ctx)
+			if err != nil {
+				return nil, err
+			}
+			definitions = entities.UserAttributeDefinitions(definitionList)
+			loaded = true
+		}
+
+		event.Data, err = visibleUserChangeData(event.Data, definitions, visibilities...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return events, nil
+}
+
+// visibleUserChangeData drops the attributes of other visibilities from the data of a user event
+func visibleUserChangeData(data json.RawMessage, definitions entities.UserAttributeDefinitions, visibilities ...string) (json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	var attributes entities.UserAttributes
+	if raw, ok := fields["attributes"]; ok {
+		if err := json.Unmarshal(raw, &attributes); err != nil {
+			return nil, err
+		}
+	}
+
+	visible, err := json.Marshal(definitions.Visible(attributes, visibilities...))
+	if err != nil {
+		return nil, err
+	}
+	fields["attributes"] = visible
+
+	return json.Marshal(fields)
+}
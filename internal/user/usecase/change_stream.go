@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+
+	"github.com/laksanagusta/identity/constants"
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/user/dtos"
+	"github.com/laksanagusta/identity/pkg/errorhelper"
+)
+
+// changeBroadcast wakes every stream at once by closing the channel they wait on, the zero value is ready
+type changeBroadcast struct {
+	mu      sync.Mutex
+	changed chan struct{}
+}
+
+func (b *changeBroadcast) wait() <-chan struct{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.changed == nil {
+		b.changed = make(chan struct{})
+	}
+	return b.changed
+}
+
+func (b *changeBroadcast) notify() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.changed != nil {
+		close(b.changed)
+		b.changed = nil
+	}
+}
+
+// NotifyChangeEvent wakes the streams of this instance, it is called whenever any instance wrote events
+func (uc *UserUseCase) NotifyChangeEvent() {
+	uc.changes.notify()
+}
+
+// WatchChangeEvent returns a channel that is closed on the next NotifyChangeEvent. Streams should watch
+// before reading the feed so that events written meanwhile wake them again.
+func (uc *UserUseCase) WatchChangeEvent() <-chan struct{} {
+	return uc.changes.wait()
+}
+
+// NewChangeStream returns the params of the first page of a stream. The stream is limited to the subtree
+// of the active organization of the caller, or of the requested organization inside it. Without a last
+// event ID it starts after the latest event.
+func (uc *UserUseCase) NewChangeStream(ctx context.Context, cred entities.AuthenticatedUser, req dtos.StreamChangeReq) (entities.ListChangeEventParams, error) {
+	params := entities.ListChangeEventParams{
+		Types:            req.EventTypes(),
+		OrganizationUUID: cred.ActiveOrganization.ID.String(),
+		Limit:            dtos.ChangeStreamPageSize,
+	}
+
+	if req.OrganizationUUID != "" && req.OrganizationUUID != params.OrganizationUUID {
+		ancestors, err := uc.organizationRepo.FindAncestors(ctx, req.OrganizationUUID)
+		if err != nil {
+			return entities.ListChangeEventParams{}, err
+		}
+
+		visible := slices.ContainsFunc(ancestors, func(ancestor *entities.Organization) bool {
+			return ancestor.UUID == params.OrganizationUUID
+		})
+		if !visible {
+			return entities.ListChangeEventParams{}, errorhelper.BadRequestMap(map[string][]string{
+				"organization_id": {constants.ErrMsgChangeOrganization},
+			})
+		}
+
+		params.OrganizationUUID = req.OrganizationUUID
+	}
+
+	if req.LastEventID != "" {
+		cursor, err := entities.ParseChangeCursor(req.LastEventID)
+		if err != nil {
+			return entities.ListChangeEventParams{}, err
+		}
+
+		err = uc.checkChangeCursor(ctx, cursor)
+		if errors.Is(err, entities.ErrChangeResyncRequired) {
+			return entities.ListChangeEventParams{}, errorhelper.BadRequestMap(map[string][]string{
+				"last_event_id": {constants.ErrMsgChangeResync},
+			})
+		}
+		if err != nil {
+			return entities.ListChangeEventParams{}, err
+		}
+		params.After = cursor
+		return params, nil
+	}
+
+	cursor, err := uc.userRepo.LatestChangeCursor(ctx)
+	if err != nil {
+		return entities.ListChangeEventParams{}, err
+	}
+	params.After = cursor
+
+	return params, nil
+}
+
+// StreamChangeEvent returns the next page of a stream, users carry the attributes the internal API shows
+func (uc *UserUseCase) StreamChangeEvent(ctx context.Context, params entities.ListChangeEventParams) ([]*entities.ChangeEvent, error) {
+	return uc.indexChangeEvent(ctx, params, entities.AttributeVisibilityPublic, entities.AttributeVisibilityInternal)
+}
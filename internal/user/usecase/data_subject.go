@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/laksanagusta/identity/constants"
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/pkg/database"
+	"github.com/laksanagusta/identity/pkg/errorhelper"
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+const (
+	erasedUsernamePrefix = "erased-"
+	erasedFirstName      = "Erased User"
+)
+
+func (uc *UserUseCase) ExportUser(ctx context.Context, cred entities.AuthenticatedUser, userUUID string) (*entities.UserDataExport, error) {
+	user, err := uc.userRepo.FindByUUID(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errorhelper.BadRequestMap(map[string][]string{
+			"user_id": {constants.ErrMsgNotFound},
+		})
+	}
+
+	roles, err := uc.userRepo.FindRoleByUserUUID(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Roles = roles
+
+	roleList := entities.Roles(roles)
+	permissions, err := uc.userRepo.FindPermissionByRoleUUIDs(ctx, roleList.Uuids())
+	if err != nil {
+		return nil, err
+	}
+
+	user.Permissions = permissions
+
+	positions, err := uc.userRepo.FindPositionsByUserUUID(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Positions = positions
+
+	uc.setAvatarURLs(user)
+
+	if user.OrganizationUUID.IsNotEmpty() {
+		organizations, err := uc.organizationRepo.FindOrganizationByUUIDs(ctx, []string{user.OrganizationUUID.GetOrDefault()})
+		if err != nil {
+			return nil, err
+		}
+		if len(organizations) > 0 {
+			user.Organization = organizations[0]
+		}
+	}
+
+	loginHistories, err := uc.userRepo.FindLoginHistoriesByUserUUID(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	auditLogs, err := uc.userRepo.FindAuditLogsByUser(ctx, userUUID, user.Username.GetOrDefault())
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.userRepo.InsertAuditLog(ctx, entities.NewAuditLog(
+		cred.Username,
+		entities.AuditActionUserExported,
+		entities.AuditEntityUser,
+		userUUID,
+		nil,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.UserDataExport{
+		User:           user,
+		LoginHistories: loginHistories,
+		AuditLogs:      auditLogs,
+		ExportedAt:     time.Now(),
+	}, nil
+}
+
+func (uc *UserUseCase) EraseUser(ctx context.Context, cred entities.AuthenticatedUser, userUUID string) error {
+	user, err := uc.userRepo.FindByUUID(ctx, userUUID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"user_id": {constants.ErrMsgNotFound},
+		})
+	}
+	if user.IsErased() {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"user": {constants.ErrMsgAlreadyErased},
+		})
+	}
+
+	// The UUID is kept as is, so foreign keys (user_roles, login_histories, ...) stay intact
+	pseudonym := erasedUsernamePrefix + user.UUID
+	now := time.Now()
+
+	erasedUser := entities.User{
+		Username:  nullable.NewString(pseudonym),
+		FirstName: nullable.NewString(erasedFirstName),
+		ErasedAt:  &now,
+	}
+	erasedUser.UUID = user.UUID
+	erasedUser.UpdatedBy = cred.Username
+
+	actor := cred.Username
+	if cred.ID == user.UUID {
+		actor = pseudonym
+	}
+
+	err = uc.txManager.Atomic(ctx, func(ctx context.Context, tx database.DBTx) error {
+		userRepoTrx := uc.userRepo.WithTransaction(tx)
+
+		err := userRepoTrx.Pseudonymize(ctx, erasedUser)
+		if err != nil {
+			return err
+		}
+
+		err = userRepoTrx.PseudonymizeChangeEvents(ctx, erasedUser)
+		if err != nil {
+			return err
+		}
+
+		err = userRepoTrx.ReplaceActorReferences(ctx, user.Username.GetOrDefault(), pseudonym)
+		if err != nil {
+			return err
+		}
+
+		return userRepoTrx.InsertAuditLog(ctx, entities.NewAuditLog(
+			actor,
+			entities.AuditActionUserErased,
+			entities.AuditEntityUser,
+			user.UUID,
+			map[string]any{"pseudonym": pseudonym},
+		))
+	})
+	if err != nil {
+		return err
+	}
+
+	if user.AvatarKey.IsNotEmpty() {
+		uc.deleteAvatarVariants(ctx, user.AvatarKey.GetOrDefault())
+	}
+
+	return nil
+}
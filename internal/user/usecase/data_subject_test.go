@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+func newDataSubjectUseCase() (*UserUseCase, *fakeUserRepo, *fakeStorage) {
+	stored := &entities.User{
+		Username:         nullable.NewString("john.doe"),
+		FirstName:        nullable.NewString("John"),
+		LastName:         nullable.NewString("Doe"),
+		Email:            nullable.NewString("john@example.com"),
+		OrganizationUUID: nullable.NewString("organization-a"),
+		AvatarKey:        nullable.NewString("avatars/user-a"),
+		IsActive:         true,
+	}
+	stored.UUID = "user-a"
+
+	role := &entities.Role{Name: nullable.NewString("staff")}
+	role.UUID = "role-a"
+	organization := &entities.Organization{Name: nullable.NewString("Head Office")}
+	organization.UUID = "organization-a"
+
+	userRepo := &fakeUserRepo{
+		users: map[string]*entities.User{stored.UUID: stored},
+		roles: []*entities.Role{role},
+	}
+	storage := &fakeStorage{}
+	organizationRepo := &fakeOrganizationRepo{organizations: []*entities.Organization{organization}}
+
+	uc := &UserUseCase{
+		userRepo:         userRepo,
+		organizationRepo: organizationRepo,
+		txManager:        fakeTxManager{},
+		storage:          storage,
+	}
+
+	return uc, userRepo, storage
+}
+
+func TestUserUseCase_ExportUser(t *testing.T) {
+	uc, userRepo, _ := newDataSubjectUseCase()
+	cred := entities.AuthenticatedUser{ID: "admin-a", Username: "admin"}
+
+	export, err := uc.ExportUser(context.Background(), cred, "user-a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if export.User.Username.GetOrDefault() != "john.doe" || export.User.Email.GetOrDefault() != "john@example.com" {
+		t.Errorf("unexpected user %+v", export.User)
+	}
+	if len(export.User.Roles) != 1 || export.User.Roles[0].UUID != "role-a" {
+		t.Errorf("unexpected roles %+v", export.User.Roles)
+	}
+	if export.User.Organization == nil || export.User.Organization.UUID != "organization-a" {
+		t.Errorf("unexpected organization %+v", export.User.Organization)
+	}
+	if len(export.User.AvatarURLs) != len(entities.AvatarSizes) {
+		t.Errorf("expected %d avatar URLs, got %v", len(entities.AvatarSizes), export.User.AvatarURLs)
+	}
+	if export.ExportedAt.IsZero() {
+		t.Error("expected the export time to be set")
+	}
+
+	if len(userRepo.auditLogs) != 1 {
+		t.Fatalf("expected 1 audit log, got %d", len(userRepo.auditLogs))
+	}
+	auditLog := userRepo.auditLogs[0]
+	if auditLog.Action != entities.AuditActionUserExported || auditLog.Actor != "admin" || auditLog.EntityUUID != "user-a" {
+		t.Errorf("unexpected audit log %+v", auditLog)
+	}
+
+	if _, err := uc.ExportUser(context.Background(), cred, "user-b"); err == nil {
+		t.Error("expected an error for an unknown user")
+	}
+}
+
+func TestUserUseCase_EraseUser(t *testing.T) {
+	uc, userRepo, storage := newDataSubjectUseCase()
+	cred := entities.AuthenticatedUser{ID: "admin-a", Username: "admin"}
+
+	err := uc.EraseUser(context.Background(), cred, "user-a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	erased := userRepo.users["user-a"]
+	if !erased.IsErased() || erased.IsActive {
+		t.Errorf("expected the user to be erased and inactive, got %+v", erased)
+	}
+	if erased.Username.GetOrDefault() != "erased-user-a" || erased.FirstName.GetOrDefault() != erasedFirstName {
+		t.Errorf("unexpected pseudonym %q %q", erased.Username.GetOrDefault(), erased.FirstName.GetOrDefault())
+	}
+	if erased.Email.IsNotEmpty() || erased.LastName.IsNotEmpty() {
+		t.Errorf("expected personal data to be removed, got %+v", erased)
+	}
+	if userRepo.replacedActors["john.doe"] != "erased-user-a" {
+		t.Errorf("expected actor references to be replaced, got %v", userRepo.replacedActors)
+	}
+	if changeEvent, ok := userRepo.changeEvents["user-a"]; !ok || changeEvent.Username.GetOrDefault() != "erased-user-a" {
+		t.Errorf("expected the change events of the user to be pseudonymized, got %v", userRepo.changeEvents)
+	}
+	if len(storage.deleted) != len(entities.AvatarSizes) {
+		t.Errorf("expected %d avatar variants to be deleted, got %v", len(entities.AvatarSizes), storage.deleted)
+	}
+
+	if len(userRepo.auditLogs) != 1 {
+		t.Fatalf("expected 1 audit log, got %d", len(userRepo.auditLogs))
+	}
+	auditLog := userRepo.auditLogs[0]
+	if auditLog.Action != entities.AuditActionUserErased || auditLog.Actor != "admin" || auditLog.EntityUUID != "user-a" {
+		t.Errorf("unexpected audit log %+v", auditLog)
+	}
+
+	if err := uc.EraseUser(context.Background(), cred, "user-a"); err == nil {
+		t.Error("expected an error for an erased user")
+	}
+}
+
+func TestUserUseCase_EraseUser_Self(t *testing.T) {
+	uc, userRepo, _ := newDataSubjectUseCase()
+	cred := entities.AuthenticatedUser{ID: "user-a", Username: "john.doe"}
+
+	err := uc.EraseUser(context.Background(), cred, "user-a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The audit log must not keep the username that was just erased
+	if len(userRepo.auditLogs) != 1 || userRepo.auditLogs[0].Actor != "erased-user-a" {
+		t.Errorf("unexpected audit logs %+v", userRepo.auditLogs)
+	}
+}
@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/organization"
+	"github.com/laksanagusta/identity/internal/user"
+	"github.com/laksanagusta/identity/pkg/database"
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+// fakeUserRepo keeps users in memory, methods the tests do not use are left unimplemented
+type fakeUserRepo struct {
+	user.Repository
+	users          map[string]*entities.User
+	roles          []*entities.Role
+	auditLogs      []entities.AuditLog
+	replacedActors map[string]string
+	changeEvents   map[string]entities.User
+}
+
+func (r *fakeUserRepo) WithTransaction(tx database.DBTx) user.Repository {
+	return r
+}
+
+func (r *fakeUserRepo) FindByUUID(ctx context.Context, uuid string) (*entities.User, error) {
+	stored, ok := r.users[uuid]
+	if !ok {
+		return nil, nil
+	}
+
+	found := *stored
+	return &found, nil
+}
+
+func (r *fakeUserRepo) FindByUsername(ctx context.Context, username string) (*entities.User, error) {
+	return r.findBy(func(u *entities.User) bool { return u.Username.GetOrDefault() == username })
+}
+
+func (r *fakeUserRepo) FindByEmail(ctx context.Context, email string) (*entities.User, error) {
+	return r.findBy(func(u *entities.User) bool { return u.Email.GetOrDefault() == email })
+}
+
+func (r *fakeUserRepo) FindByEmployeeID(ctx context.Context, employeeID string) (*entities.User, error) {
+	return r.findBy(func(u *entities.User) bool { return u.EmployeeID.GetOrDefault() == employeeID })
+}
+
+func (r *fakeUserRepo) FindByPhoneNumber(ctx context.Context, phoneNumber string) (*entities.User, error) {
+	return r.findBy(func(u *entities.User) bool { return u.PhoneNumber.GetOrDefault() == phoneNumber })
+}
+
+func (r *fakeUserRepo) findBy(match func(u *entities.User) bool) (*entities.User, error) {
+	for _, stored := range r.users {
+		if match(stored) {
+			found := *stored
+			return &found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *fakeUserRepo) FindRoleByUserUUID(ctx context.Context, uuid string) ([]*entities.Role, error) {
+	return r.roles, nil
+}
+
+func (r *fakeUserRepo) FindPermissionByRoleUUIDs(ctx context.Context, roleUUIDs []string) ([]*entities.Permission, error) {
+	return nil, nil
+}
+
+func (r *fakeUserRepo) FindPositionsByUserUUID(ctx context.Context, userUUID string) (entities.UserPositions, error) {
+	return nil, nil
+}
+
+func (r *fakeUserRepo) FindLoginHistoriesByUserUUID(ctx context.Context, userUUID string) ([]*entities.LoginHistory, error) {
+	return []*entities.LoginHistory{}, nil
+}
+
+func (r *fakeUserRepo) FindAuditLogsByUser(ctx context.Context, userUUID string, username string) ([]*entities.AuditLog, error) {
+	return []*entities.AuditLog{}, nil
+}
+
+func (r *fakeUserRepo) InsertAuditLog(ctx context.Context, auditLog entities.AuditLog) error {
+	r.auditLogs = append(r.auditLogs, auditLog)
+	return nil
+}
+
+func (r *fakeUserRepo) Pseudonymize(ctx context.Context, erased entities.User) error {
+	stored := r.users[erased.UUID]
+	stored.Username = erased.Username
+	stored.FirstName = erased.FirstName
+	stored.LastName = nullable.NullString{}
+	stored.Email = nullable.NullString{}
+	stored.AvatarKey = nullable.NullString{}
+	stored.IsActive = false
+	stored.ErasedAt = erased.ErasedAt
+	return nil
+}
+
+func (r *fakeUserRepo) ReplaceActorReferences(ctx context.Context, oldUsername string, newUsername string) error {
+	if r.replacedActors == nil {
+		r.replacedActors = map[string]string{}
+	}
+	r.replacedActors[oldUsername] = newUsername
+	return nil
+}
+
+func (r *fakeUserRepo) PseudonymizeChangeEvents(ctx context.Context, erased entities.User) error {
+	if r.changeEvents == nil {
+		r.changeEvents = map[string]entities.User{}
+	}
+	r.changeEvents[erased.UUID] = erased
+	return nil
+}
+
+type fakeOrganizationRepo struct {
+	organization.Repository
+	organizations []*entities.Organization
+}
+
+func (r *fakeOrganizationRepo) FindOrganizationByUUIDs(ctx context.Context, uuids []string) ([]*entities.Organization, error) {
+	return r.organizations, nil
+}
+
+// fakeTxManager runs the callback without a transaction, the fake repositories ignore it
+type fakeTxManager struct{}
+
+func (fakeTxManager) Atomic(ctx context.Context, callback func(ctx context.Context, tx database.DBTx) error) error {
+	return callback(ctx, nil)
+}
+
+type fakeStorage struct {
+	deleted []string
+}
+
+func (s *fakeStorage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	return nil
+}
+
+func (s *fakeStorage) Get(ctx context.Context, key string) ([]byte, error) {
+	return nil, nil
+}
+
+func (s *fakeStorage) Delete(ctx context.Context, key string) error {
+	s.deleted = append(s.deleted, key)
+	return nil
+}
+
+func (s *fakeStorage) URL(key string) string {
+	return "/storage/" + key
+}
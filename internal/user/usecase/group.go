@@ -0,0 +1,261 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+
+	"github.com/laksanagusta/identity/constants"
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/user/dtos"
+	"github.com/laksanagusta/identity/pkg/database"
+	"github.com/laksanagusta/identity/pkg/errorhelper"
+	"github.com/laksanagusta/identity/pkg/nullable"
+	"github.com/laksanagusta/identity/pkg/pagination"
+)
+
+func (uc *UserUseCase) IndexGroup(ctx context.Context) ([]*entities.Group, error) {
+	return uc.userRepo.FindGroups(ctx)
+}
+
+func (uc *UserUseCase) ShowGroup(ctx context.Context, uuid string) (*entities.Group, error) {
+	group, err := uc.findGroup(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	group.Roles, err = uc.userRepo.FindRolesByGroupUUIDs(ctx, []string{group.UUID})
+	if err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (uc *UserUseCase) CreateGroup(ctx context.Context, group entities.Group) (string, error) {
+	existing, err := uc.userRepo.FindGroupByName(ctx, group.Name.GetOrDefault())
+	if err != nil {
+		return "", err
+	}
+	if existing != nil {
+		return "", errorhelper.BadRequestMap(map[string][]string{
+			"name": {constants.ErrMsgAlreadyExist},
+		})
+	}
+
+	return uc.userRepo.InsertGroup(ctx, group)
+}
+
+func (uc *UserUseCase) UpdateGroup(ctx context.Context, cred entities.AuthenticatedUser, req dtos.UpdateGroupReq) error {
+	group, err := uc.findGroup(ctx, req.UUID)
+	if err != nil {
+		return err
+	}
+
+	if req.Name.IsNotEmpty() {
+		existing, err := uc.userRepo.FindGroupByName(ctx, req.Name.GetOrDefault())
+		if err != nil {
+			return err
+		}
+		if existing != nil && existing.UUID != group.UUID {
+			return errorhelper.BadRequestMap(map[string][]string{
+				"name": {constants.ErrMsgAlreadyExist},
+			})
+		}
+	}
+
+	if group.IsStatic() && req.Filters != nil {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"filters": {constants.ErrMsgGroupNotDynamic},
+		})
+	}
+
+	req.Apply(group, cred)
+
+	return uc.userRepo.UpdateGroup(ctx, *group)
+}
+
+func (uc *UserUseCase) DeleteGroup(ctx context.Context, uuid string) error {
+	if _, err := uc.findGroup(ctx, uuid); err != nil {
+		return err
+	}
+
+	return uc.userRepo.DeleteGroup(ctx, uuid)
+}
+
+// PreviewGroup evaluates filters that are not saved yet
+func (uc *UserUseCase) PreviewGroup(ctx context.Context, req dtos.PreviewGroupReq) ([]*entities.User, *pagination.PagedResponse, error) {
+	group := entities.Group{
+		Type:    nullable.NewString(entities.GroupTypeDynamic),
+		Filters: req.Filters,
+	}
+
+	return uc.groupMembers(ctx, group, req.Pagination())
+}
+
+func (uc *UserUseCase) GroupMembers(ctx context.Context, req dtos.ListGroupMemberReq) ([]*entities.User, *pagination.PagedResponse, error) {
+	group, err := uc.findGroup(ctx, req.GroupUUID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return uc.groupMembers(ctx, *group, req.Pagination())
+}
+
+func (uc *UserUseCase) IsGroupMember(ctx context.Context, req dtos.GroupMemberReq) (bool, error) {
+	group, err := uc.findGroup(ctx, req.GroupUUID)
+	if err != nil {
+		return false, err
+	}
+
+	return uc.userRepo.IsGroupMember(ctx, *group, req.UserUUID)
+}
+
+// IndexGroupMember lists the direct members of a static group, nested groups are not expanded
+func (uc *UserUseCase) IndexGroupMember(ctx context.Context, groupUUID string) ([]*entities.GroupMember, error) {
+	group, err := uc.findStaticGroup(ctx, groupUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.userRepo.FindGroupMembersByGroupUUID(ctx, group.UUID)
+}
+
+func (uc *UserUseCase) CreateGroupMember(ctx context.Context, member entities.GroupMember) error {
+	if _, err := uc.findStaticGroup(ctx, member.GroupUUID); err != nil {
+		return err
+	}
+
+	if member.MemberUserUUID.IsNotEmpty() {
+		if err := uc.ensureUserExists(ctx, member.MemberUserUUID.GetOrDefault()); err != nil {
+			return err
+		}
+
+		return uc.userRepo.InsertGroupMember(ctx, member)
+	}
+
+	memberGroup, err := uc.userRepo.FindGroupByUUID(ctx, member.MemberGroupUUID.GetOrDefault())
+	if err != nil {
+		return err
+	}
+	if memberGroup == nil {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"group_id": {constants.ErrMsgNotFound},
+		})
+	}
+	if !memberGroup.IsStatic() {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"group_id": {constants.ErrMsgGroupNotStatic},
+		})
+	}
+
+	return uc.txManager.Atomic(ctx, func(ctx context.Context, tx database.DBTx) error {
+		userRepoTrx := uc.userRepo.WithTransaction(tx)
+
+		err := userRepoTrx.LockGroupMembers(ctx)
+		if err != nil {
+			return err
+		}
+
+		// the group must not already be nested, at any depth, inside the group being added
+		descendants, err := userRepoTrx.FindGroupDescendantUUIDs(ctx, memberGroup.UUID)
+		if err != nil {
+			return err
+		}
+		if memberGroup.UUID == member.GroupUUID || slices.Contains(descendants, member.GroupUUID) {
+			return errorhelper.BadRequestMap(map[string][]string{
+				"group_id": {constants.ErrMsgGroupCycle},
+			})
+		}
+
+		return userRepoTrx.InsertGroupMember(ctx, member)
+	})
+}
+
+func (uc *UserUseCase) DeleteGroupMember(ctx context.Context, groupUUID string, memberUUID string) error {
+	member, err := uc.userRepo.FindGroupMemberByUUID(ctx, memberUUID)
+	if err != nil {
+		return err
+	}
+	if member == nil || member.GroupUUID != groupUUID {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"member_id": {constants.ErrMsgNotFound},
+		})
+	}
+
+	return uc.userRepo.DeleteGroupMember(ctx, memberUUID)
+}
+
+func (uc *UserUseCase) CreateGroupRole(ctx context.Context, groupRole entities.GroupRole) error {
+	if _, err := uc.findGroup(ctx, groupRole.GroupUUID); err != nil {
+		return err
+	}
+
+	role, err := uc.userRepo.FindRoleByUUID(ctx, groupRole.RoleUUID)
+	if err != nil {
+		return err
+	}
+	if role == nil {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"role_id": {constants.ErrMsgNotFound},
+		})
+	}
+
+	return uc.userRepo.InsertGroupRole(ctx, groupRole)
+}
+
+func (uc *UserUseCase) DeleteGroupRole(ctx context.Context, req dtos.GroupRoleReq) error {
+	if _, err := uc.findGroup(ctx, req.GroupUUID); err != nil {
+		return err
+	}
+
+	return uc.userRepo.DeleteGroupRole(ctx, req.GroupUUID, req.RoleUUID)
+}
+
+func (uc *UserUseCase) findGroup(ctx context.Context, uuid string) (*entities.Group, error) {
+	group, err := uc.userRepo.FindGroupByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, errorhelper.BadRequestMap(map[string][]string{
+			"group_id": {constants.ErrMsgNotFound},
+		})
+	}
+
+	return group, nil
+}
+
+func (uc *UserUseCase) findStaticGroup(ctx context.Context, uuid string) (*entities.Group, error) {
+	group, err := uc.findGroup(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	if !group.IsStatic() {
+		return nil, errorhelper.BadRequestMap(map[string][]string{
+			"group_id": {constants.ErrMsgGroupNotStatic},
+		})
+	}
+
+	return group, nil
+}
+
+func (uc *UserUseCase) groupMembers(ctx context.Context, group entities.Group, page pagination.Pagination) ([]*entities.User, *pagination.PagedResponse, error) {
+	users, totalCount, err := uc.userRepo.FindGroupMembers(ctx, group, page)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uc.setAvatarURLs(users...)
+
+	totalPages := int(totalCount) / page.Limit
+	if int(totalCount)%page.Limit > 0 {
+		totalPages++
+	}
+
+	return users, &pagination.PagedResponse{
+		Page:       page.Page,
+		Limit:      page.Limit,
+		TotalItems: totalCount,
+		TotalPages: totalPages,
+	}, nil
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/user/dtos"
+	"github.com/laksanagusta/identity/pkg/helper"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// dummyPasswordHash is compared against when the identifier is unknown, see Login
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("identity-dummy-password"), bcrypt.MinCost)
+
+// findByLoginIdentifier resolves an identifier as email, username, employee ID and phone number,
+// in that order. An identifier shaped like an email is tried as a username too, older usernames may
+// contain an @. Usernames keep the case they were stored with. It returns the full user, nil when
+// nothing matches.
+func (uc *UserUseCase) findByLoginIdentifier(ctx context.Context, identifier string) (*entities.User, error) {
+	identifier = strings.TrimSpace(identifier)
+	if identifier == "" {
+		return nil, nil
+	}
+
+	lookups := []func() (*entities.User, error){}
+
+	if email, ok := helper.NormalizeEmail(identifier); ok {
+		lookups = append(lookups,
+			func() (*entities.User, error) { return uc.userRepo.FindByEmail(ctx, email) },
+			func() (*entities.User, error) { return uc.userRepo.FindByUsername(ctx, identifier) },
+		)
+	} else {
+		lookups = append(lookups,
+			func() (*entities.User, error) { return uc.userRepo.FindByUsername(ctx, identifier) },
+			func() (*entities.User, error) { return uc.userRepo.FindByEmployeeID(ctx, identifier) },
+		)
+
+		for _, phoneNumber := range helper.PhoneNumberCandidates(identifier) {
+			lookups = append(lookups, func() (*entities.User, error) { return uc.userRepo.FindByPhoneNumber(ctx, phoneNumber) })
+		}
+	}
+
+	for _, lookup := range lookups {
+		found, err := lookup()
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return uc.userRepo.FindByUUID(ctx, found.UUID)
+		}
+	}
+
+	return nil, nil
+}
+
+// DiscoverLogin tells the client how to authenticate the identifier. The answer depends only on
+// the identifier itself and configuration, never on the account, so it does not reveal whether
+// the account exists.
+func (uc *UserUseCase) DiscoverLogin(ctx context.Context, req dtos.DiscoverLoginReq) dtos.DiscoverLoginRes {
+	res := dtos.DiscoverLoginRes{
+		Methods: []string{entities.AuthMethodPassword},
+	}
+
+	if email, ok := helper.NormalizeEmail(req.Identifier); ok {
+		if ssoURL, ok := uc.authConfig.SSODomains[helper.EmailDomain(email)]; ok {
+			res.Methods = []string{entities.AuthMethodSSO, entities.AuthMethodPassword}
+			res.SSOURL = &ssoURL
+		}
+	}
+
+	return res
+}
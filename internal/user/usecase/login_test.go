@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/user/dtos"
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+func newLoginUseCase() (*UserUseCase, *fakeUserRepo) {
+	legacy := &entities.User{Username: nullable.NewString("john@legacy.id")}
+	legacy.UUID = "user-legacy"
+
+	employee := &entities.User{
+		Username:    nullable.NewString("jane"),
+		Email:       nullable.NewString("jane@example.com"),
+		EmployeeID:  nullable.NewString("EMP001"),
+		PhoneNumber: nullable.NewString("081234567890"),
+	}
+	employee.UUID = "user-employee"
+
+	userRepo := &fakeUserRepo{users: map[string]*entities.User{
+		legacy.UUID:   legacy,
+		employee.UUID: employee,
+	}}
+
+	return &UserUseCase{userRepo: userRepo}, userRepo
+}
+
+func TestUserUseCase_findByLoginIdentifier(t *testing.T) {
+	uc, _ := newLoginUseCase()
+
+	cases := map[string]string{
+		"jane@example.com": "user-employee",
+		"Jane@Example.com": "user-employee",
+		"john@legacy.id":   "user-legacy",
+		"jane":             "user-employee",
+		"EMP001":           "user-employee",
+		"+6281234567890":   "user-employee",
+	}
+	for identifier, expected := range cases {
+		found, err := uc.findByLoginIdentifier(context.Background(), identifier)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", identifier, err)
+		}
+		if found == nil || found.UUID != expected {
+			t.Errorf("%s: expected %s, got %+v", identifier, expected, found)
+		}
+	}
+
+	found, err := uc.findByLoginIdentifier(context.Background(), "nobody@example.com")
+	if err != nil || found != nil {
+		t.Errorf("expected no user, got %+v %v", found, err)
+	}
+}
+
+func TestUsernameTakenAsIdentifier(t *testing.T) {
+	_, userRepo := newLoginUseCase()
+
+	cases := map[string]bool{
+		"EMP001":           true,
+		"Jane@Example.com": true,
+		"081234567890":     true,
+		"+6281234567890":   true,
+		"jane.doe":         false,
+		"john@legacy.id":   false,
+	}
+	for username, expected := range cases {
+		taken, err := usernameTakenAsIdentifier(context.Background(), userRepo, username, "")
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", username, err)
+		}
+		if taken != expected {
+			t.Errorf("%s: expected %t, got %t", username, expected, taken)
+		}
+	}
+
+	// A user may keep its own employee ID as username
+	taken, err := usernameTakenAsIdentifier(context.Background(), userRepo, "EMP001", "user-employee")
+	if err != nil || taken {
+		t.Errorf("expected the identifier of the user itself to be allowed, got %t %v", taken, err)
+	}
+}
+
+func TestUserUseCase_Update_RejectsUsernameOfAnotherIdentifier(t *testing.T) {
+	uc, _ := newLoginUseCase()
+
+	err := uc.Update(context.Background(), entities.AuthenticatedUser{Username: "admin"}, dtos.UpdateUserReq{
+		UserUUID: "user-legacy",
+		Username: nullable.NewString("EMP001"),
+	})
+	if err == nil {
+		t.Fatal("expected the username to be rejected")
+	}
+}
+
+func TestUserUseCase_newUserConflicts(t *testing.T) {
+	uc, userRepo := newLoginUseCase()
+
+	errs, err := uc.newUserConflicts(context.Background(), userRepo, "EMP001", "089999999999", "EMP002")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(errs) != 1 || len(errs["username"]) != 1 {
+		t.Errorf("expected only the username to conflict, got %v", errs)
+	}
+}
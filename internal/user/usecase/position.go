@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/laksanagusta/identity/constants"
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/user"
+	"github.com/laksanagusta/identity/internal/user/dtos"
+	"github.com/laksanagusta/identity/pkg/database"
+	"github.com/laksanagusta/identity/pkg/errorhelper"
+)
+
+func (uc *UserUseCase) IndexUserPosition(ctx context.Context, userUUID string) (entities.UserPositions, error) {
+	err := uc.ensureUserExists(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.userRepo.FindPositionsByUserUUID(ctx, userUUID)
+}
+
+func (uc *UserUseCase) CreateUserPosition(ctx context.Context, cred entities.AuthenticatedUser, req dtos.CreateUserPositionReq) (string, error) {
+	err := uc.ensureUserExists(ctx, req.UserUUID)
+	if err != nil {
+		return "", err
+	}
+
+	position := req.NewUserPosition(cred)
+
+	err = uc.validatePosition(ctx, position)
+	if err != nil {
+		return "", err
+	}
+
+	var newUUID string
+	err = uc.txManager.Atomic(ctx, func(ctx context.Context, tx database.DBTx) error {
+		userRepoTrx := uc.userRepo.WithTransaction(tx)
+
+		if position.IsPrimary {
+			err := userRepoTrx.ClearPrimaryPosition(ctx, position.UserUUID, position.UUID)
+			if err != nil {
+				return err
+			}
+		}
+
+		newUUID, err = userRepoTrx.InsertPosition(ctx, position)
+		if err != nil {
+			return err
+		}
+
+		return uc.syncPrimaryOrganization(ctx, userRepoTrx, position, cred)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return newUUID, nil
+}
+
+func (uc *UserUseCase) UpdateUserPosition(ctx context.Context, cred entities.AuthenticatedUser, req dtos.UpdateUserPositionReq) error {
+	position, err := uc.findUserPosition(ctx, req.UserUUID, req.PositionUUID)
+	if err != nil {
+		return err
+	}
+
+	wasPrimary := position.IsPrimary
+	req.Apply(position, cred)
+
+	// the primary position mirrors users.organization_uuid, so it can only move to another position
+	if wasPrimary && !position.IsPrimary {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"is_primary": {constants.ErrMsgPositionPrimaryLocked},
+		})
+	}
+
+	err = uc.validatePosition(ctx, *position)
+	if err != nil {
+		return err
+	}
+
+	return uc.txManager.Atomic(ctx, func(ctx context.Context, tx database.DBTx) error {
+		userRepoTrx := uc.userRepo.WithTransaction(tx)
+
+		if position.IsPrimary {
+			err := userRepoTrx.ClearPrimaryPosition(ctx, position.UserUUID, position.UUID)
+			if err != nil {
+				return err
+			}
+		}
+
+		err := userRepoTrx.UpdatePosition(ctx, *position)
+		if err != nil {
+			return err
+		}
+
+		return uc.syncPrimaryOrganization(ctx, userRepoTrx, *position, cred)
+	})
+}
+
+func (uc *UserUseCase) DeleteUserPosition(ctx context.Context, cred entities.AuthenticatedUser, userUUID string, positionUUID string) error {
+	position, err := uc.findUserPosition(ctx, userUUID, positionUUID)
+	if err != nil {
+		return err
+	}
+	if position.IsPrimary {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"is_primary": {constants.ErrMsgPositionPrimaryLocked},
+		})
+	}
+
+	return uc.userRepo.DeletePosition(ctx, positionUUID)
+}
+
+// SwitchOrganization issues a new token whose active organization is one the user holds a position in
+func (uc *UserUseCase) SwitchOrganization(ctx context.Context, cred entities.AuthenticatedUser, req dtos.SwitchOrganizationReq) (string, error) {
+	user, err := uc.userRepo.FindByUUID(ctx, cred.ID)
+	if err != nil {
+		return "", err
+	}
+	if user == nil {
+		return "", errorhelper.BadRequestMap(map[string][]string{
+			"user_id": {constants.ErrMsgNotFound},
+		})
+	}
+
+	positions, err := uc.userRepo.FindPositionsByUserUUID(ctx, user.UUID)
+	if err != nil {
+		return "", err
+	}
+
+	organizationUUID := req.OrganizationUUID.GetOrDefault()
+	if !positions.CanActAs(user.OrganizationUUID.GetOrDefault(), organizationUUID, time.Now()) {
+		return "", errorhelper.BadRequestMap(map[string][]string{
+			"organization_id": {constants.ErrMsgNoActivePosition},
+		})
+	}
+
+	organizations, err := uc.organizationRepo.FindOrganizationByUUIDs(ctx, []string{organizationUUID})
+	if err != nil {
+		return "", err
+	}
+	if len(organizations) == 0 {
+		return "", errorhelper.BadRequestMap(map[string][]string{
+			"organization_id": {constants.ErrMsgNotFound},
+		})
+	}
+
+	roles, err := uc.userRepo.FindRoleByUserUUID(ctx, user.UUID)
+	if err != nil {
+		return "", err
+	}
+
+	user.Roles = roles
+
+	return uc.jwtAuth.GenerateToken(*user, *organizations[0])
+}
+
+func (uc *UserUseCase) findUserPosition(ctx context.Context, userUUID string, positionUUID string) (*entities.UserPosition, error) {
+	position, err := uc.userRepo.FindPositionByUUID(ctx, positionUUID)
+	if err != nil {
+		return nil, err
+	}
+	if position == nil || position.UserUUID != userUUID {
+		return nil, errorhelper.BadRequestMap(map[string][]string{
+			"position_id": {constants.ErrMsgNotFound},
+		})
+	}
+
+	return position, nil
+}
+
+func (uc *UserUseCase) validatePosition(ctx context.Context, position entities.UserPosition) error {
+	if position.EndDate != nil && position.EndDate.Before(position.StartDate) {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"end_date": {constants.ErrMsgPositionEndBeforeStart},
+		})
+	}
+
+	organizations, err := uc.organizationRepo.FindOrganizationByUUIDs(ctx, []string{position.OrganizationUUID})
+	if err != nil {
+		return err
+	}
+	if len(organizations) == 0 {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"organization_id": {constants.ErrMsgNotFound},
+		})
+	}
+
+	return nil
+}
+
+func (uc *UserUseCase) syncPrimaryOrganization(ctx context.Context, userRepo user.Repository, position entities.UserPosition, cred entities.AuthenticatedUser) error {
+	if !position.IsPrimary {
+		return nil
+	}
+
+	return userRepo.UpdateOrganizationUUID(ctx, position.UserUUID, position.OrganizationUUID, cred.Username)
+}
@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/laksanagusta/identity/constants"
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/user/dtos"
+	"github.com/laksanagusta/identity/pkg/database"
+	"github.com/laksanagusta/identity/pkg/errorhelper"
+	"github.com/laksanagusta/identity/pkg/nullable"
+)
+
+func (uc *UserUseCase) UpdateManager(ctx context.Context, cred entities.AuthenticatedUser, req dtos.UpdateManagerReq) error {
+	return uc.txManager.Atomic(ctx, func(ctx context.Context, tx database.DBTx) error {
+		userRepoTrx := uc.userRepo.WithTransaction(tx)
+
+		err := userRepoTrx.LockReportingLines(ctx)
+		if err != nil {
+			return err
+		}
+
+		user, err := userRepoTrx.FindByUUID(ctx, req.UserUUID)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return errorhelper.BadRequestMap(map[string][]string{
+				"user_id": {constants.ErrMsgNotFound},
+			})
+		}
+
+		managerUUID := req.ManagerUUID.GetOrDefault()
+		if managerUUID != "" {
+			manager, err := userRepoTrx.FindByUUID(ctx, managerUUID)
+			if err != nil {
+				return err
+			}
+			if manager == nil {
+				return errorhelper.BadRequestMap(map[string][]string{
+					"manager_id": {constants.ErrMsgNotFound},
+				})
+			}
+			if !manager.IsActive || manager.IsErased() {
+				return errorhelper.BadRequestMap(map[string][]string{
+					"manager_id": {constants.ErrMsgManagerInactive},
+				})
+			}
+
+			// the user must not already be above the new manager
+			chain, err := userRepoTrx.FindManagementChain(ctx, managerUUID)
+			if err != nil {
+				return err
+			}
+			if managerUUID == user.UUID || entities.Users(chain).Contains(user.UUID) {
+				return errorhelper.BadRequestMap(map[string][]string{
+					"manager_id": {constants.ErrMsgManagerCycle},
+				})
+			}
+		}
+
+		previousManagerUUID := user.ManagerUUID.GetOrDefault()
+		if previousManagerUUID == managerUUID {
+			return nil
+		}
+
+		if managerUUID == "" {
+			user.ManagerUUID = nullable.NewNilString()
+		} else {
+			user.ManagerUUID = nullable.NewString(managerUUID)
+		}
+		user.UpdateModel(cred.Username)
+
+		err = userRepoTrx.UpdateManager(ctx, *user)
+		if err != nil {
+			return err
+		}
+
+		return userRepoTrx.InsertAuditLog(ctx, entities.NewAuditLog(
+			cred.Username,
+			entities.AuditActionUserManagerChanged,
+			entities.AuditEntityUser,
+			user.UUID,
+			map[string]any{"from": previousManagerUUID, "to": managerUUID},
+		))
+	})
+}
+
+func (uc *UserUseCase) DirectReports(ctx context.Context, userUUID string) ([]*entities.User, error) {
+	err := uc.ensureUserExists(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	reports, err := uc.userRepo.FindDirectReports(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.setAvatarURLs(reports...)
+
+	return reports, nil
+}
+
+// ManagementChain returns the managers of the user from the direct manager up to the root
+func (uc *UserUseCase) ManagementChain(ctx context.Context, userUUID string) ([]*entities.User, error) {
+	err := uc.ensureUserExists(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	chain, err := uc.userRepo.FindManagementChain(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.setAvatarURLs(chain...)
+
+	return chain, nil
+}
+
+// Approver resolves the level-th active manager above the user, inactive and erased managers
+// are skipped so a departed manager does not block approvals. It returns nil when there is none.
+func (uc *UserUseCase) Approver(ctx context.Context, req dtos.ApproverReq) (*entities.User, error) {
+	chain, err := uc.ManagementChain(ctx, req.UserUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	level := max(req.Level, 1)
+	for _, manager := range chain {
+		if !manager.IsActive || manager.IsErased() {
+			continue
+		}
+
+		level--
+		if level == 0 {
+			return manager, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (uc *UserUseCase) ListByOrganizations(ctx context.Context, organizationUUIDs []string) ([]*entities.User, error) {
+	if len(organizationUUIDs) == 0 {
+		return []*entities.User{}, nil
+	}
+
+	users, err := uc.userRepo.FindUsersByOrganizationUUIDs(ctx, organizationUUIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.setAvatarURLs(users...)
+
+	return users, nil
+}
+
+func (uc *UserUseCase) ensureUserExists(ctx context.Context, userUUID string) error {
+	user, err := uc.userRepo.FindByUUID(ctx, userUUID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return errorhelper.BadRequestMap(map[string][]string{
+			"user_id": {constants.ErrMsgNotFound},
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/user/dtos"
+	"github.com/laksanagusta/identity/pkg/helper"
+	"github.com/laksanagusta/identity/pkg/nullable"
+	"github.com/laksanagusta/identity/pkg/pagination"
+)
+
+func (uc *UserUseCase) Suggest(ctx context.Context, req dtos.SuggestUserReq) ([]*entities.User, error) {
+	limit := req.Limit
+	if limit == 0 {
+		limit = dtos.DefaultSuggestLimit
+	}
+
+	users, err := uc.userRepo.Suggest(ctx, req.Query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.setAvatarURLs(users...)
+	setSearchHighlights(users, req.Query)
+
+	return users, nil
+}
+
+// setSearchHighlights marks the words of term in the searchable fields of users, fields
+// without a match are left out
+func setSearchHighlights(users []*entities.User, term string) {
+	tokens := pagination.SearchTokens(term)
+	if len(tokens) == 0 {
+		return
+	}
+
+	for _, user := range users {
+		fields := map[string]nullable.NullString{
+			"first_name":   user.FirstName,
+			"last_name":    user.LastName,
+			"username":     user.Username,
+			"email":        user.Email,
+			"phone_number": user.PhoneNumber,
+			"employee_id":  user.EmployeeID,
+		}
+
+		highlights := map[string]string{}
+		for field, value := range fields {
+			if !value.IsNotEmpty() {
+				continue
+			}
+
+			if highlighted, ok := helper.Highlight(value.GetOrDefault(), tokens); ok {
+				highlights[field] = highlighted
+			}
+		}
+		user.SearchHighlights = highlights
+	}
+}
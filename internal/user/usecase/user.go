@@ -7,12 +7,15 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/laksanagusta/identity/config"
 	"github.com/laksanagusta/identity/constants"
 	"github.com/laksanagusta/identity/internal/entities"
 	"github.com/laksanagusta/identity/internal/organization"
 	"github.com/laksanagusta/identity/internal/user"
 	"github.com/laksanagusta/identity/internal/user/dtos"
 	"github.com/laksanagusta/identity/pkg/authservice/jwt"
+	"github.com/laksanagusta/identity/pkg/blobstorage"
+	"github.com/laksanagusta/identity/pkg/database"
 	"github.com/laksanagusta/identity/pkg/errorhelper"
 	"github.com/laksanagusta/identity/pkg/helper"
 	"github.com/laksanagusta/identity/pkg/nullable"
@@ -25,6 +28,9 @@ type UseCaseParameter struct {
 	UserRepo         user.Repository
 	OrganizationRepo organization.Repository
 	JwtAuth          jwt.JwtAuth
+	TxManager        database.Manager
+	Storage          blobstorage.Storage
+	AuthConfig       config.AuthConfig
 }
 
 func NewUserUseCase(uc UseCaseParameter) user.UseCase {
@@ -32,6 +38,9 @@ func NewUserUseCase(uc UseCaseParameter) user.UseCase {
 		userRepo:         uc.UserRepo,
 		jwtAuth:          uc.JwtAuth,
 		organizationRepo: uc.OrganizationRepo,
+		txManager:        uc.TxManager,
+		storage:          uc.Storage,
+		authConfig:       uc.AuthConfig,
 	}
 }
 
@@ -39,59 +48,113 @@ type UserUseCase struct {
 	userRepo         user.Repository
 	jwtAuth          jwt.JwtAuth
 	organizationRepo organization.Repository
+	txManager        database.Manager
+	storage          blobstorage.Storage
+	authConfig       config.AuthConfig
+	changes          changeBroadcast
 }
 
 func (uc *UserUseCase) Create(ctx context.Context, req dtos.CreateNewUserReq) (string, error) {
 	user := req.NewUser()
 
-	existedUser, err := uc.userRepo.FindByUsername(ctx, strings.ToLower(req.Username))
+	errs, err := uc.newUserConflicts(ctx, uc.userRepo, req.Username, req.PhoneNumber, req.EmployeeID)
 	if err != nil {
 		return "", err
 	}
-	if existedUser != nil {
-		return "", errorhelper.BadRequestMap(map[string][]string{
-			"username": {constants.ErrMsgAlreadyExist},
-		})
+	if len(errs) > 0 {
+		return "", errorhelper.BadRequestMap(errs)
 	}
 
-	existedUser, err = uc.userRepo.FindByPhoneNumber(ctx, req.PhoneNumber)
+	organization, err := uc.organizationRepo.FindOrganizationNodeByUUID(ctx, req.OrganizationUUID)
 	if err != nil {
 		return "", err
 	}
-	if existedUser != nil {
+	if organization == nil {
 		return "", errorhelper.BadRequestMap(map[string][]string{
-			"phone_number": {constants.ErrMsgAlreadyExist},
+			"organization_id": {constants.ErrMsgNotFound},
 		})
 	}
 
-	existedUser, err = uc.userRepo.FindByEmployeeID(ctx, req.EmployeeID)
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.MinCost)
 	if err != nil {
 		return "", err
 	}
+
+	user.PasswordHash = nullable.NewString(string(passwordHash))
+
+	return uc.insertUser(ctx, uc.userRepo, user, "admin")
+}
+
+// newUserConflicts returns the fields of a new user that are already taken by another user
+func (uc *UserUseCase) newUserConflicts(ctx context.Context, repo user.Repository, username string, phoneNumber string, employeeID string) (map[string][]string, error) {
+	errs := map[string][]string{}
+
+	existedUser, err := repo.FindByUsername(ctx, strings.ToLower(username))
+	if err != nil {
+		return nil, err
+	}
 	if existedUser != nil {
-		return "", errorhelper.BadRequestMap(map[string][]string{
-			"employee_id": {constants.ErrMsgAlreadyExist},
-		})
+		errs["username"] = []string{constants.ErrMsgAlreadyExist}
+	} else {
+		taken, err := usernameTakenAsIdentifier(ctx, repo, username, "")
+		if err != nil {
+			return nil, err
+		}
+		if taken {
+			errs["username"] = []string{constants.ErrMsgUsernameIdentifier}
+		}
 	}
 
-	organization, err := uc.organizationRepo.FindOrganizationByUUID(ctx, req.OrganizationUUID)
+	existedUser, err = repo.FindByPhoneNumber(ctx, phoneNumber)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	if organization == nil {
-		return "", errorhelper.BadRequestMap(map[string][]string{
-			"organization_id": {constants.ErrMsgNotFound},
-		})
+	if existedUser != nil {
+		errs["phone_number"] = []string{constants.ErrMsgAlreadyExist}
 	}
 
-	passwordHash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.MinCost)
+	existedUser, err = repo.FindByEmployeeID(ctx, employeeID)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	if existedUser != nil {
+		errs["employee_id"] = []string{constants.ErrMsgAlreadyExist}
 	}
 
-	user.PasswordHash = nullable.NewString(string(passwordHash))
+	return errs, nil
+}
+
+// usernameTakenAsIdentifier tells whether username is the email, employee ID or phone number of a user
+// other than userUUID. Login tries usernames before those, such a username would take over the account.
+func usernameTakenAsIdentifier(ctx context.Context, repo user.Repository, username string, userUUID string) (bool, error) {
+	lookups := []func() (*entities.User, error){
+		func() (*entities.User, error) { return repo.FindByEmployeeID(ctx, username) },
+	}
 
-	newUUID, err := uc.userRepo.Insert(ctx, user)
+	if email, ok := helper.NormalizeEmail(username); ok {
+		lookups = append(lookups, func() (*entities.User, error) { return repo.FindByEmail(ctx, email) })
+	}
+
+	for _, phoneNumber := range helper.PhoneNumberCandidates(username) {
+		lookups = append(lookups, func() (*entities.User, error) { return repo.FindByPhoneNumber(ctx, phoneNumber) })
+	}
+
+	for _, lookup := range lookups {
+		found, err := lookup()
+		if err != nil {
+			return false, err
+		}
+		if found != nil && found.UUID != userUUID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// insertUser saves a new user together with its roles
+func (uc *UserUseCase) insertUser(ctx context.Context, repo user.Repository, user entities.User, username string) (string, error) {
+	newUUID, err := repo.Insert(ctx, user)
 	if err != nil {
 		return "", err
 	}
@@ -106,16 +169,16 @@ func (uc *UserUseCase) Create(ctx context.Context, req dtos.CreateNewUserReq) (s
 			BaseModel: entities.BaseModel{
 				UUID:      uuid.NewString(),
 				CreatedAt: now,
-				CreatedBy: "admin",
+				CreatedBy: username,
 				UpdatedAt: now,
-				UpdatedBy: "admin",
+				UpdatedBy: username,
 			},
 			UserUUID: newUUID,
 			RoleUUID: roleUUID,
 		})
 	}
 
-	err = uc.userRepo.BulkInsertUserRoles(ctx, userRoles)
+	err = repo.BulkInsertUserRoles(ctx, userRoles)
 	if err != nil {
 		return "", err
 	}
@@ -136,6 +199,16 @@ func (uc *UserUseCase) Update(ctx context.Context, cred entities.AuthenticatedUs
 				"username": {constants.ErrMsgAlreadyExist},
 			})
 		}
+
+		taken, err := usernameTakenAsIdentifier(ctx, uc.userRepo, user.Username.GetOrDefault(), req.UserUUID)
+		if err != nil {
+			return err
+		}
+		if taken {
+			return errorhelper.BadRequestMap(map[string][]string{
+				"username": {constants.ErrMsgUsernameIdentifier},
+			})
+		}
 	}
 
 	if req.EmployeeID.IsExists {
@@ -150,6 +223,15 @@ func (uc *UserUseCase) Update(ctx context.Context, cred entities.AuthenticatedUs
 		}
 	}
 
+	if req.Attributes != nil {
+		attributes, err := uc.mergeUserAttributes(ctx, cred, req.UserUUID, req.Attributes)
+		if err != nil {
+			return err
+		}
+
+		user.Attributes = attributes
+	}
+
 	if req.Password.IsExists {
 		passwordHash, err := bcrypt.GenerateFromPassword([]byte(req.Password.GetOrDefault()), bcrypt.MinCost)
 		if err != nil {
@@ -193,6 +275,17 @@ func (uc *UserUseCase) Update(ctx context.Context, cred entities.AuthenticatedUs
 		return err
 	}
 
+	err = uc.userRepo.InsertAuditLog(ctx, entities.NewAuditLog(
+		cred.Username,
+		entities.AuditActionUserUpdated,
+		entities.AuditEntityUser,
+		req.UserUUID,
+		nil,
+	))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -232,7 +325,7 @@ func (uc *UserUseCase) Show(ctx context.Context, uuid string) (*entities.User, [
 		}
 	}
 
-	organization, err := uc.organizationRepo.FindOrganizationByUUID(ctx, user.OrganizationUUID.GetOrDefault())
+	organization, err := uc.organizationRepo.FindOrganizationNodeByUUID(ctx, user.OrganizationUUID.GetOrDefault())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -244,29 +337,60 @@ func (uc *UserUseCase) Show(ctx context.Context, uuid string) (*entities.User, [
 
 	user.Organization = organization
 
+	positions, err := uc.userRepo.FindPositionsByUserUUID(ctx, uuid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user.Positions = positions
+
+	definitions, err := uc.userRepo.FindAttributeDefinitions(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user.Attributes = entities.UserAttributeDefinitions(definitions).Visible(
+		user.Attributes,
+		entities.AttributeVisibilityPublic,
+		entities.AttributeVisibilityInternal,
+	)
+
+	uc.setAvatarURLs(user)
+
 	return user, nil, nil
 }
 
 func (uc *UserUseCase) Login(ctx context.Context, req dtos.LoginReq) (string, error) {
-	user, err := uc.userRepo.FindByUsername(ctx, req.Username)
+	user, err := uc.findByLoginIdentifier(ctx, req.LoginIdentifier())
 	if err != nil {
 		return "", err
 	}
 	if user == nil {
+		// compare anyway so an unknown identifier takes as long as a wrong password
+		_ = bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(req.Password))
+
 		return "", errorhelper.BadRequestMap(map[string][]string{
-			"username": {constants.ErrMsgNotFound},
+			"credentials": {constants.ErrMsgInvalidCredentials},
 		})
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash.GetOrDefault()), []byte(req.Password))
 	if err != nil {
+		if err := uc.recordLogin(ctx, user.UUID, req, false, "invalid_password"); err != nil {
+			return "", err
+		}
+
 		return "", errorhelper.BadRequestMap(map[string][]string{
-			"password": {"invalid"},
+			"credentials": {constants.ErrMsgInvalidCredentials},
 		})
 	}
 
 	// Check if user is approved by admin
 	if !user.IsApproved {
+		if err := uc.recordLogin(ctx, user.UUID, req, false, "pending_approval"); err != nil {
+			return "", err
+		}
+
 		return "", errorhelper.BadRequestMap(map[string][]string{
 			"account": {constants.ErrMsgPendingApproval},
 		})
@@ -284,19 +408,51 @@ func (uc *UserUseCase) Login(ctx context.Context, req dtos.LoginReq) (string, er
 
 	user.Roles = roles
 
-	organization, err := uc.organizationRepo.FindOrganizationByUUID(ctx, user.OrganizationUUID.GetOrDefault())
+	organization, err := uc.organizationRepo.FindOrganizationNodeByUUID(ctx, user.OrganizationUUID.GetOrDefault())
 	if err != nil {
 		return "", err
 	}
+	if organization == nil {
+		return "", errorhelper.BadRequestMap(map[string][]string{
+			"organization_id": {constants.ErrMsgNotFound},
+		})
+	}
 
 	token, err := uc.jwtAuth.GenerateToken(*user, *organization)
 	if err != nil {
 		return "", err
 	}
 
+	err = uc.recordLogin(ctx, user.UUID, req, true, "")
+	if err != nil {
+		return "", err
+	}
+
+	err = uc.userRepo.UpdateLastLoginAt(ctx, user.UUID, time.Now())
+	if err != nil {
+		return "", err
+	}
+
 	return token, nil
 }
 
+func (uc *UserUseCase) recordLogin(ctx context.Context, userUUID string, req dtos.LoginReq, isSuccess bool, failureReason string) error {
+	loginHistory := entities.LoginHistory{
+		UUID:      uuid.NewString(),
+		UserUUID:  userUUID,
+		IPAddress: nullable.NewString(req.IPAddress),
+		UserAgent: nullable.NewString(req.UserAgent),
+		IsSuccess: isSuccess,
+		CreatedAt: time.Now(),
+	}
+
+	if failureReason != "" {
+		loginHistory.FailureReason = nullable.NewString(failureReason)
+	}
+
+	return uc.userRepo.InsertLoginHistory(ctx, loginHistory)
+}
+
 func (uc *UserUseCase) Role(ctx context.Context) ([]entities.Role, error) {
 	return uc.userRepo.FindRole(ctx)
 }
@@ -353,6 +509,9 @@ func (uc *UserUseCase) Index(ctx context.Context, params *pagination.QueryParams
 		}
 	}
 
+	uc.setAvatarURLs(users...)
+	setSearchHighlights(users, params.Search)
+
 	totalPages := int(totalCount) / params.Pagination.Limit
 	if int(totalCount)%params.Pagination.Limit > 0 {
 		totalPages++
@@ -421,6 +580,17 @@ func (uc *UserUseCase) Delete(ctx context.Context, cred entities.AuthenticatedUs
 		return err
 	}
 
+	err = uc.userRepo.InsertAuditLog(ctx, entities.NewAuditLog(
+		cred.Username,
+		entities.AuditActionUserDeleted,
+		entities.AuditEntityUser,
+		uuid,
+		nil,
+	))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -446,6 +616,17 @@ func (uc *UserUseCase) ApproveUser(ctx context.Context, cred entities.Authentica
 		return err
 	}
 
+	err = uc.userRepo.InsertAuditLog(ctx, entities.NewAuditLog(
+		cred.Username,
+		entities.AuditActionUserApproved,
+		entities.AuditEntityUser,
+		userUUID,
+		nil,
+	))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -466,6 +647,17 @@ func (uc *UserUseCase) RejectUser(ctx context.Context, cred entities.Authenticat
 		return err
 	}
 
+	err = uc.userRepo.InsertAuditLog(ctx, entities.NewAuditLog(
+		cred.Username,
+		entities.AuditActionUserRejected,
+		entities.AuditEntityUser,
+		userUUID,
+		nil,
+	))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
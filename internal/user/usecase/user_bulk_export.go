@@ -0,0 +1,15 @@
+package usecase
+
+import (
+	"context"
+	"iter"
+
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/pkg/pagination"
+)
+
+// BulkExport returns every user matching the filters of params with their organization and roles. The
+// query already runs when it returns, so invalid filters are reported before anything is written.
+func (uc *UserUseCase) BulkExport(ctx context.Context, params *pagination.QueryParams) (iter.Seq2[*entities.User, error], error) {
+	return uc.userRepo.StreamUsers(ctx, params)
+}
@@ -0,0 +1,274 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/laksanagusta/identity/constants"
+	"github.com/laksanagusta/identity/internal/entities"
+	"github.com/laksanagusta/identity/internal/user"
+	"github.com/laksanagusta/identity/internal/user/dtos"
+	"github.com/laksanagusta/identity/pkg/database"
+	"github.com/laksanagusta/identity/pkg/errorhelper"
+	"github.com/laksanagusta/identity/pkg/nullable"
+	"github.com/laksanagusta/identity/pkg/pagination"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// userImportStaleAfter is how long a running import may go without progress before it is taken over
+const userImportStaleAfter = 5 * time.Minute
+
+// CheckUserImport returns the result of every record of req, failing with the errors Create would
+// give it and with the values it repeats from earlier records of the file
+func (uc *UserUseCase) CheckUserImport(ctx context.Context, req dtos.ImportUserReq) (entities.UserImportResults, error) {
+	_, results, err := uc.resolveUserImport(ctx, req.Records)
+	return results, err
+}
+
+// ImportUsers queues a job creating the users of req once every record passes CheckUserImport
+func (uc *UserUseCase) ImportUsers(ctx context.Context, cred entities.AuthenticatedUser, req dtos.ImportUserReq) (string, error) {
+	rows, results, err := uc.resolveUserImport(ctx, req.Records)
+	if err != nil {
+		return "", err
+	}
+
+	failed := results.Failed()
+	if len(failed) > 0 {
+		return "", errorhelper.BadRequestMap(dtos.UserImportErrors(failed))
+	}
+
+	// Only hashes are stored with the job
+	for i, record := range req.Records {
+		passwordHash, err := bcrypt.GenerateFromPassword([]byte(record.Password), bcrypt.MinCost)
+		if err != nil {
+			return "", err
+		}
+		rows[i].PasswordHash = string(passwordHash)
+	}
+
+	return uc.userRepo.InsertUserImportJob(ctx, entities.NewUserImportJob(cred.Username, req.FileName, rows))
+}
+
+// resolveUserImport looks up the organizations and roles of records and checks every record against
+// the rules of Create
+func (uc *UserUseCase) resolveUserImport(ctx context.Context, records []dtos.UserImportRecord) (entities.UserImportRows, entities.UserImportResults, error) {
+	codes := make([]string, 0, len(records))
+	for _, record := range records {
+		if record.OrganizationCode != "" {
+			codes = append(codes, record.OrganizationCode)
+		}
+	}
+
+	organizations, err := uc.organizationRepo.FindOrganizationsByCodes(ctx, codes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	organizationUUIDs := make(map[string]string, len(organizations))
+	for _, organization := range organizations {
+		if organization.DeletedAt == nil {
+			organizationUUIDs[organization.Code.GetOrDefault()] = organization.UUID
+		}
+	}
+
+	// Keyed by lower case name, empty for names without a role
+	roleUUIDs := map[string]string{}
+	duplicates := dtos.DuplicateErrors(records)
+
+	rows := make(entities.UserImportRows, 0, len(records))
+	results := make(entities.UserImportResults, 0, len(records))
+	for _, record := range records {
+		record.OrganizationUUID = organizationUUIDs[record.OrganizationCode]
+
+		var unknownRoles []string
+		for _, name := range record.RoleNames {
+			roleUUID, ok := roleUUIDs[strings.ToLower(name)]
+			if !ok {
+				role, err := uc.userRepo.FindRoleByName(ctx, name)
+				if err != nil {
+					return nil, nil, err
+				}
+				if role != nil {
+					roleUUID = role.UUID
+				}
+				roleUUIDs[strings.ToLower(name)] = roleUUID
+			}
+
+			if roleUUID == "" {
+				unknownRoles = append(unknownRoles, name)
+				continue
+			}
+			record.RoleUUIDs = append(record.RoleUUIDs, roleUUID)
+		}
+
+		errs, err := record.Errors()
+		if err != nil {
+			return nil, nil, err
+		}
+		if record.OrganizationCode != "" && record.OrganizationUUID == "" {
+			errs["organization_code"] = []string{constants.ErrMsgNotFound}
+		}
+		if len(unknownRoles) > 0 {
+			errs["roles"] = []string{constants.ErrMsgNotFound + ": " + strings.Join(unknownRoles, ", ")}
+		}
+		for field, messages := range duplicates[record.Row] {
+			errs[field] = append(errs[field], messages...)
+		}
+
+		conflicts, err := uc.newUserConflicts(ctx, uc.userRepo, record.Username, record.PhoneNumber, record.EmployeeID)
+		if err != nil {
+			return nil, nil, err
+		}
+		for field, messages := range conflicts {
+			errs[field] = append(errs[field], messages...)
+		}
+
+		result := entities.UserImportResult{Row: record.Row}
+		if len(errs) > 0 {
+			result.Errors = errs
+		}
+		results = append(results, result)
+
+		rows = append(rows, entities.UserImportRow{
+			Row:              record.Row,
+			EmployeeID:       record.EmployeeID,
+			Username:         record.Username,
+			FirstName:        record.FirstName,
+			LastName:         record.LastName,
+			PhoneNumber:      record.PhoneNumber,
+			OrganizationUUID: record.OrganizationUUID,
+			RoleUUIDs:        record.RoleUUIDs,
+		})
+	}
+
+	return rows, results, nil
+}
+
+func (uc *UserUseCase) ShowUserImportJob(ctx context.Context, uuid string) (*entities.UserImportJob, error) {
+	job, err := uc.userRepo.FindUserImportJobByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, errorhelper.NotFound()
+	}
+
+	return job, nil
+}
+
+func (uc *UserUseCase) IndexUserImportJob(ctx context.Context, params *pagination.QueryParams) ([]*entities.UserImportJob, *pagination.PagedResponse, error) {
+	jobs, totalCount, err := uc.userRepo.IndexUserImportJob(ctx, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	totalPages := int(totalCount) / params.Pagination.Limit
+	if int(totalCount)%params.Pagination.Limit > 0 {
+		totalPages++
+	}
+
+	return jobs, &pagination.PagedResponse{
+		Page:       params.Pagination.Page,
+		Limit:      params.Pagination.Limit,
+		TotalItems: totalCount,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// RunUserImportJobs works through the queued imports and returns how many it finished. Every row is
+// created in its own transaction together with its progress, so a job taken over after a crash
+// continues right after the last row it saved.
+func (uc *UserUseCase) RunUserImportJobs(ctx context.Context) (int, error) {
+	finished := 0
+	for {
+		job, err := uc.userRepo.ClaimUserImportJob(ctx, time.Now().Add(-userImportStaleAfter))
+		if err != nil {
+			return finished, err
+		}
+		if job == nil {
+			return finished, nil
+		}
+
+		err = uc.runUserImportJob(ctx, *job)
+		if err != nil && ctx.Err() != nil {
+			// Stopped by a shutdown, the job is taken over once it is stale
+			return finished, err
+		}
+		if err != nil {
+			finishErr := uc.userRepo.FinishUserImportJob(ctx, job.UUID, entities.UserImportFailed, nullable.NewString(err.Error()))
+			if finishErr != nil {
+				return finished, finishErr
+			}
+			return finished, err
+		}
+
+		err = uc.userRepo.FinishUserImportJob(ctx, job.UUID, entities.UserImportCompleted, nullable.NullString{})
+		if err != nil {
+			return finished, err
+		}
+		finished++
+	}
+}
+
+func (uc *UserUseCase) runUserImportJob(ctx context.Context, job entities.UserImportJob) error {
+	for _, row := range job.PendingRows() {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		err := uc.txManager.Atomic(ctx, func(ctx context.Context, tx database.DBTx) error {
+			userRepoTrx := uc.userRepo.WithTransaction(tx)
+
+			result, err := uc.importUser(ctx, userRepoTrx, row, job.CreatedBy)
+			if err != nil {
+				return err
+			}
+
+			return userRepoTrx.SaveUserImportResult(ctx, job.UUID, result)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// importUser creates the user of row, the checks done when the job was queued are run again since
+// users, organizations and roles may have changed in the meantime
+func (uc *UserUseCase) importUser(ctx context.Context, repo user.Repository, row entities.UserImportRow, username string) (entities.UserImportResult, error) {
+	result := entities.UserImportResult{Row: row.Row}
+
+	errs, err := uc.newUserConflicts(ctx, repo, row.Username, row.PhoneNumber, row.EmployeeID)
+	if err != nil {
+		return result, err
+	}
+
+	organization, err := uc.organizationRepo.FindOrganizationNodeByUUID(ctx, row.OrganizationUUID)
+	if err != nil {
+		return result, err
+	}
+	if organization == nil {
+		errs["organization_code"] = []string{constants.ErrMsgNotFound}
+	}
+
+	for _, roleUUID := range row.RoleUUIDs {
+		role, err := repo.FindRoleByUUID(ctx, roleUUID)
+		if err != nil {
+			return result, err
+		}
+		if role == nil {
+			errs["roles"] = []string{constants.ErrMsgNotFound}
+		}
+	}
+
+	if len(errs) > 0 {
+		result.Errors = errs
+		return result, nil
+	}
+
+	result.UserUUID, err = uc.insertUser(ctx, repo, row.NewUser(username), username)
+	return result, err
+}
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os"
 
 	"github.com/laksanagusta/identity/config"
 	"github.com/laksanagusta/identity/internal/server"
@@ -42,6 +44,14 @@ func main() {
 		appLogger.Fatal("Error on getting database postgre connection: %s", err)
 	}
 
+	// Maintenance commands run against the database and exit instead of serving
+	if len(os.Args) > 1 {
+		code := runCommand(context.Background(), db, os.Args[1:], os.Stdout)
+		db.Close()
+		appLogger.Sync()
+		os.Exit(code)
+	}
+
 	// Create & Run Server
 	server := server.NewServer(cfg, appLogger, db)
 	if err = server.Run(); err != nil {
@@ -0,0 +1,8 @@
+ALTER TABLE users DROP COLUMN IF EXISTS erased_at;
+
+DROP INDEX IF EXISTS idx_audit_logs_actor;
+DROP INDEX IF EXISTS idx_audit_logs_entity;
+DROP TABLE IF EXISTS audit_logs;
+
+DROP INDEX IF EXISTS idx_login_histories_user_uuid;
+DROP TABLE IF EXISTS login_histories;
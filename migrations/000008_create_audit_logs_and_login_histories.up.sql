@@ -0,0 +1,28 @@
+-- Login history and audit trail, used by the data subject export and erasure
+CREATE TABLE IF NOT EXISTS login_histories (
+    uuid UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_uuid UUID NOT NULL REFERENCES users(uuid) ON DELETE CASCADE,
+    ip_address VARCHAR(64),
+    user_agent TEXT,
+    is_success BOOLEAN NOT NULL DEFAULT false,
+    failure_reason VARCHAR(100),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_histories_user_uuid ON login_histories(user_uuid, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS audit_logs (
+    uuid UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    actor VARCHAR(255) NOT NULL,
+    action VARCHAR(100) NOT NULL,
+    entity_type VARCHAR(100) NOT NULL,
+    entity_uuid UUID NOT NULL,
+    metadata JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_entity ON audit_logs(entity_type, entity_uuid);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor ON audit_logs(actor);
+
+-- Marks users whose personal data has been pseudonymized on request
+ALTER TABLE users ADD COLUMN IF NOT EXISTS erased_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_users_attributes;
+ALTER TABLE users DROP COLUMN IF EXISTS attributes;
+
+DROP TABLE IF EXISTS user_attribute_definitions;
@@ -0,0 +1,22 @@
+-- Registry of admin-defined custom user profile attributes
+CREATE TABLE IF NOT EXISTS user_attribute_definitions (
+    uuid UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    key VARCHAR(63) NOT NULL UNIQUE,
+    label VARCHAR(255) NOT NULL,
+    description TEXT,
+    type VARCHAR(20) NOT NULL,
+    is_required BOOLEAN NOT NULL DEFAULT false,
+    enum_values TEXT[] NOT NULL DEFAULT '{}',
+    regex TEXT,
+    visibility VARCHAR(20) NOT NULL DEFAULT 'internal',
+    is_editable BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_by VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_by VARCHAR(255) NOT NULL
+);
+
+-- Attribute values, validated against user_attribute_definitions by the application
+ALTER TABLE users ADD COLUMN IF NOT EXISTS attributes JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+CREATE INDEX IF NOT EXISTS idx_users_attributes ON users USING GIN (attributes);
@@ -0,0 +1,2 @@
+-- Blob storage prefix of the uploaded avatar variants, NULL falls back to the gradient
+ALTER TABLE users ADD COLUMN IF NOT EXISTS avatar_key VARCHAR(255);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_users_manager_uuid;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS chk_users_manager_not_self;
+ALTER TABLE users DROP COLUMN IF EXISTS manager_uuid;
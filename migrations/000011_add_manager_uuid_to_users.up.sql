@@ -0,0 +1,6 @@
+-- Reporting line, cycles are rejected by the application before writing
+ALTER TABLE users ADD COLUMN IF NOT EXISTS manager_uuid UUID REFERENCES users(uuid) ON DELETE SET NULL;
+
+ALTER TABLE users ADD CONSTRAINT chk_users_manager_not_self CHECK (manager_uuid IS NULL OR manager_uuid <> uuid);
+
+CREATE INDEX IF NOT EXISTS idx_users_manager_uuid ON users (manager_uuid);
@@ -0,0 +1,20 @@
+-- Positions a user holds, the primary one mirrors users.organization_uuid
+CREATE TABLE IF NOT EXISTS user_positions (
+    uuid UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_uuid UUID NOT NULL REFERENCES users(uuid) ON DELETE CASCADE,
+    organization_uuid UUID NOT NULL REFERENCES organizations(uuid),
+    title VARCHAR(255) NOT NULL,
+    is_primary BOOLEAN NOT NULL DEFAULT false,
+    start_date DATE NOT NULL,
+    end_date DATE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_by VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_by VARCHAR(255) NOT NULL,
+
+    CONSTRAINT chk_user_positions_dates CHECK (end_date IS NULL OR end_date >= start_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_positions_user_uuid ON user_positions (user_uuid);
+CREATE INDEX IF NOT EXISTS idx_user_positions_organization_uuid ON user_positions (organization_uuid);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_user_positions_primary ON user_positions (user_uuid) WHERE is_primary;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_users_search_fts;
+DROP INDEX IF EXISTS idx_users_search_trgm;
+DROP FUNCTION IF EXISTS users_search_document(TEXT, TEXT, TEXT, TEXT, TEXT, TEXT);
@@ -0,0 +1,28 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Text the user search matches against, immutable so it can back expression indexes
+CREATE OR REPLACE FUNCTION users_search_document(
+    first_name TEXT,
+    last_name TEXT,
+    username TEXT,
+    email TEXT,
+    phone_number TEXT,
+    employee_id TEXT
+) RETURNS TEXT AS $$
+    SELECT lower(
+        coalesce(first_name, '') || ' ' ||
+        coalesce(last_name, '') || ' ' ||
+        coalesce(username, '') || ' ' ||
+        coalesce(email, '') || ' ' ||
+        coalesce(phone_number, '') || ' ' ||
+        coalesce(employee_id, '')
+    )
+$$ LANGUAGE SQL IMMUTABLE PARALLEL SAFE;
+
+-- Serves ILIKE '%term%' and the word similarity operator <%
+CREATE INDEX IF NOT EXISTS idx_users_search_trgm ON users
+    USING GIN (users_search_document(first_name, last_name, username, email, phone_number, employee_id) gin_trgm_ops);
+
+-- Serves prefix full-text queries, 'simple' because names and ids must not be stemmed
+CREATE INDEX IF NOT EXISTS idx_users_search_fts ON users
+    USING GIN (to_tsvector('simple', users_search_document(first_name, last_name, username, email, phone_number, employee_id)));
@@ -0,0 +1,26 @@
+-- Saved groups of users, membership of dynamic groups is evaluated from their filters
+CREATE TABLE IF NOT EXISTS user_groups (
+    uuid UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL UNIQUE,
+    description TEXT,
+    type VARCHAR(20) NOT NULL DEFAULT 'dynamic',
+    filters JSONB NOT NULL DEFAULT '[]'::jsonb,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_by VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_by VARCHAR(255) NOT NULL
+);
+
+-- Role grants whose principal is a group, every member holds the role
+CREATE TABLE IF NOT EXISTS user_group_roles (
+    uuid UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    group_uuid UUID NOT NULL REFERENCES user_groups(uuid) ON DELETE CASCADE,
+    role_uuid UUID NOT NULL REFERENCES roles(uuid) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_by VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_by VARCHAR(255) NOT NULL,
+    UNIQUE(group_uuid, role_uuid)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_group_roles_role_uuid ON user_group_roles(role_uuid);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS user_group_members;
+
+ALTER TABLE user_groups DROP CONSTRAINT IF EXISTS chk_user_groups_type;
@@ -0,0 +1,20 @@
+ALTER TABLE user_groups ADD CONSTRAINT chk_user_groups_type CHECK (type IN ('dynamic', 'static'));
+
+-- Direct members of static groups, a member is either a user or a nested static group
+CREATE TABLE IF NOT EXISTS user_group_members (
+    uuid UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    group_uuid UUID NOT NULL REFERENCES user_groups(uuid) ON DELETE CASCADE,
+    member_user_uuid UUID REFERENCES users(uuid) ON DELETE CASCADE,
+    member_group_uuid UUID REFERENCES user_groups(uuid) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_by VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_by VARCHAR(255) NOT NULL,
+    CONSTRAINT chk_user_group_members_one_member CHECK ((member_user_uuid IS NULL) <> (member_group_uuid IS NULL)),
+    CONSTRAINT chk_user_group_members_not_self CHECK (member_group_uuid <> group_uuid)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_user_group_members_user ON user_group_members(group_uuid, member_user_uuid) WHERE member_user_uuid IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_user_group_members_group ON user_group_members(group_uuid, member_group_uuid) WHERE member_group_uuid IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_user_group_members_member_user_uuid ON user_group_members(member_user_uuid);
+CREATE INDEX IF NOT EXISTS idx_user_group_members_member_group_uuid ON user_group_members(member_group_uuid);
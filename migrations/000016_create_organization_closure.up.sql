@@ -0,0 +1,23 @@
+-- Every ancestor/descendant pair of the organization hierarchy, an organization is its own ancestor at depth 0.
+-- Soft deleted organizations keep their rows, readers filter on organizations.deleted_at.
+CREATE TABLE IF NOT EXISTS organization_closure (
+    ancestor_uuid UUID NOT NULL REFERENCES organizations(uuid) ON DELETE CASCADE,
+    descendant_uuid UUID NOT NULL REFERENCES organizations(uuid) ON DELETE CASCADE,
+    depth INTEGER NOT NULL CHECK (depth >= 0),
+    PRIMARY KEY (ancestor_uuid, descendant_uuid)
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_closure_ancestor_depth ON organization_closure(ancestor_uuid, depth);
+CREATE INDEX IF NOT EXISTS idx_organization_closure_descendant_depth ON organization_closure(descendant_uuid, depth);
+
+-- Backfill from the dot separated materialized paths, root first
+INSERT INTO organization_closure (ancestor_uuid, descendant_uuid, depth)
+SELECT a.uuid, o.uuid, cardinality(string_to_array(o.path, '.')) - p.position
+FROM organizations o
+CROSS JOIN LATERAL unnest(string_to_array(o.path, '.')) WITH ORDINALITY AS p(label, position)
+JOIN organizations a ON a.uuid::text = p.label
+ON CONFLICT DO NOTHING;
+
+-- Organizations created before levels were maintained
+UPDATE organizations SET level = cardinality(string_to_array(path, '.')) - 1
+WHERE level IS DISTINCT FROM cardinality(string_to_array(path, '.')) - 1;
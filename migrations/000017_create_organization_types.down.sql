@@ -0,0 +1,3 @@
+ALTER TABLE organizations DROP CONSTRAINT IF EXISTS fk_organizations_type;
+
+DROP TABLE IF EXISTS organization_types;
@@ -0,0 +1,32 @@
+-- Registry of organization types, organizations.type references its code
+CREATE TABLE IF NOT EXISTS organization_types (
+    uuid UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code VARCHAR(100) NOT NULL UNIQUE,
+    label VARCHAR(255) NOT NULL,
+    is_root_allowed BOOLEAN NOT NULL DEFAULT false,
+    allowed_parent_types TEXT[] NOT NULL DEFAULT '{}',
+    max_depth INTEGER CHECK (max_depth >= 0),
+    required_fields TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_by VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_by VARCHAR(255) NOT NULL
+);
+
+-- Register the free text types in use with the placements they already have. Address and
+-- coordinates stay mandatory for them as they were for every organization so far.
+INSERT INTO organization_types (code, label, is_root_allowed, allowed_parent_types, required_fields, created_by, updated_by)
+SELECT
+    o.type,
+    o.type,
+    bool_or(o.parent_uuid IS NULL),
+    COALESCE(array_agg(DISTINCT p.type) FILTER (WHERE p.type IS NOT NULL), '{}'),
+    ARRAY['address', 'latitude', 'longitude'],
+    'system',
+    'system'
+FROM organizations o
+LEFT JOIN organizations p ON p.uuid = o.parent_uuid
+GROUP BY o.type
+ON CONFLICT (code) DO NOTHING;
+
+ALTER TABLE organizations ADD CONSTRAINT fk_organizations_type FOREIGN KEY (type) REFERENCES organization_types(code);
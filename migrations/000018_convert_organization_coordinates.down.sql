@@ -0,0 +1,17 @@
+DROP INDEX IF EXISTS idx_organizations_coordinates;
+
+ALTER TABLE organizations
+    DROP CONSTRAINT IF EXISTS chk_organizations_coordinates,
+    DROP CONSTRAINT IF EXISTS chk_organizations_longitude,
+    DROP CONSTRAINT IF EXISTS chk_organizations_latitude;
+
+ALTER TABLE organizations
+    ALTER COLUMN latitude TYPE VARCHAR(50) USING latitude::text,
+    ALTER COLUMN longitude TYPE VARCHAR(50) USING longitude::text;
+
+-- Rejected coordinates are put back as they were
+UPDATE organizations o SET latitude = r.latitude, longitude = r.longitude
+FROM organization_coordinates_rejected r
+WHERE r.organization_uuid = o.uuid;
+
+DROP TABLE IF EXISTS organization_coordinates_rejected;
@@ -0,0 +1,40 @@
+-- Coordinates were free text. Decimal commas become points, a pair that still is not two numbers in
+-- range is copied to organization_coordinates_rejected as it was and cleared before converting.
+CREATE FUNCTION pg_temp.coordinate(value TEXT, bound DOUBLE PRECISION) RETURNS TEXT AS $$
+    SELECT CASE WHEN v ~ '^[-+]?[0-9]+(\.[0-9]+)?$' THEN
+        CASE WHEN abs(v::double precision) <= bound THEN v END
+    END
+    FROM replace(trim(value), ',', '.') AS v
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE TABLE IF NOT EXISTS organization_coordinates_rejected (
+    organization_uuid UUID PRIMARY KEY,
+    latitude VARCHAR(50),
+    longitude VARCHAR(50),
+    rejected_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+INSERT INTO organization_coordinates_rejected (organization_uuid, latitude, longitude)
+SELECT uuid, latitude, longitude
+FROM organizations
+WHERE (trim(latitude) <> '' OR trim(longitude) <> '')
+    AND (pg_temp.coordinate(latitude, 90) IS NULL OR pg_temp.coordinate(longitude, 180) IS NULL)
+ON CONFLICT (organization_uuid) DO NOTHING;
+
+-- Half a coordinate can not be placed on a map
+UPDATE organizations SET
+    latitude = CASE WHEN pg_temp.coordinate(longitude, 180) IS NOT NULL THEN pg_temp.coordinate(latitude, 90) END,
+    longitude = CASE WHEN pg_temp.coordinate(latitude, 90) IS NOT NULL THEN pg_temp.coordinate(longitude, 180) END
+WHERE latitude IS NOT NULL OR longitude IS NOT NULL;
+
+ALTER TABLE organizations
+    ALTER COLUMN latitude TYPE DOUBLE PRECISION USING latitude::double precision,
+    ALTER COLUMN longitude TYPE DOUBLE PRECISION USING longitude::double precision;
+
+ALTER TABLE organizations
+    ADD CONSTRAINT chk_organizations_latitude CHECK (latitude BETWEEN -90 AND 90),
+    ADD CONSTRAINT chk_organizations_longitude CHECK (longitude BETWEEN -180 AND 180),
+    ADD CONSTRAINT chk_organizations_coordinates CHECK ((latitude IS NULL) = (longitude IS NULL));
+
+CREATE INDEX idx_organizations_coordinates ON organizations (latitude, longitude)
+    WHERE latitude IS NOT NULL AND deleted_at IS NULL;
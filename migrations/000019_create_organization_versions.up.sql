@@ -0,0 +1,34 @@
+-- Effective dated values of the organization attributes that restructures change. Every attribute has
+-- its own timeline, valid_to is exclusive and NULL while open ended. organizations keeps the values in
+-- effect today, applied_at is set once a version has been written there.
+CREATE TABLE IF NOT EXISTS organization_versions (
+    uuid UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_uuid UUID NOT NULL REFERENCES organizations(uuid),
+    attribute VARCHAR(20) NOT NULL,
+    value TEXT,
+    valid_from DATE NOT NULL,
+    valid_to DATE,
+    applied_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_by VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_by VARCHAR(255) NOT NULL,
+
+    CONSTRAINT chk_organization_versions_attribute CHECK (attribute IN ('name', 'parent_uuid', 'type', 'is_active')),
+    CONSTRAINT chk_organization_versions_dates CHECK (valid_to IS NULL OR valid_to > valid_from),
+    CONSTRAINT uq_organization_versions_valid_from UNIQUE (organization_uuid, attribute, valid_from)
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_versions_valid_from ON organization_versions (valid_from, valid_to);
+CREATE INDEX IF NOT EXISTS idx_organization_versions_pending ON organization_versions (valid_from) WHERE applied_at IS NULL;
+
+-- Today's values become the first version of every organization
+INSERT INTO organization_versions (organization_uuid, attribute, value, valid_from, applied_at, created_by, updated_by)
+SELECT o.uuid, a.attribute, a.value, COALESCE(o.created_at, NOW())::date, NOW(), 'system', 'system'
+FROM organizations o
+CROSS JOIN LATERAL (VALUES
+    ('name', o.name),
+    ('parent_uuid', o.parent_uuid::text),
+    ('type', o.type),
+    ('is_active', COALESCE(o.is_active, true)::text)
+) AS a(attribute, value);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS organization_settings;
+DROP TABLE IF EXISTS organization_setting_definitions;
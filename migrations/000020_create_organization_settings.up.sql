@@ -0,0 +1,29 @@
+-- Registry of typed organization settings, default_value applies where no organization sets a value
+CREATE TABLE IF NOT EXISTS organization_setting_definitions (
+    uuid UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    key VARCHAR(63) NOT NULL UNIQUE,
+    label VARCHAR(255) NOT NULL,
+    description TEXT,
+    type VARCHAR(20) NOT NULL,
+    enum_values TEXT[] NOT NULL DEFAULT '{}',
+    default_value JSONB,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_by VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_by VARCHAR(255) NOT NULL
+);
+
+-- Values set on an organization, they apply to its whole subtree until a descendant overrides them
+CREATE TABLE IF NOT EXISTS organization_settings (
+    uuid UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_uuid UUID NOT NULL REFERENCES organizations(uuid) ON DELETE CASCADE,
+    key VARCHAR(63) NOT NULL REFERENCES organization_setting_definitions(key) ON DELETE CASCADE,
+    value JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_by VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_by VARCHAR(255) NOT NULL,
+    UNIQUE(organization_uuid, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_settings_key ON organization_settings(key);
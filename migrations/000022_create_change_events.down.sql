@@ -0,0 +1,10 @@
+DROP TRIGGER IF EXISTS trg_user_roles_change_events ON user_roles;
+DROP TRIGGER IF EXISTS trg_roles_change_events ON roles;
+DROP TRIGGER IF EXISTS trg_organizations_change_events ON organizations;
+DROP TRIGGER IF EXISTS trg_users_change_events ON users;
+
+DROP FUNCTION IF EXISTS record_change_event();
+DROP FUNCTION IF EXISTS change_event_is_deleted(JSONB);
+DROP FUNCTION IF EXISTS change_event_data(TEXT, JSONB);
+
+DROP TABLE IF EXISTS change_events;
@@ -0,0 +1,138 @@
+-- Feed of directory changes for incremental synchronization, filled by triggers so that no write path
+-- can miss it. Consumers read in (txid, id) order and only up to the oldest running transaction, an
+-- event is therefore never committed behind a cursor that was already handed out.
+CREATE TABLE IF NOT EXISTS change_events (
+    id BIGSERIAL PRIMARY KEY,
+    txid XID8 NOT NULL DEFAULT pg_current_xact_id(),
+    entity_type VARCHAR(20) NOT NULL,
+    entity_uuid UUID NOT NULL,
+    action VARCHAR(10) NOT NULL,
+    data JSONB,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_change_events_cursor ON change_events (txid, id);
+
+-- The fields published for a row, keyed like the API. Timestamps of the last update are left out so
+-- writes that change nothing else are not published.
+CREATE OR REPLACE FUNCTION change_event_data(entity_type TEXT, r JSONB) RETURNS JSONB AS $$
+    SELECT CASE entity_type
+        WHEN 'user' THEN jsonb_build_object(
+            'id', r->'uuid',
+            'employee_id', r->'employee_id',
+            'username', r->'username',
+            'email', r->'email',
+            'first_name', r->'first_name',
+            'last_name', r->'last_name',
+            'phone_number', r->'phone_number',
+            'organization_id', r->'organization_uuid',
+            'manager_id', r->'manager_uuid',
+            'is_active', r->'is_active',
+            'is_approved', r->'is_approved',
+            'attributes', r->'attributes',
+            'created_at', r->'created_at'
+        )
+        WHEN 'organization' THEN jsonb_build_object(
+            'id', r->'uuid',
+            'code', r->'code',
+            'name', r->'name',
+            'type', r->'type',
+            'parent_id', r->'parent_uuid',
+            'address', r->'address',
+            'latitude', r->'latitude',
+            'longitude', r->'longitude',
+            'is_active', r->'is_active',
+            'created_at', r->'created_at'
+        )
+        WHEN 'role' THEN jsonb_build_object(
+            'id', r->'uuid',
+            'name', r->'name',
+            'description', r->'description',
+            'created_at', r->'created_at'
+        )
+        WHEN 'user_role' THEN jsonb_build_object(
+            'id', r->'uuid',
+            'user_id', r->'user_uuid',
+            'role_id', r->'role_uuid',
+            'created_at', r->'created_at'
+        )
+    END
+$$ LANGUAGE SQL IMMUTABLE;
+
+-- Soft deleted and erased rows are published as deleted
+CREATE OR REPLACE FUNCTION change_event_is_deleted(r JSONB) RETURNS BOOLEAN AS $$
+    SELECT r->>'deleted_at' IS NOT NULL OR r->>'erased_at' IS NOT NULL
+$$ LANGUAGE SQL IMMUTABLE;
+
+-- Records the change of a row of the entity type in the first trigger argument. Tombstones carry no
+-- data except for role assignments, which consumers can only find by their user and role.
+CREATE OR REPLACE FUNCTION record_change_event() RETURNS TRIGGER AS $$
+DECLARE
+    entity_type TEXT := TG_ARGV[0];
+    old_row JSONB;
+    new_row JSONB;
+    event_action TEXT;
+    event_data JSONB;
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        old_row := to_jsonb(OLD);
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        new_row := to_jsonb(NEW);
+    END IF;
+
+    IF TG_OP = 'DELETE' OR change_event_is_deleted(new_row) THEN
+        -- Rows that were never published or are already tombstoned
+        IF old_row IS NULL OR change_event_is_deleted(old_row) THEN
+            RETURN NULL;
+        END IF;
+        event_action := 'deleted';
+        IF entity_type = 'user_role' THEN
+            event_data := change_event_data(entity_type, coalesce(new_row, old_row));
+        END IF;
+    ELSE
+        event_data := change_event_data(entity_type, new_row);
+        IF TG_OP = 'INSERT' OR change_event_is_deleted(old_row) THEN
+            event_action := 'created';
+        ELSIF event_data = change_event_data(entity_type, old_row) THEN
+            RETURN NULL;
+        ELSE
+            event_action := 'updated';
+        END IF;
+    END IF;
+
+    INSERT INTO change_events (entity_type, entity_uuid, action, data)
+    VALUES (entity_type, (coalesce(new_row, old_row)->>'uuid')::uuid, event_action, event_data);
+
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_users_change_events AFTER INSERT OR UPDATE OR DELETE ON users
+    FOR EACH ROW EXECUTE FUNCTION record_change_event('user');
+CREATE TRIGGER trg_organizations_change_events AFTER INSERT OR UPDATE OR DELETE ON organizations
+    FOR EACH ROW EXECUTE FUNCTION record_change_event('organization');
+CREATE TRIGGER trg_roles_change_events AFTER INSERT OR UPDATE OR DELETE ON roles
+    FOR EACH ROW EXECUTE FUNCTION record_change_event('role');
+CREATE TRIGGER trg_user_roles_change_events AFTER INSERT OR UPDATE OR DELETE ON user_roles
+    FOR EACH ROW EXECUTE FUNCTION record_change_event('user_role');
+
+-- Existing rows start the feed, so a consumer without a cursor receives the whole directory
+INSERT INTO change_events (entity_type, entity_uuid, action, data)
+SELECT 'organization', o.uuid, 'created', change_event_data('organization', to_jsonb(o))
+FROM organizations o WHERE o.deleted_at IS NULL ORDER BY o.level, o.created_at;
+
+INSERT INTO change_events (entity_type, entity_uuid, action, data)
+SELECT 'role', r.uuid, 'created', change_event_data('role', to_jsonb(r))
+FROM roles r WHERE r.deleted_at IS NULL ORDER BY r.created_at;
+
+INSERT INTO change_events (entity_type, entity_uuid, action, data)
+SELECT 'user', u.uuid, 'created', change_event_data('user', to_jsonb(u))
+FROM users u WHERE NOT change_event_is_deleted(to_jsonb(u)) ORDER BY u.created_at;
+
+INSERT INTO change_events (entity_type, entity_uuid, action, data)
+SELECT 'user_role', ur.uuid, 'created', change_event_data('user_role', to_jsonb(ur))
+FROM user_roles ur
+JOIN users u ON u.uuid = ur.user_uuid AND NOT change_event_is_deleted(to_jsonb(u))
+JOIN roles r ON r.uuid = ur.role_uuid AND r.deleted_at IS NULL
+ORDER BY ur.created_at;
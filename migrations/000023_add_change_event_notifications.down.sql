@@ -0,0 +1,48 @@
+DROP TRIGGER IF EXISTS trg_change_events_notify ON change_events;
+DROP FUNCTION IF EXISTS notify_change_events();
+
+CREATE OR REPLACE FUNCTION record_change_event() RETURNS TRIGGER AS $$
+DECLARE
+    entity_type TEXT := TG_ARGV[0];
+    old_row JSONB;
+    new_row JSONB;
+    event_action TEXT;
+    event_data JSONB;
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        old_row := to_jsonb(OLD);
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        new_row := to_jsonb(NEW);
+    END IF;
+
+    IF TG_OP = 'DELETE' OR change_event_is_deleted(new_row) THEN
+        -- Rows that were never published or are already tombstoned
+        IF old_row IS NULL OR change_event_is_deleted(old_row) THEN
+            RETURN NULL;
+        END IF;
+        event_action := 'deleted';
+        IF entity_type = 'user_role' THEN
+            event_data := change_event_data(entity_type, coalesce(new_row, old_row));
+        END IF;
+    ELSE
+        event_data := change_event_data(entity_type, new_row);
+        IF TG_OP = 'INSERT' OR change_event_is_deleted(old_row) THEN
+            event_action := 'created';
+        ELSIF event_data = change_event_data(entity_type, old_row) THEN
+            RETURN NULL;
+        ELSE
+            event_action := 'updated';
+        END IF;
+    END IF;
+
+    INSERT INTO change_events (entity_type, entity_uuid, action, data)
+    VALUES (entity_type, (coalesce(new_row, old_row)->>'uuid')::uuid, event_action, event_data);
+
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+DROP FUNCTION IF EXISTS change_event_organizations(TEXT, JSONB, JSONB);
+
+ALTER TABLE change_events DROP COLUMN IF EXISTS organization_uuids;
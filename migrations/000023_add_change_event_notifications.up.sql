@@ -0,0 +1,95 @@
+-- Organizations an event belongs to, so a stream can be limited to the subtree its reader may see.
+-- Roles are global and have NULL. Users and organizations that moved belong to both sides of the move.
+ALTER TABLE change_events ADD COLUMN IF NOT EXISTS organization_uuids UUID[];
+
+CREATE OR REPLACE FUNCTION change_event_organizations(entity_type TEXT, old_row JSONB, new_row JSONB) RETURNS UUID[] AS $$
+    SELECT CASE entity_type
+        WHEN 'user' THEN ARRAY(
+            SELECT DISTINCT o FROM unnest(ARRAY[
+                (new_row->>'organization_uuid')::uuid,
+                (old_row->>'organization_uuid')::uuid
+            ]) o WHERE o IS NOT NULL
+        )
+        WHEN 'organization' THEN ARRAY(
+            SELECT DISTINCT o FROM unnest(ARRAY[
+                (coalesce(new_row, old_row)->>'uuid')::uuid,
+                (old_row->>'parent_uuid')::uuid
+            ]) o WHERE o IS NOT NULL
+        )
+        WHEN 'user_role' THEN ARRAY(
+            SELECT u.organization_uuid FROM users u
+            WHERE u.uuid = (coalesce(new_row, old_row)->>'user_uuid')::uuid
+        )
+    END
+$$ LANGUAGE SQL STABLE;
+
+CREATE OR REPLACE FUNCTION record_change_event() RETURNS TRIGGER AS $$
+DECLARE
+    entity_type TEXT := TG_ARGV[0];
+    old_row JSONB;
+    new_row JSONB;
+    event_action TEXT;
+    event_data JSONB;
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        old_row := to_jsonb(OLD);
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        new_row := to_jsonb(NEW);
+    END IF;
+
+    IF TG_OP = 'DELETE' OR change_event_is_deleted(new_row) THEN
+        -- Rows that were never published or are already tombstoned
+        IF old_row IS NULL OR change_event_is_deleted(old_row) THEN
+            RETURN NULL;
+        END IF;
+        event_action := 'deleted';
+        IF entity_type = 'user_role' THEN
+            event_data := change_event_data(entity_type, coalesce(new_row, old_row));
+        END IF;
+    ELSE
+        event_data := change_event_data(entity_type, new_row);
+        IF TG_OP = 'INSERT' OR change_event_is_deleted(old_row) THEN
+            event_action := 'created';
+        ELSIF event_data = change_event_data(entity_type, old_row) THEN
+            RETURN NULL;
+        ELSE
+            event_action := 'updated';
+        END IF;
+    END IF;
+
+    INSERT INTO change_events (entity_type, entity_uuid, action, data, organization_uuids)
+    VALUES (
+        entity_type,
+        (coalesce(new_row, old_row)->>'uuid')::uuid,
+        event_action,
+        event_data,
+        change_event_organizations(entity_type, old_row, new_row)
+    );
+
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+-- Earlier events only know the organization they ended in
+UPDATE change_events e SET organization_uuids = CASE
+    WHEN e.entity_type = 'user' AND e.data ? 'organization_id' THEN ARRAY[(e.data->>'organization_id')::uuid]
+    WHEN e.entity_type = 'user' THEN ARRAY(SELECT u.organization_uuid FROM users u WHERE u.uuid = e.entity_uuid)
+    WHEN e.entity_type = 'organization' THEN ARRAY[e.entity_uuid]
+    WHEN e.entity_type = 'user_role' THEN ARRAY(
+        SELECT u.organization_uuid FROM users u WHERE u.uuid = (e.data->>'user_id')::uuid
+    )
+END
+WHERE e.entity_type <> 'role';
+
+-- Wakes the streams of every instance once per transaction, notifications with the same payload are
+-- folded and only delivered on commit
+CREATE OR REPLACE FUNCTION notify_change_events() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('change_events', '');
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_change_events_notify AFTER INSERT ON change_events
+    FOR EACH STATEMENT EXECUTE FUNCTION notify_change_events();
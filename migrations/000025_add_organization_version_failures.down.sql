@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_organization_versions_pending;
+CREATE INDEX IF NOT EXISTS idx_organization_versions_pending ON organization_versions (valid_from) WHERE applied_at IS NULL;
+
+ALTER TABLE organization_versions DROP COLUMN IF EXISTS error;
+ALTER TABLE organization_versions DROP COLUMN IF EXISTS failed_at;
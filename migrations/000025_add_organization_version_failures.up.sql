@@ -0,0 +1,7 @@
+-- A scheduled version that could not be applied keeps its error and is not retried until it is
+-- scheduled again
+ALTER TABLE organization_versions ADD COLUMN IF NOT EXISTS failed_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE organization_versions ADD COLUMN IF NOT EXISTS error TEXT;
+
+DROP INDEX IF EXISTS idx_organization_versions_pending;
+CREATE INDEX IF NOT EXISTS idx_organization_versions_pending ON organization_versions (valid_from) WHERE applied_at IS NULL AND failed_at IS NULL;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_change_events_occurred_at;
+
+DROP TABLE IF EXISTS change_event_retention;
@@ -0,0 +1,10 @@
+-- Events older than the retention are deleted, the last deleted event is kept so that cursors before it
+-- can be told apart from cursors that missed nothing
+CREATE TABLE IF NOT EXISTS change_event_retention (
+    id BOOLEAN PRIMARY KEY DEFAULT true CHECK (id),
+    txid XID8 NOT NULL,
+    event_id BIGINT NOT NULL,
+    purged_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_change_events_occurred_at ON change_events (occurred_at);
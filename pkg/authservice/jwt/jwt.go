@@ -22,12 +22,14 @@ type JwtAuth struct {
 	config config.Config
 }
 
-func (s *JwtAuth) GenerateToken(user entities.User, store entities.Organization) (string, error) {
+// GenerateToken signs a token for user, organization is the active organization context of the session
+func (s *JwtAuth) GenerateToken(user entities.User, organization entities.Organization) (string, error) {
 	claim := jwt.MapClaims{}
 	claim["user_id"] = user.UUID
 	claim["username"] = user.Username
 	claim["roles"] = user.Roles
 	claim["organization_id"] = user.OrganizationUUID
+	claim["active_organization_id"] = organization.UUID
 	claim["exp"] = time.Now().Add(time.Hour * 24).Unix()
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claim)
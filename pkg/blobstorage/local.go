@@ -0,0 +1,102 @@
+package blobstorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalMountPath is where the server serves the files of local storage
+const LocalMountPath = "/storage"
+
+// NewLocalStorage keeps files under root, their URLs start with publicURL or with LocalMountPath of the
+// same host when it is empty
+func NewLocalStorage(root string, publicURL string) *LocalStorage {
+	if root == "" {
+		root = "./storage"
+	}
+	if publicURL == "" {
+		publicURL = LocalMountPath
+	}
+
+	return &LocalStorage{
+		root:      root,
+		publicURL: strings.TrimRight(publicURL, "/"),
+	}
+}
+
+// LocalStorage keeps objects on the filesystem, the root directory is served as static files
+type LocalStorage struct {
+	root      string
+	publicURL string
+}
+
+func (s *LocalStorage) Root() string {
+	return s.root
+}
+
+func (s *LocalStorage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	// write to a temporary file first so readers never see a partial object
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+func (s *LocalStorage) Get(ctx context.Context, key string) ([]byte, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func (s *LocalStorage) URL(key string) string {
+	return s.publicURL + "/" + key
+}
+
+func (s *LocalStorage) path(key string) (string, error) {
+	cleaned := filepath.Clean("/" + key)
+	if cleaned == "/" || strings.Contains(key, "..") {
+		return "", fmt.Errorf("invalid key: %s", key)
+	}
+
+	return filepath.Join(s.root, cleaned), nil
+}
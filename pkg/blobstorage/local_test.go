@@ -0,0 +1,15 @@
+package blobstorage
+
+import "testing"
+
+func TestLocalStorage_URL(t *testing.T) {
+	storage := NewLocalStorage(t.TempDir(), "")
+	if url := storage.URL("avatars/a.webp"); url != "/storage/avatars/a.webp" {
+		t.Errorf("expected the mount path by default, got %q", url)
+	}
+
+	storage = NewLocalStorage(t.TempDir(), "https://cdn.example.com/files/")
+	if url := storage.URL("avatars/a.webp"); url != "https://cdn.example.com/files/avatars/a.webp" {
+		t.Errorf("unexpected url %q", url)
+	}
+}
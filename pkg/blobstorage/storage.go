@@ -0,0 +1,35 @@
+package blobstorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/laksanagusta/identity/config"
+)
+
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+var ErrNotFound = errors.New("blob not found")
+
+// Storage stores small binary objects (avatars, exports) addressed by a slash separated key
+type Storage interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+}
+
+func New(cfg config.StorageConfig) (Storage, error) {
+	switch cfg.Driver {
+	case DriverLocal, "":
+		return NewLocalStorage(cfg.LocalPath, cfg.PublicURL), nil
+	case DriverS3:
+		return NewS3Storage(cfg.S3, cfg.PublicURL), nil
+	}
+
+	return nil, fmt.Errorf("unsupported storage driver: %s", cfg.Driver)
+}
@@ -0,0 +1,77 @@
+// Package bulkexport writes lists of any size as CSV, XLSX or NDJSON while they are read from the database
+package bulkexport
+
+import (
+	"bufio"
+	"encoding/json"
+	"iter"
+
+	"github.com/laksanagusta/identity/pkg/spreadsheet"
+)
+
+const (
+	FormatCSV    = spreadsheet.FormatCSV
+	FormatXLSX   = spreadsheet.FormatXLSX
+	FormatNDJSON = "ndjson"
+)
+
+// Formats are the accepted values of a format parameter, usable with validation.In
+var Formats = []interface{}{FormatCSV, FormatXLSX, FormatNDJSON}
+
+// Table turns items into rows below a header for the spreadsheet formats and into JSON objects, one per
+// line, for NDJSON
+type Table[T any] struct {
+	Columns []string
+	Row     func(item T) []string
+	Record  func(item T) any
+}
+
+// ContentType returns the media type of a format
+func ContentType(format string) string {
+	switch format {
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+// Write writes every item in format, w sends the rows on whenever its buffer fills up. It stops at the
+// first error of items, what was written before stays written.
+func Write[T any](w *bufio.Writer, format string, table Table[T], items iter.Seq2[T, error]) error {
+	if format == FormatNDJSON {
+		encoder := json.NewEncoder(w)
+		for item, err := range items {
+			if err != nil {
+				return err
+			}
+			if err := encoder.Encode(table.Record(item)); err != nil {
+				return err
+			}
+		}
+		return w.Flush()
+	}
+
+	writer, err := spreadsheet.NewWriter(format, w)
+	if err != nil {
+		return err
+	}
+	if err := writer.Write(table.Columns); err != nil {
+		return err
+	}
+	for item, err := range items {
+		if err != nil {
+			return err
+		}
+		if err := writer.Write(table.Row(item)); err != nil {
+			return err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,75 @@
+package bulkexport
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"iter"
+	"reflect"
+	"testing"
+
+	"github.com/laksanagusta/identity/pkg/spreadsheet"
+)
+
+type item struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+var table = Table[item]{
+	Columns: []string{"code", "name"},
+	Row:     func(i item) []string { return []string{i.Code, i.Name} },
+	Record:  func(i item) any { return i },
+}
+
+func items(values []item, err error) iter.Seq2[item, error] {
+	return func(yield func(item, error) bool) {
+		for _, value := range values {
+			if !yield(value, nil) {
+				return
+			}
+		}
+		if err != nil {
+			yield(item{}, err)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	values := []item{{"HQ", "Head office"}, {"BR", "Branch, east"}}
+
+	for _, format := range []string{FormatCSV, FormatXLSX} {
+		buf := new(bytes.Buffer)
+		if err := Write(bufio.NewWriter(buf), format, table, items(values, nil)); err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+
+		rows, err := spreadsheet.Read(format, buf.Bytes())
+		if err != nil {
+			t.Fatalf("%s: read: %v", format, err)
+		}
+		expected := [][]string{{"code", "name"}, {"HQ", "Head office"}, {"BR", "Branch, east"}}
+		if !reflect.DeepEqual(rows, expected) {
+			t.Errorf("%s: expected %q, got %q", format, expected, rows)
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := Write(bufio.NewWriter(buf), FormatNDJSON, table, items(values, nil)); err != nil {
+		t.Fatal(err)
+	}
+	expected := "{\"code\":\"HQ\",\"name\":\"Head office\"}\n{\"code\":\"BR\",\"name\":\"Branch, east\"}\n"
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestWrite_StopsAtError(t *testing.T) {
+	failure := errors.New("connection lost")
+
+	buf := new(bytes.Buffer)
+	err := Write(bufio.NewWriter(buf), FormatNDJSON, table, items([]item{{"HQ", "Head office"}}, failure))
+	if !errors.Is(err, failure) {
+		t.Errorf("expected %v, got %v", failure, err)
+	}
+}
@@ -0,0 +1,39 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/laksanagusta/identity/config"
+
+	"github.com/lib/pq"
+)
+
+// listenerPingInterval checks idle listener connections, a dead one is only noticed when used
+const listenerPingInterval = 90 * time.Second
+
+// Listen calls notify on every notification of channel until ctx is done. It runs on its own connection,
+// which is reopened when lost, and also calls notify after a reconnect as notifications sent meanwhile
+// are gone.
+func Listen(ctx context.Context, config config.Config, channel string, notify func()) error {
+	listener := pq.NewListener(PostgresDSN(config), time.Second, time.Minute, nil)
+	defer listener.Close()
+
+	if err := listener.Listen(channel); err != nil {
+		return err
+	}
+
+	ping := time.NewTicker(listenerPingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-listener.Notify:
+			notify()
+		case <-ping.C:
+			go listener.Ping()
+		}
+	}
+}
@@ -10,14 +10,7 @@ import (
 )
 
 func GetPostgreConnection(config config.Config) (*sqlx.DB, error) {
-	dbHost := config.Postgres.Host
-	dbPort := config.Postgres.Port
-	dbUser := config.Postgres.User
-	dbPassword := config.Postgres.Password
-	dbName := config.Postgres.DBName
-
-	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable", dbHost, dbPort, dbUser, dbPassword, dbName)
-	db, err := sqlx.Open("postgres", dsn)
+	db, err := sqlx.Open("postgres", PostgresDSN(config))
 	if err != nil {
 		return nil, err
 	}
@@ -31,3 +24,13 @@ func GetPostgreConnection(config config.Config) (*sqlx.DB, error) {
 
 	return db, nil
 }
+
+func PostgresDSN(config config.Config) string {
+	dbHost := config.Postgres.Host
+	dbPort := config.Postgres.Port
+	dbUser := config.Postgres.User
+	dbPassword := config.Postgres.Password
+	dbName := config.Postgres.DBName
+
+	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable", dbHost, dbPort, dbUser, dbPassword, dbName)
+}
@@ -50,6 +50,26 @@ func BadRequestMap[T any](errMap map[string][]T) error {
 	}
 }
 
+// PrefixMap prefixes the keys of a bad request map error, other errors are returned as they are
+func PrefixMap(err error, prefix string) error {
+	var appErr *AppError
+	if !errors.As(err, &appErr) || !errors.Is(appErr.Err, ErrBadRequest) {
+		return err
+	}
+
+	errMap, ok := appErr.errMap.(map[string][]string)
+	if !ok {
+		return err
+	}
+
+	prefixed := make(map[string][]string, len(errMap))
+	for key, messages := range errMap {
+		prefixed[prefix+key] = messages
+	}
+
+	return BadRequestMap(prefixed)
+}
+
 func Unauthorized() error {
 	return &AppError{
 		Message: "unauthorized",
@@ -197,6 +197,23 @@ func HttpHandleError(c *fiber.Ctx, err error) error {
 	})
 }
 
+// FieldErrors returns the messages per field of a validation error or a bad request map error, ok is
+// false for any other error
+func FieldErrors(err error) (map[string][]string, bool) {
+	var validatorError validation.Errors
+	if errors.As(err, &validatorError) {
+		return validationErrorMapping(validatorError), true
+	}
+
+	var appErr *AppError
+	if errors.As(err, &appErr) && errors.Is(appErr.Err, ErrBadRequest) {
+		errMap, ok := appErr.errMap.(map[string][]string)
+		return errMap, ok
+	}
+
+	return nil, false
+}
+
 func validationErrorMapping(validatorError validation.Errors) map[string][]string {
 	mapErr := make(map[string][]string)
 	for key, err := range validatorError {
@@ -0,0 +1,58 @@
+package helper
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInitials(t *testing.T) {
+	tests := []struct {
+		firstName string
+		lastName  string
+		expected  string
+	}{
+		{"budi", "santoso", "BS"},
+		{"Siti", "", "SI"},
+		{"Muhammad Rizki", "Pratama", "MP"},
+		{"  ", "", "?"},
+		{"élodie", "", "ÉL"},
+		{"J", "", "J"},
+	}
+
+	for _, tt := range tests {
+		if got := Initials(tt.firstName, tt.lastName); got != tt.expected {
+			t.Errorf("Initials(%q, %q) = %q, expected %q", tt.firstName, tt.lastName, got, tt.expected)
+		}
+	}
+}
+
+func TestRenderAvatarSVG(t *testing.T) {
+	svg := RenderAvatarSVG(AvatarSVGOptions{
+		Initials:      "<B>",
+		GradientStart: "#FFA500",
+		GradientEnd:   "#FF4500",
+		Size:          64,
+		Shape:         AvatarShapeSquare,
+	})
+
+	for _, expected := range []string{`width="64"`, `stop-color="#FFA500"`, `stop-color="#FF4500"`, `<rect width="64"`, "&lt;B&gt;"} {
+		if !strings.Contains(svg, expected) {
+			t.Errorf("expected svg to contain %s, got %s", expected, svg)
+		}
+	}
+
+	fallback := RenderAvatarSVG(AvatarSVGOptions{
+		Initials:      "AB",
+		GradientStart: `red"/><script>`,
+		Size:          5000,
+		Seed:          "user-1",
+	})
+
+	start, _ := GenerateGradientFromSeed("user-1")
+	if !strings.Contains(fallback, `stop-color="`+start+`"`) || strings.Contains(fallback, "<script>") {
+		t.Errorf("expected invalid colors to fall back to the seeded palette, got %s", fallback)
+	}
+	if !strings.Contains(fallback, `<circle cx="64"`) {
+		t.Errorf("expected default size and circle shape, got %s", fallback)
+	}
+}
@@ -0,0 +1,62 @@
+package helper
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+const (
+	HighlightOpen  = "<mark>"
+	HighlightClose = "</mark>"
+)
+
+// Highlight wraps the case-insensitive occurrences of tokens in text with <mark>, the rest is
+// HTML escaped. It returns false when no token occurs in text.
+func Highlight(text string, tokens []string) (string, bool) {
+	runes := []rune(text)
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	marked := make([]bool, len(runes))
+	found := false
+	for _, token := range tokens {
+		needle := []rune(strings.ToLower(token))
+		if len(needle) == 0 {
+			continue
+		}
+
+		for i := 0; i+len(needle) <= len(lower); i++ {
+			if string(lower[i:i+len(needle)]) == string(needle) {
+				for j := i; j < i+len(needle); j++ {
+					marked[j] = true
+				}
+				found = true
+			}
+		}
+	}
+
+	if !found {
+		return html.EscapeString(text), false
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(runes); {
+		j := i
+		for j < len(runes) && marked[j] == marked[i] {
+			j++
+		}
+
+		segment := html.EscapeString(string(runes[i:j]))
+		if marked[i] {
+			b.WriteString(HighlightOpen + segment + HighlightClose)
+		} else {
+			b.WriteString(segment)
+		}
+		i = j
+	}
+
+	return b.String(), true
+}
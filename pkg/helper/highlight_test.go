@@ -0,0 +1,25 @@
+package helper
+
+import "testing"
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		text   string
+		tokens []string
+		want   string
+		found  bool
+	}{
+		{"Jonathan", []string{"jon"}, "<mark>Jon</mark>athan", true},
+		{"John Doe", []string{"jo", "do"}, "<mark>Jo</mark>hn <mark>Do</mark>e", true},
+		{"banana", []string{"an"}, "b<mark>anan</mark>a", true},
+		{"<b>Budi</b>", []string{"budi"}, "&lt;b&gt;<mark>Budi</mark>&lt;/b&gt;", true},
+		{"Siti", []string{"budi"}, "Siti", false},
+	}
+
+	for _, tt := range tests {
+		got, found := Highlight(tt.text, tt.tokens)
+		if got != tt.want || found != tt.found {
+			t.Errorf("Highlight(%q, %v) = %q, %v, want %q, %v", tt.text, tt.tokens, got, found, tt.want, tt.found)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package helper
+
+import (
+	"regexp"
+	"strings"
+)
+
+const defaultCountryCode = "62"
+
+var phoneNumberRegex = regexp.MustCompile(`^\+?[0-9][0-9 ().-]{6,}$`)
+
+// NormalizeEmail trims and lowercases, it returns false when s is not shaped like an email
+func NormalizeEmail(s string) (string, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	at := strings.LastIndex(s, "@")
+	if at < 1 || at == len(s)-1 || strings.ContainsAny(s, " \t") {
+		return "", false
+	}
+
+	return s, true
+}
+
+// EmailDomain returns the part after the last @, empty when there is none
+func EmailDomain(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return ""
+	}
+
+	return email[at+1:]
+}
+
+// PhoneNumberCandidates returns the forms a phone number may be stored in, local (08...) first
+// and then international without the plus (628...). It returns nil when s is not shaped like a phone number.
+func PhoneNumberCandidates(s string) []string {
+	s = strings.TrimSpace(s)
+	if !phoneNumberRegex.MatchString(s) {
+		return nil
+	}
+
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, s)
+
+	var subscriber string
+	switch {
+	case strings.HasPrefix(digits, "0"):
+		subscriber = digits[1:]
+	case strings.HasPrefix(digits, defaultCountryCode):
+		subscriber = digits[len(defaultCountryCode):]
+	default:
+		return []string{digits}
+	}
+
+	if subscriber == "" {
+		return nil
+	}
+
+	return []string{"0" + subscriber, defaultCountryCode + subscriber}
+}
@@ -0,0 +1,47 @@
+package helper
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"  Budi.Santoso@Example.COM ", "budi.santoso@example.com", true},
+		{"budi", "", false},
+		{"@example.com", "", false},
+		{"budi@", "", false},
+		{"budi santoso@example.com", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := NormalizeEmail(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("NormalizeEmail(%q) = %q, %v, want %q, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestPhoneNumberCandidates(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"0812-3456-7890", []string{"081234567890", "6281234567890"}},
+		{"+62 812 3456 7890", []string{"081234567890", "6281234567890"}},
+		{"6281234567890", []string{"081234567890", "6281234567890"}},
+		{"1987654321", []string{"1987654321"}},
+		{"budi", nil},
+		{"12345", nil},
+	}
+
+	for _, tt := range tests {
+		if got := PhoneNumberCandidates(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("PhoneNumberCandidates(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+// halves returns a w x h image whose left half is red and right half is blue
+func halves(w, h int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := color.RGBA{R: 255, A: 255}
+			if x >= w/2 {
+				c = color.RGBA{B: 255, A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+// withOrientation inserts an EXIF APP1 segment carrying the orientation tag right after SOI
+func withOrientation(jpegData []byte, orientation uint16) []byte {
+	tiff := new(bytes.Buffer)
+	tiff.WriteString("MM")
+	binary.Write(tiff, binary.BigEndian, uint16(42))
+	binary.Write(tiff, binary.BigEndian, uint32(8))
+	binary.Write(tiff, binary.BigEndian, uint16(1))
+	binary.Write(tiff, binary.BigEndian, uint16(0x0112))
+	binary.Write(tiff, binary.BigEndian, uint16(3))
+	binary.Write(tiff, binary.BigEndian, uint32(1))
+	binary.Write(tiff, binary.BigEndian, orientation)
+	binary.Write(tiff, binary.BigEndian, uint16(0))
+	binary.Write(tiff, binary.BigEndian, uint32(0))
+
+	payload := append([]byte("Exif\x00\x00"), tiff.Bytes()...)
+
+	out := new(bytes.Buffer)
+	out.Write(jpegData[:2])
+	out.Write([]byte{0xFF, 0xE1})
+	binary.Write(out, binary.BigEndian, uint16(len(payload)+2))
+	out.Write(payload)
+	out.Write(jpegData[2:])
+	return out.Bytes()
+}
+
+func isRed(c color.Color) bool {
+	r, _, b, _ := c.RGBA()
+	return r > 0xC000 && b < 0x4000
+}
+
+func isBlue(c color.Color) bool {
+	r, _, b, _ := c.RGBA()
+	return b > 0xC000 && r < 0x4000
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		expected string
+		err      error
+	}{
+		{"jpeg", []byte{0xFF, 0xD8, 0xFF, 0xE0}, FormatJPEG, nil},
+		{"png", []byte("\x89PNG\r\n\x1a\n...."), FormatPNG, nil},
+		{"webp", []byte("RIFF\x00\x00\x00\x00WEBPVP8 "), FormatWebP, nil},
+		{"gif", []byte("GIF89a......"), "", ErrUnsupportedFormat},
+		{"empty", nil, "", ErrUnsupportedFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, err := DetectFormat(tt.data)
+			if err != tt.err || format != tt.expected {
+				t.Errorf("expected (%q, %v), got (%q, %v)", tt.expected, tt.err, format, err)
+			}
+		})
+	}
+}
+
+func TestDecode_AppliesExifOrientation(t *testing.T) {
+	buf := new(bytes.Buffer)
+	if err := jpeg.Encode(buf, halves(40, 20), &jpeg.Options{Quality: 100}); err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := Decode(withOrientation(buf.Bytes(), 6))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	if img.Bounds().Dx() != 20 || img.Bounds().Dy() != 40 {
+		t.Fatalf("expected 20x40 after rotation, got %v", img.Bounds())
+	}
+
+	// rotating 90 degrees clockwise moves the red left half to the top
+	if !isRed(img.At(10, 2)) || !isBlue(img.At(10, 37)) {
+		t.Errorf("unexpected orientation: top %v bottom %v", img.At(10, 2), img.At(10, 37))
+	}
+}
+
+func TestDecode_RejectsOversizedImages(t *testing.T) {
+	buf := new(bytes.Buffer)
+	if err := png.Encode(buf, image.NewGray(image.Rect(0, 0, MaxDimension+1, 1))); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Decode(buf.Bytes()); err != ErrTooLarge {
+		t.Errorf("expected ErrTooLarge, got %v", err)
+	}
+}
+
+func TestSquareJPEG_StripsMetadata(t *testing.T) {
+	buf := new(bytes.Buffer)
+	if err := jpeg.Encode(buf, halves(60, 30), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := Decode(withOrientation(buf.Bytes(), 1))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	out, err := SquareJPEG(img, 16)
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+
+	if bytes.Contains(out, []byte("Exif")) {
+		t.Errorf("expected EXIF to be stripped")
+	}
+
+	cfg, err := jpeg.DecodeConfig(bytes.NewReader(out))
+	if err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	if cfg.Width != 16 || cfg.Height != 16 {
+		t.Errorf("expected 16x16, got %dx%d", cfg.Width, cfg.Height)
+	}
+}
@@ -2,9 +2,17 @@ package pagination
 
 import (
 	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 )
 
+var (
+	jsonKeyRegex     = regexp.MustCompile(`^[a-z][a-z0-9_]{0,62}$`)
+	searchTokenRegex = regexp.MustCompile(`[\p{L}\p{N}]+`)
+)
+
 type QueryParams struct {
 	Filters    []Filter
 	Sorts      []Sort
@@ -42,6 +50,8 @@ type QueryBuilder struct {
 	orderClause []string
 	args        []interface{}
 	argCounter  int
+	jsonFields  map[string]bool
+	fields      map[string]bool
 }
 
 func NewQueryBuilder(baseQuery string) *QueryBuilder {
@@ -51,15 +61,52 @@ func NewQueryBuilder(baseQuery string) *QueryBuilder {
 	}
 }
 
+// AllowJSONField enables filtering and sorting on keys of a JSONB column using
+// "<column>.<key>", e.g. "attributes.job_grade" becomes attributes->>'job_grade'
+func (qb *QueryBuilder) AllowJSONField(column string) *QueryBuilder {
+	if qb.jsonFields == nil {
+		qb.jsonFields = map[string]bool{}
+	}
+	qb.jsonFields[column] = true
+	return qb
+}
+
+// ContinueArgs numbers the placeholders after args of an earlier query, so that the built queries can
+// be combined into one statement. Build then returns args followed by the new ones.
+func (qb *QueryBuilder) ContinueArgs(args []interface{}) *QueryBuilder {
+	qb.args = append(slices.Clip(args), qb.args...)
+	qb.argCounter += len(args)
+	return qb
+}
+
+// AllowFields extends the default field whitelist with columns of the queried table
+func (qb *QueryBuilder) AllowFields(fields ...string) *QueryBuilder {
+	if qb.fields == nil {
+		qb.fields = map[string]bool{}
+	}
+	for _, field := range fields {
+		qb.fields[field] = true
+	}
+	return qb
+}
+
 func (qb *QueryBuilder) AddFilter(filter Filter) error {
 	operator := qb.mapOperator(filter.Operator)
 	if operator == "" {
 		return fmt.Errorf("unsupported operator: %s", filter.Operator)
 	}
 
-	field := qb.sanitizeField(filter.Field)
-	if !qb.isValidField(field) {
-		return fmt.Errorf("invalid field: %s", field)
+	field, ok := qb.resolveField(filter.Field)
+	if !ok {
+		return fmt.Errorf("invalid field: %s", qb.sanitizeField(filter.Field))
+	}
+
+	// JSON values are compared as text, numeric comparisons need an explicit cast
+	if qb.isJSONField(field) && (operator == ">" || operator == ">=" || operator == "<" || operator == "<=") {
+		if number, err := strconv.ParseFloat(fmt.Sprintf("%v", filter.Value), 64); err == nil {
+			field = fmt.Sprintf("(%s)::numeric", field)
+			filter.Value = number
+		}
 	}
 
 	if operator == "IN" || operator == "NOT IN" {
@@ -88,9 +135,9 @@ func (qb *QueryBuilder) AddFilter(filter Filter) error {
 }
 
 func (qb *QueryBuilder) AddSort(sort Sort) error {
-	field := qb.sanitizeField(sort.Field)
-	if !qb.isValidField(field) {
-		return fmt.Errorf("invalid field: %s", field)
+	field, ok := qb.resolveField(sort.Field)
+	if !ok {
+		return fmt.Errorf("invalid field: %s", qb.sanitizeField(sort.Field))
 	}
 
 	order := strings.ToUpper(sort.Order)
@@ -127,6 +174,77 @@ func (qb *QueryBuilder) AddSearch(term string, fields []string) error {
 	return nil
 }
 
+// SearchTokens splits a search term into lowercase words, punctuation is dropped
+func SearchTokens(term string) []string {
+	return searchTokenRegex.FindAllString(strings.ToLower(term), -1)
+}
+
+// AddFuzzySearch matches every word of term against document, a SQL expression written by the
+// caller (never user input). A row matches when the words prefix words of the document (full-text),
+// or when each word is contained in or similar to the document (trigram), so "jon do" finds "John Doe".
+func (qb *QueryBuilder) AddFuzzySearch(term string, document string) {
+	tokens := SearchTokens(term)
+	if len(tokens) == 0 {
+		return
+	}
+
+	prefixQuery := qb.addArg(prefixTSQuery(tokens))
+
+	fuzzy := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		like := qb.addArg("%" + token + "%")
+		similar := qb.addArg(token)
+		fuzzy = append(fuzzy, fmt.Sprintf("(%s ILIKE $%d OR $%d <%% %s)", document, like, similar, document))
+	}
+
+	qb.whereClause = append(qb.whereClause, fmt.Sprintf(
+		"(to_tsvector('simple', %s) @@ to_tsquery('simple', $%d) OR (%s))",
+		document, prefixQuery, strings.Join(fuzzy, " AND "),
+	))
+}
+
+// AddRelevanceSort orders by how well document matches term, after any sort added before it
+func (qb *QueryBuilder) AddRelevanceSort(term string, document string) {
+	tokens := SearchTokens(term)
+	if len(tokens) == 0 {
+		return
+	}
+
+	prefixQuery := qb.addArg(prefixTSQuery(tokens))
+	words := qb.addArg(strings.Join(tokens, " "))
+
+	qb.orderClause = append(qb.orderClause, fmt.Sprintf(
+		"ts_rank(to_tsvector('simple', %s), to_tsquery('simple', $%d)) + word_similarity($%d, %s) DESC",
+		document, prefixQuery, words, document,
+	))
+}
+
+// AddRawCondition adds a condition written by the caller, it must never contain user input
+func (qb *QueryBuilder) AddRawCondition(condition string) *QueryBuilder {
+	qb.whereClause = append(qb.whereClause, condition)
+	return qb
+}
+
+// Placeholder binds value as the next argument and returns its placeholder, for use in raw conditions
+func (qb *QueryBuilder) Placeholder(value interface{}) string {
+	return fmt.Sprintf("$%d", qb.addArg(value))
+}
+
+func (qb *QueryBuilder) addArg(value interface{}) int {
+	qb.args = append(qb.args, value)
+	qb.argCounter++
+	return qb.argCounter - 1
+}
+
+// prefixTSQuery turns tokens into "jon:* & do:*", tokens only contain letters and digits
+func prefixTSQuery(tokens []string) string {
+	parts := make([]string, len(tokens))
+	for i, token := range tokens {
+		parts[i] = token + ":*"
+	}
+	return strings.Join(parts, " & ")
+}
+
 func (qb *QueryBuilder) AddPagination(pagination Pagination) {
 	// Pagination will be handled separately with LIMIT and OFFSET
 }
@@ -170,6 +288,25 @@ func (qb *QueryBuilder) sanitizeField(field string) string {
 	return field
 }
 
+// resolveField returns the SQL expression for a whitelisted column or an allowed JSON key
+func (qb *QueryBuilder) resolveField(field string) (string, bool) {
+	field = qb.sanitizeField(field)
+	if qb.isValidField(field) {
+		return field, true
+	}
+
+	column, key, found := strings.Cut(field, ".")
+	if !found || !qb.jsonFields[column] || !jsonKeyRegex.MatchString(key) {
+		return "", false
+	}
+
+	return fmt.Sprintf("%s->>'%s'", column, key), true
+}
+
+func (qb *QueryBuilder) isJSONField(field string) bool {
+	return strings.Contains(field, "->>")
+}
+
 func (qb *QueryBuilder) isValidField(field string) bool {
 	validFields := map[string]bool{
 		"name":        true,
@@ -180,5 +317,5 @@ func (qb *QueryBuilder) isValidField(field string) bool {
 		"first_name":  true,
 		"is_approved": true,
 	}
-	return validFields[field]
+	return validFields[field] || qb.fields[field]
 }
@@ -87,3 +87,175 @@ func TestQueryBuilder_AddSearch(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryBuilder_AllowJSONField(t *testing.T) {
+	tests := []struct {
+		name          string
+		allowJSON     bool
+		filter        Filter
+		sort          *Sort
+		expectedQuery string
+		expectedArgs  []interface{}
+		expectError   bool
+	}{
+		{
+			name:          "Equality filter on attribute",
+			allowJSON:     true,
+			filter:        Filter{Field: "attributes.shirt_size", Operator: "eq", Value: "M"},
+			expectedQuery: "SELECT * FROM users WHERE attributes->>'shirt_size' = $1",
+			expectedArgs:  []interface{}{"M"},
+		},
+		{
+			name:          "Numeric comparison casts the attribute",
+			allowJSON:     true,
+			filter:        Filter{Field: "attributes.job_grade", Operator: "gte", Value: "4"},
+			expectedQuery: "SELECT * FROM users WHERE (attributes->>'job_grade')::numeric >= $1",
+			expectedArgs:  []interface{}{float64(4)},
+		},
+		{
+			name:          "Sort by attribute",
+			allowJSON:     true,
+			filter:        Filter{Field: "username", Operator: "eq", Value: "john"},
+			sort:          &Sort{Field: "attributes.hire_date", Order: "desc"},
+			expectedQuery: "SELECT * FROM users WHERE username = $1 ORDER BY attributes->>'hire_date' DESC",
+			expectedArgs:  []interface{}{"john"},
+		},
+		{
+			name:        "Attribute filter is rejected when not allowed",
+			allowJSON:   false,
+			filter:      Filter{Field: "attributes.shirt_size", Operator: "eq", Value: "M"},
+			expectError: true,
+		},
+		{
+			name:        "Malformed attribute key is rejected",
+			allowJSON:   true,
+			filter:      Filter{Field: "attributes.x'; drop table users;--", Operator: "eq", Value: "M"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qb := NewQueryBuilder("SELECT * FROM users")
+			if tt.allowJSON {
+				qb.AllowJSONField("attributes")
+			}
+
+			err := qb.AddFilter(tt.filter)
+			if err == nil && tt.sort != nil {
+				err = qb.AddSort(*tt.sort)
+			}
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("expected error, got nil")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			query, args := qb.Build()
+
+			if query != tt.expectedQuery {
+				t.Errorf("expected query %q, got %q", tt.expectedQuery, query)
+			}
+
+			if len(args) != len(tt.expectedArgs) {
+				t.Fatalf("expected %d args, got %d", len(tt.expectedArgs), len(args))
+			}
+			for i, arg := range args {
+				if arg != tt.expectedArgs[i] {
+					t.Errorf("expected arg %v, got %v", tt.expectedArgs[i], arg)
+				}
+			}
+		})
+	}
+}
+
+func TestQueryBuilder_AddFuzzySearch(t *testing.T) {
+	qb := NewQueryBuilder("SELECT * FROM users")
+	if err := qb.AddFilter(Filter{Field: "is_approved", Operator: "eq", Value: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	qb.AddFuzzySearch("Jon, do!", "doc")
+	qb.AddRelevanceSort("Jon, do!", "doc")
+
+	query, args := qb.Build()
+
+	expectedQuery := "SELECT * FROM users WHERE is_approved = $1 AND " +
+		"(to_tsvector('simple', doc) @@ to_tsquery('simple', $2) OR " +
+		"((doc ILIKE $3 OR $4 <% doc) AND (doc ILIKE $5 OR $6 <% doc))) " +
+		"ORDER BY ts_rank(to_tsvector('simple', doc), to_tsquery('simple', $7)) + word_similarity($8, doc) DESC"
+	if query != expectedQuery {
+		t.Errorf("expected query %q, got %q", expectedQuery, query)
+	}
+
+	expectedArgs := []interface{}{true, "jon:* & do:*", "%jon%", "jon", "%do%", "do", "jon:* & do:*", "jon do"}
+	if len(args) != len(expectedArgs) {
+		t.Fatalf("expected %d args, got %d", len(expectedArgs), len(args))
+	}
+	for i := range args {
+		if args[i] != expectedArgs[i] {
+			t.Errorf("arg %d: expected %v, got %v", i, expectedArgs[i], args[i])
+		}
+	}
+}
+
+func TestQueryBuilder_AddFuzzySearchWithoutWords(t *testing.T) {
+	qb := NewQueryBuilder("SELECT * FROM users")
+	qb.AddFuzzySearch(" %_ ", "doc")
+	qb.AddRelevanceSort(" %_ ", "doc")
+
+	query, args := qb.Build()
+	if query != "SELECT * FROM users" || len(args) != 0 {
+		t.Errorf("expected no search clause, got %q %v", query, args)
+	}
+}
+
+func TestQueryBuilder_AllowFields(t *testing.T) {
+	qb := NewQueryBuilder("SELECT 1 FROM users u")
+	if err := qb.AddFilter(Filter{Field: "is_active", Operator: "eq", Value: true}); err == nil {
+		t.Fatalf("expected error for a field outside the whitelist")
+	}
+
+	qb.AllowFields("is_active")
+	if err := qb.AddFilter(Filter{Field: "is_active", Operator: "eq", Value: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	qb.AddRawCondition("EXISTS (SELECT 1 FROM user_roles ur WHERE ur.user_uuid = u.uuid AND ur.role_uuid = " + qb.Placeholder("role") + ")")
+
+	query, args := qb.Build()
+
+	expectedQuery := "SELECT 1 FROM users u WHERE is_active = $1 AND EXISTS (SELECT 1 FROM user_roles ur WHERE ur.user_uuid = u.uuid AND ur.role_uuid = $2)"
+	if query != expectedQuery {
+		t.Errorf("expected query %q, got %q", expectedQuery, query)
+	}
+	if len(args) != 2 || args[0] != true || args[1] != "role" {
+		t.Errorf("unexpected args %v", args)
+	}
+}
+
+func TestQueryBuilder_ContinueArgs(t *testing.T) {
+	first := NewQueryBuilder("SELECT 1 FROM users")
+	first.AddRawCondition("uuid = " + first.Placeholder("a"))
+	firstQuery, firstArgs := first.Build()
+
+	second := NewQueryBuilder("SELECT 1 FROM users").ContinueArgs(firstArgs)
+	if err := second.AddFilter(Filter{Field: "username", Operator: "eq", Value: "b"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	secondQuery, args := second.Build()
+
+	if firstQuery != "SELECT 1 FROM users WHERE uuid = $1" || secondQuery != "SELECT 1 FROM users WHERE username = $2" {
+		t.Errorf("unexpected queries %q and %q", firstQuery, secondQuery)
+	}
+	if len(args) != 2 || args[0] != "a" || args[1] != "b" {
+		t.Errorf("unexpected args %v", args)
+	}
+	if len(firstArgs) != 1 {
+		t.Errorf("expected the earlier args to be left alone, got %v", firstArgs)
+	}
+}
@@ -0,0 +1,282 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+
+	// MaxRows guards against workbooks far larger than an import is meant for
+	MaxRows = 10000
+	// MaxColumns is the number of columns a sheet can have, the last one is XFD
+	MaxColumns = 16384
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported spreadsheet format")
+	ErrTooManyRows       = errors.New("spreadsheet has too many rows")
+	ErrNoSheet           = errors.New("workbook has no sheet")
+	ErrInvalidReference  = errors.New("invalid cell reference")
+)
+
+// FormatOf returns the format of a file by its extension
+func FormatOf(fileName string) (string, error) {
+	switch strings.ToLower(path.Ext(fileName)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".xlsx":
+		return FormatXLSX, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+// Read returns the rows of a CSV file or of the first sheet of an XLSX workbook, cells trimmed.
+// Rows of a sheet are padded up to their last filled cell, empty rows are dropped.
+func Read(format string, data []byte) ([][]string, error) {
+	var rows [][]string
+	var err error
+	switch format {
+	case FormatCSV:
+		rows, err = readCSV(data)
+	case FormatXLSX:
+		rows, err = readXLSX(data)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	filled := rows[:0]
+	for _, row := range rows {
+		for i := range row {
+			row[i] = strings.TrimSpace(row[i])
+		}
+		if strings.Join(row, "") != "" {
+			filled = append(filled, row)
+		}
+	}
+	if len(filled) > MaxRows+1 {
+		return nil, ErrTooManyRows
+	}
+
+	return filled, nil
+}
+
+func readCSV(data []byte) ([][]string, error) {
+	// Spreadsheet applications save CSV with a byte order mark
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	return reader.ReadAll()
+}
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		RelationID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// xlsxText is a shared string or an inline string, rich text comes in runs
+type xlsxText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.Text
+	}
+
+	var b strings.Builder
+	for _, run := range t.Runs {
+		b.WriteString(run.Text)
+	}
+	return b.String()
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxText `xml:"si"`
+}
+
+type xlsxSheet struct {
+	Rows []struct {
+		Cells []struct {
+			Reference string   `xml:"r,attr"`
+			Type      string   `xml:"t,attr"`
+			Value     string   `xml:"v"`
+			Inline    xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// readXLSX reads the first sheet of the workbook. Formulas give their cached value, dates stay the
+// serial numbers they are stored as.
+func readXLSX(data []byte) ([][]string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	sheetName, err := firstSheetName(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var sharedStrings xlsxSharedStrings
+	if file, ok := files["xl/sharedStrings.xml"]; ok {
+		err = decodeXML(file, &sharedStrings)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sheetFile, ok := files[sheetName]
+	if !ok {
+		return nil, ErrNoSheet
+	}
+
+	var sheet xlsxSheet
+	err = decodeXML(sheetFile, &sheet)
+	if err != nil {
+		return nil, err
+	}
+	if len(sheet.Rows) > MaxRows+1 {
+		return nil, ErrTooManyRows
+	}
+
+	rows := make([][]string, 0, len(sheet.Rows))
+	for _, sheetRow := range sheet.Rows {
+		var row []string
+		for i, cell := range sheetRow.Cells {
+			column := i
+			if cell.Reference != "" {
+				column, err = columnIndex(cell.Reference)
+				if err != nil {
+					return nil, err
+				}
+			}
+			for len(row) <= column {
+				row = append(row, "")
+			}
+
+			switch {
+			case cell.Type == "inlineStr":
+				row[column] = cell.Inline.String()
+			case cell.Value == "":
+				// Styled cells without a value are written out too
+			case cell.Type == "s":
+				index, err := strconv.Atoi(cell.Value)
+				if err != nil || index < 0 || index >= len(sharedStrings.Items) {
+					return nil, errors.New("invalid shared string reference " + cell.Value)
+				}
+				row[column] = sharedStrings.Items[index].String()
+			case cell.Type == "b":
+				row[column] = strconv.FormatBool(cell.Value == "1")
+			default:
+				row[column] = cell.Value
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// firstSheetName resolves the part of the first sheet through the workbook relationships
+func firstSheetName(files map[string]*zip.File) (string, error) {
+	workbookFile, ok := files["xl/workbook.xml"]
+	if !ok {
+		return "", ErrUnsupportedFormat
+	}
+
+	var workbook xlsxWorkbook
+	err := decodeXML(workbookFile, &workbook)
+	if err != nil {
+		return "", err
+	}
+	if len(workbook.Sheets) == 0 {
+		return "", ErrNoSheet
+	}
+
+	relationshipsFile, ok := files["xl/_rels/workbook.xml.rels"]
+	if !ok {
+		return "xl/worksheets/sheet1.xml", nil
+	}
+
+	var relationships xlsxRelationships
+	err = decodeXML(relationshipsFile, &relationships)
+	if err != nil {
+		return "", err
+	}
+
+	for _, relationship := range relationships.Relationships {
+		if relationship.ID != workbook.Sheets[0].RelationID {
+			continue
+		}
+		if strings.HasPrefix(relationship.Target, "/") {
+			return strings.TrimPrefix(relationship.Target, "/"), nil
+		}
+		return path.Join("xl", relationship.Target), nil
+	}
+
+	return "", ErrNoSheet
+}
+
+func decodeXML(file *zip.File, v any) error {
+	reader, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	return xml.NewDecoder(io.LimitReader(reader, maxPartSize)).Decode(v)
+}
+
+// maxPartSize bounds how much of a single workbook part is decompressed
+const maxPartSize = 64 << 20
+
+// columnIndex returns the zero based column of a cell reference like "AB12", references without a
+// column or past the last column are invalid
+func columnIndex(reference string) (int, error) {
+	column := 0
+	for _, r := range reference {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		column = column*26 + int(r-'A'+1)
+		if column > MaxColumns {
+			return 0, ErrInvalidReference
+		}
+	}
+	if column == 0 {
+		return 0, ErrInvalidReference
+	}
+	return column - 1, nil
+}
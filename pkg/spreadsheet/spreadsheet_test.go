@@ -0,0 +1,217 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+// workbook zips the given parts into a minimal XLSX file
+func workbook(t *testing.T, parts map[string]string) []byte {
+	t.Helper()
+
+	buf := new(bytes.Buffer)
+	writer := zip.NewWriter(buf)
+	for name, content := range parts {
+		part, err := writer.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := part.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+const (
+	testWorkbook = `<?xml version="1.0" encoding="UTF-8"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="Users" sheetId="1" r:id="rId3"/><sheet name="Other" sheetId="2" r:id="rId4"/></sheets>
+</workbook>`
+	testRelationships = `<?xml version="1.0" encoding="UTF-8"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId4" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet2.xml"/>
+<Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/users.xml"/>
+</Relationships>`
+	testSharedStrings = `<?xml version="1.0" encoding="UTF-8"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<si><t>username</t></si><si><t>phone_number</t></si><si><r><t>jo</t></r><r><t>hn</t></r></si>
+</sst>`
+	testSheet = `<?xml version="1.0" encoding="UTF-8"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c></row>
+<row r="2"><c r="A2" t="s"><v>2</v></c><c r="B2"><v>81234567890</v></c><c r="D2" t="inlineStr"><is><t> admin </t></is></c></row>
+<row r="3"><c r="A3" t="s"/></row>
+<row r="4"><c r="C4" t="b"><v>1</v></c></row>
+</sheetData></worksheet>`
+)
+
+func TestRead_XLSXFirstSheet(t *testing.T) {
+	data := workbook(t, map[string]string{
+		"xl/workbook.xml":            testWorkbook,
+		"xl/_rels/workbook.xml.rels": testRelationships,
+		"xl/sharedStrings.xml":       testSharedStrings,
+		"xl/worksheets/users.xml":    testSheet,
+		"xl/worksheets/sheet2.xml":   `<worksheet><sheetData/></worksheet>`,
+	})
+
+	rows, err := Read(FormatXLSX, data)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+
+	expected := [][]string{
+		{"username", "phone_number"},
+		{"john", "81234567890", "", "admin"},
+		{"", "", "true"},
+	}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("expected %q, got %q", expected, rows)
+	}
+}
+
+func TestRead_CSV(t *testing.T) {
+	data := []byte("\xef\xbb\xbfusername,roles\n john ,admin;staff\n,\nmary\n")
+
+	rows, err := Read(FormatCSV, data)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+
+	expected := [][]string{
+		{"username", "roles"},
+		{"john", "admin;staff"},
+		{"mary"},
+	}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("expected %q, got %q", expected, rows)
+	}
+}
+
+func TestRead_RejectsOtherFiles(t *testing.T) {
+	if _, err := Read(FormatXLSX, []byte("username\njohn\n")); err == nil {
+		t.Error("expected an error for a file that is not a workbook")
+	}
+
+	if _, err := Read(FormatXLSX, workbook(t, map[string]string{"word/document.xml": "<document/>"})); err != ErrUnsupportedFormat {
+		t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+	}
+
+	if _, err := FormatOf("users.xls"); err != ErrUnsupportedFormat {
+		t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+	}
+}
+
+func TestColumnIndex(t *testing.T) {
+	cases := map[string]int{"A1": 0, "C7": 2, "Z10": 25, "AA1": 26, "AB12": 27}
+	for reference, expected := range cases {
+		if column, err := columnIndex(reference); err != nil || column != expected {
+			t.Errorf("%s: expected %d, got %d %v", reference, expected, column, err)
+		}
+	}
+
+	if column, err := columnIndex("XFD1"); err != nil || column != MaxColumns-1 {
+		t.Errorf("XFD1: expected %d, got %d %v", MaxColumns-1, column, err)
+	}
+	for _, reference := range []string{"1", "a1", "XFE1", "ZZZZZZZZ1", "ZZZZZZZZZZZZZZZZZZZZ1"} {
+		if _, err := columnIndex(reference); err != ErrInvalidReference {
+			t.Errorf("%s: expected ErrInvalidReference, got %v", reference, err)
+		}
+	}
+}
+
+func TestRead_RejectsInvalidReferences(t *testing.T) {
+	for _, reference := range []string{"1", "ZZZZZZZZ1"} {
+		data := workbook(t, map[string]string{
+			"xl/workbook.xml":          testWorkbook,
+			"xl/worksheets/sheet1.xml": `<worksheet><sheetData><row r="1"><c r="` + reference + `" t="inlineStr"><is><t>john</t></is></c></row></sheetData></worksheet>`,
+		})
+		if _, err := Read(FormatXLSX, data); err != ErrInvalidReference {
+			t.Errorf("%s: expected ErrInvalidReference, got %v", reference, err)
+		}
+	}
+}
+
+func TestWriter_RoundTrip(t *testing.T) {
+	rows := [][]string{
+		{"employee_id", "name", "roles"},
+		{"007", "Jane <Doe> & co", "admin;staff"},
+		{"008", "", "  spaced  "},
+	}
+
+	for _, format := range []string{FormatCSV, FormatXLSX} {
+		buf := new(bytes.Buffer)
+		writer, err := NewWriter(format, buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, row := range rows {
+			if err := writer.Write(row); err != nil {
+				t.Fatalf("%s: write: %v", format, err)
+			}
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatalf("%s: close: %v", format, err)
+		}
+
+		read, err := Read(format, buf.Bytes())
+		if err != nil {
+			t.Fatalf("%s: read: %v", format, err)
+		}
+
+		// Cells are read back trimmed
+		expected := [][]string{rows[0], rows[1], {"008", "", "spaced"}}
+		if !reflect.DeepEqual(read, expected) {
+			t.Errorf("%s: expected %q, got %q", format, expected, read)
+		}
+	}
+}
+
+func TestWriter_Formulas(t *testing.T) {
+	row := []string{"=HYPERLINK(\"http://x\")", "+1", "-2", "@SUM(A1)", "a=b", "6,2"}
+
+	buf := new(bytes.Buffer)
+	writer, _ := NewWriter(FormatCSV, buf)
+	if err := writer.Write(row); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "\"'=HYPERLINK(\"\"http://x\"\")\",'+1,'-2,'@SUM(A1),a=b,\"6,2\"\n"
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+
+	// Inline string cells are text, the workbook keeps the values as they are
+	buf.Reset()
+	writer, _ = NewWriter(FormatXLSX, buf)
+	if err := writer.Write(row); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	read, err := Read(FormatXLSX, buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(read, [][]string{row}) {
+		t.Errorf("expected %q, got %q", row, read)
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	for _, column := range []int{0, 2, 25, 26, 27, 701, 702} {
+		if index, _ := columnIndex(columnName(column) + "1"); index != column {
+			t.Errorf("%d: got %s which reads back as %d", column, columnName(column), index)
+		}
+	}
+}
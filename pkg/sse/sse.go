@@ -0,0 +1,50 @@
+// Package sse writes the text/event-stream format of server-sent events
+package sse
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const ContentType = "text/event-stream"
+
+// Event is a message of the stream. ID is sent back by reconnecting clients in the Last-Event-ID header,
+// Retry tells them how long to wait before reconnecting. An event without Data is not dispatched to
+// listeners, it only sets the ID or the retry of the stream.
+type Event struct {
+	ID    string
+	Name  string
+	Data  string
+	Retry time.Duration
+}
+
+// Write writes the event, every line of Data becomes a data field
+func Write(w io.Writer, event Event) error {
+	var b strings.Builder
+	if event.ID != "" {
+		b.WriteString("id: " + event.ID + "\n")
+	}
+	if event.Name != "" {
+		b.WriteString("event: " + event.Name + "\n")
+	}
+	if event.Retry > 0 {
+		b.WriteString("retry: " + strconv.FormatInt(event.Retry.Milliseconds(), 10) + "\n")
+	}
+	if event.Data != "" {
+		for _, line := range strings.Split(event.Data, "\n") {
+			b.WriteString("data: " + line + "\n")
+		}
+	}
+	b.WriteString("\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteComment writes a line clients ignore, it keeps idle connections from being closed by proxies
+func WriteComment(w io.Writer, comment string) error {
+	_, err := io.WriteString(w, ": "+comment+"\n\n")
+	return err
+}
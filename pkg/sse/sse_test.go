@@ -0,0 +1,42 @@
+package sse
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWrite(t *testing.T) {
+	var b strings.Builder
+	err := Write(&b, Event{ID: "Nzoz", Name: "user.updated", Data: "{\"a\":1}\n{\"b\":2}", Retry: 3 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "id: Nzoz\nevent: user.updated\nretry: 3000\ndata: {\"a\":1}\ndata: {\"b\":2}\n\n"
+	if b.String() != expected {
+		t.Errorf("expected %q, got %q", expected, b.String())
+	}
+}
+
+func TestWrite_WithoutData(t *testing.T) {
+	var b strings.Builder
+	if err := Write(&b, Event{Retry: 1500 * time.Millisecond}); err != nil {
+		t.Fatal(err)
+	}
+
+	if b.String() != "retry: 1500\n\n" {
+		t.Errorf("unexpected event %q", b.String())
+	}
+}
+
+func TestWriteComment(t *testing.T) {
+	var b strings.Builder
+	if err := WriteComment(&b, "ping"); err != nil {
+		t.Fatal(err)
+	}
+
+	if b.String() != ": ping\n\n" {
+		t.Errorf("unexpected comment %q", b.String())
+	}
+}